@@ -32,6 +32,9 @@ type Admin interface {
 	// Chain provide handlers chain for gin
 	Chain() gin.HandlersChain
 
+	CommentRevisions(*web.CommentRevisionsReq) (*web.CommentRevisionsResp, error)
+	ListTrashTweets(*web.ListTrashTweetsReq) (*web.ListTrashTweetsResp, error)
+	ChangeTweetSensitive(*web.ChangeTweetSensitiveReq) error
 	SiteInfo(*web.SiteInfoReq) (*web.SiteInfoResp, error)
 	ChangeUserStatus(*web.ChangeUserStatusReq) error
 
@@ -46,6 +49,48 @@ func RegisterAdminServant(e *gin.Engine, s Admin) {
 	router.Use(middlewares...)
 
 	// register routes info to router
+	router.Handle("GET", "admin/comment/revisions", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.CommentRevisionsReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.CommentRevisions(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("GET", "admin/post/trash", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.ListTrashTweetsReq)
+		var bv _binding_ = req
+		if err := bv.Bind(c); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.ListTrashTweets(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("POST", "admin/post/sensitive", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.ChangeTweetSensitiveReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		s.Render(c, nil, s.ChangeTweetSensitive(req))
+	})
 	router.Handle("GET", "admin/site/status", func(c *gin.Context) {
 		select {
 		case <-c.Request.Context().Done():
@@ -82,6 +127,18 @@ func (UnimplementedAdminServant) Chain() gin.HandlersChain {
 	return nil
 }
 
+func (UnimplementedAdminServant) CommentRevisions(req *web.CommentRevisionsReq) (*web.CommentRevisionsResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedAdminServant) ListTrashTweets(req *web.ListTrashTweetsReq) (*web.ListTrashTweetsResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedAdminServant) ChangeTweetSensitive(req *web.ChangeTweetSensitiveReq) error {
+	return mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
 func (UnimplementedAdminServant) SiteInfo(req *web.SiteInfoReq) (*web.SiteInfoResp, error) {
 	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
 }
@@ -23,9 +23,20 @@ type Core interface {
 	SuggestTags(*web.SuggestTagsReq) (*web.SuggestTagsResp, error)
 	SuggestUsers(*web.SuggestUsersReq) (*web.SuggestUsersResp, error)
 	ChangeAvatar(*web.ChangeAvatarReq) error
+	SubscribeUser(*web.SubscribeUserReq) (*web.SubscribeUserResp, error)
+	ChargeUser(*web.ChargeUserReq) (*web.ChargeUserResp, error)
+	ChangeShowSensitive(*web.ChangeShowSensitiveReq) error
 	ChangeNickname(*web.ChangeNicknameReq) error
+	ResetRecoveryCodes(*web.ResetRecoveryCodesReq) (*web.ResetRecoveryCodesResp, error)
+	DisableTwoFactor(*web.DisableTwoFactorReq) error
+	EnableTwoFactor(*web.EnableTwoFactorReq) (*web.EnableTwoFactorResp, error)
+	SetupTwoFactor(*web.SetupTwoFactorReq) (*web.SetupTwoFactorResp, error)
+	RevokeAllSessions(*web.RevokeAllSessionsReq) error
+	RevokeSession(*web.RevokeSessionReq) error
+	ListSessions(*web.ListSessionsReq) (*web.ListSessionsResp, error)
 	ChangePassword(*web.ChangePasswordReq) error
 	UserPhoneBind(*web.UserPhoneBindReq) error
+	GetMentions(*web.GetMentionsReq) (*web.GetMentionsResp, error)
 	GetStars(*web.GetStarsReq) (*web.GetStarsResp, error)
 	GetCollections(*web.GetCollectionsReq) (*web.GetCollectionsResp, error)
 	SendUserWhisper(*web.SendWhisperReq) error
@@ -119,6 +130,47 @@ func RegisterCoreServant(e *gin.Engine, s Core) {
 		}
 		s.Render(c, nil, s.ChangeAvatar(req))
 	})
+	router.Handle("POST", "user/subscribe", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.SubscribeUserReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.SubscribeUser(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("POST", "user/charge", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.ChargeUserReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.ChargeUser(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("POST", "user/sensitive", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.ChangeShowSensitiveReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		s.Render(c, nil, s.ChangeShowSensitive(req))
+	})
 	router.Handle("POST", "user/nickname", func(c *gin.Context) {
 		select {
 		case <-c.Request.Context().Done():
@@ -132,6 +184,101 @@ func RegisterCoreServant(e *gin.Engine, s Core) {
 		}
 		s.Render(c, nil, s.ChangeNickname(req))
 	})
+	router.Handle("POST", "user/2fa/recovery", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.ResetRecoveryCodesReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.ResetRecoveryCodes(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("POST", "user/2fa/disable", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.DisableTwoFactorReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		s.Render(c, nil, s.DisableTwoFactor(req))
+	})
+	router.Handle("POST", "user/2fa/enable", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.EnableTwoFactorReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.EnableTwoFactor(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("POST", "user/2fa/setup", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.SetupTwoFactorReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.SetupTwoFactor(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("POST", "user/sessions/revoke", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.RevokeAllSessionsReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		s.Render(c, nil, s.RevokeAllSessions(req))
+	})
+	router.Handle("POST", "user/session/revoke", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.RevokeSessionReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		s.Render(c, nil, s.RevokeSession(req))
+	})
+	router.Handle("GET", "user/sessions", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.ListSessionsReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.ListSessions(req)
+		s.Render(c, resp, err)
+	})
 	router.Handle("POST", "user/password", func(c *gin.Context) {
 		select {
 		case <-c.Request.Context().Done():
@@ -158,6 +305,21 @@ func RegisterCoreServant(e *gin.Engine, s Core) {
 		}
 		s.Render(c, nil, s.UserPhoneBind(req))
 	})
+	router.Handle("GET", "user/mentions", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.GetMentionsReq)
+		var bv _binding_ = req
+		if err := bv.Bind(c); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.GetMentions(req)
+		s.Render(c, resp, err)
+	})
 	router.Handle("GET", "user/stars", func(c *gin.Context) {
 		select {
 		case <-c.Request.Context().Done():
@@ -303,10 +465,50 @@ func (UnimplementedCoreServant) ChangeAvatar(req *web.ChangeAvatarReq) error {
 	return mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
 }
 
+func (UnimplementedCoreServant) SubscribeUser(req *web.SubscribeUserReq) (*web.SubscribeUserResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedCoreServant) ChargeUser(req *web.ChargeUserReq) (*web.ChargeUserResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedCoreServant) ChangeShowSensitive(req *web.ChangeShowSensitiveReq) error {
+	return mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
 func (UnimplementedCoreServant) ChangeNickname(req *web.ChangeNicknameReq) error {
 	return mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
 }
 
+func (UnimplementedCoreServant) ResetRecoveryCodes(req *web.ResetRecoveryCodesReq) (*web.ResetRecoveryCodesResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedCoreServant) DisableTwoFactor(req *web.DisableTwoFactorReq) error {
+	return mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedCoreServant) EnableTwoFactor(req *web.EnableTwoFactorReq) (*web.EnableTwoFactorResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedCoreServant) SetupTwoFactor(req *web.SetupTwoFactorReq) (*web.SetupTwoFactorResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedCoreServant) RevokeAllSessions(req *web.RevokeAllSessionsReq) error {
+	return mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedCoreServant) RevokeSession(req *web.RevokeSessionReq) error {
+	return mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedCoreServant) ListSessions(req *web.ListSessionsReq) (*web.ListSessionsResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
 func (UnimplementedCoreServant) ChangePassword(req *web.ChangePasswordReq) error {
 	return mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
 }
@@ -315,6 +517,10 @@ func (UnimplementedCoreServant) UserPhoneBind(req *web.UserPhoneBindReq) error {
 	return mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
 }
 
+func (UnimplementedCoreServant) GetMentions(req *web.GetMentionsReq) (*web.GetMentionsResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
 func (UnimplementedCoreServant) GetStars(req *web.GetStarsReq) (*web.GetStarsResp, error) {
 	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
 }
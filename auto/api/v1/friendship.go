@@ -18,6 +18,11 @@ type Friendship interface {
 	// Chain provide handlers chain for gin
 	Chain() gin.HandlersChain
 
+	SetContactGroup(*web.SetContactGroupReq) error
+	DeleteContactGroup(*web.DeleteContactGroupReq) error
+	RenameContactGroup(*web.RenameContactGroupReq) error
+	CreateContactGroup(*web.CreateContactGroupReq) (*web.CreateContactGroupResp, error)
+	ListContactGroups(*web.ListContactGroupsReq) (*web.ListContactGroupsResp, error)
 	GetContacts(*web.GetContactsReq) (*web.GetContactsResp, error)
 	DeleteFriend(*web.DeleteFriendReq) error
 	RejectFriend(*web.RejectFriendReq) error
@@ -35,6 +40,73 @@ func RegisterFriendshipServant(e *gin.Engine, s Friendship) {
 	router.Use(middlewares...)
 
 	// register routes info to router
+	router.Handle("POST", "friend/group/assign", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.SetContactGroupReq)
+		if err := s.BindJson(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		s.Render(c, nil, s.SetContactGroup(req))
+	})
+	router.Handle("POST", "friend/group/delete", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.DeleteContactGroupReq)
+		if err := s.BindJson(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		s.Render(c, nil, s.DeleteContactGroup(req))
+	})
+	router.Handle("POST", "friend/group/rename", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.RenameContactGroupReq)
+		if err := s.BindJson(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		s.Render(c, nil, s.RenameContactGroup(req))
+	})
+	router.Handle("POST", "friend/group/create", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.CreateContactGroupReq)
+		if err := s.BindJson(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.CreateContactGroup(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("GET", "friend/groups", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.ListContactGroupsReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.ListContactGroups(req)
+		s.Render(c, resp, err)
+	})
 	router.Handle("GET", "user/contacts", func(c *gin.Context) {
 		select {
 		case <-c.Request.Context().Done():
@@ -110,6 +182,26 @@ func (UnimplementedFriendshipServant) Chain() gin.HandlersChain {
 	return nil
 }
 
+func (UnimplementedFriendshipServant) SetContactGroup(req *web.SetContactGroupReq) error {
+	return mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedFriendshipServant) DeleteContactGroup(req *web.DeleteContactGroupReq) error {
+	return mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedFriendshipServant) RenameContactGroup(req *web.RenameContactGroupReq) error {
+	return mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedFriendshipServant) CreateContactGroup(req *web.CreateContactGroupReq) (*web.CreateContactGroupResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedFriendshipServant) ListContactGroups(req *web.ListContactGroupsReq) (*web.ListContactGroupsResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
 func (UnimplementedFriendshipServant) GetContacts(req *web.GetContactsReq) (*web.GetContactsResp, error) {
 	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
 }
@@ -18,10 +18,16 @@ type Loose interface {
 	// Chain provide handlers chain for gin
 	Chain() gin.HandlersChain
 
+	TweetThread(*web.TweetThreadReq) (*web.TweetThreadResp, error)
+	TweetRevisions(*web.TweetRevisionsReq) (*web.TweetRevisionsResp, error)
+	TweetViews(*web.TweetViewsReq) error
 	TweetDetail(*web.TweetDetailReq) (*web.TweetDetailResp, error)
+	CommentReplies(*web.CommentRepliesReq) (*web.CommentRepliesResp, error)
 	TweetComments(*web.TweetCommentsReq) (*web.TweetCommentsResp, error)
 	TopicList(*web.TopicListReq) (*web.TopicListResp, error)
 	GetUserProfile(*web.GetUserProfileReq) (*web.GetUserProfileResp, error)
+	FolderTweets(*web.FolderTweetsReq) (*web.FolderTweetsResp, error)
+	CollectionFolders(*web.CollectionFoldersReq) (*web.CollectionFoldersResp, error)
 	GetUserTweets(*web.GetUserTweetsReq) (*web.GetUserTweetsResp, error)
 	Timeline(*web.TimelineReq) (*web.TimelineResp, error)
 
@@ -36,6 +42,48 @@ func RegisterLooseServant(e *gin.Engine, s Loose) {
 	router.Use(middlewares...)
 
 	// register routes info to router
+	router.Handle("GET", "post/thread", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.TweetThreadReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.TweetThread(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("GET", "post/revisions", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.TweetRevisionsReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.TweetRevisions(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("POST", "post/views", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.TweetViewsReq)
+		var bv _binding_ = req
+		if err := bv.Bind(c); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		s.Render(c, nil, s.TweetViews(req))
+	})
 	router.Handle("GET", "post", func(c *gin.Context) {
 		select {
 		case <-c.Request.Context().Done():
@@ -43,13 +91,28 @@ func RegisterLooseServant(e *gin.Engine, s Loose) {
 		default:
 		}
 		req := new(web.TweetDetailReq)
-		if err := s.Bind(c, req); err != nil {
+		var bv _binding_ = req
+		if err := bv.Bind(c); err != nil {
 			s.Render(c, nil, err)
 			return
 		}
 		resp, err := s.TweetDetail(req)
 		s.Render(c, resp, err)
 	})
+	router.Handle("GET", "post/comment/replies", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.CommentRepliesReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.CommentReplies(req)
+		s.Render(c, resp, err)
+	})
 	router.Handle("GET", "post/comments", func(c *gin.Context) {
 		select {
 		case <-c.Request.Context().Done():
@@ -97,6 +160,34 @@ func RegisterLooseServant(e *gin.Engine, s Loose) {
 		resp, err := s.GetUserProfile(req)
 		s.Render(c, resp, err)
 	})
+	router.Handle("GET", "collection/folder/posts", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.FolderTweetsReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.FolderTweets(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("GET", "user/collection/folders", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.CollectionFoldersReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.CollectionFolders(req)
+		s.Render(c, resp, err)
+	})
 	router.Handle("GET", "user/posts", func(c *gin.Context) {
 		select {
 		case <-c.Request.Context().Done():
@@ -145,10 +236,26 @@ func (UnimplementedLooseServant) Chain() gin.HandlersChain {
 	return nil
 }
 
+func (UnimplementedLooseServant) TweetThread(req *web.TweetThreadReq) (*web.TweetThreadResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedLooseServant) TweetRevisions(req *web.TweetRevisionsReq) (*web.TweetRevisionsResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedLooseServant) TweetViews(req *web.TweetViewsReq) error {
+	return mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
 func (UnimplementedLooseServant) TweetDetail(req *web.TweetDetailReq) (*web.TweetDetailResp, error) {
 	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
 }
 
+func (UnimplementedLooseServant) CommentReplies(req *web.CommentRepliesReq) (*web.CommentRepliesResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
 func (UnimplementedLooseServant) TweetComments(req *web.TweetCommentsReq) (*web.TweetCommentsResp, error) {
 	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
 }
@@ -161,6 +268,14 @@ func (UnimplementedLooseServant) GetUserProfile(req *web.GetUserProfileReq) (*we
 	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
 }
 
+func (UnimplementedLooseServant) FolderTweets(req *web.FolderTweetsReq) (*web.FolderTweetsResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedLooseServant) CollectionFolders(req *web.CollectionFoldersReq) (*web.CollectionFoldersResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
 func (UnimplementedLooseServant) GetUserTweets(req *web.GetUserTweetsReq) (*web.GetUserTweetsResp, error) {
 	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
 }
@@ -26,18 +26,42 @@ type Priv interface {
 	ThumbsUpTweetReply(*web.TweetReplyThumbsReq) error
 	ThumbsDownTweetComment(*web.TweetCommentThumbsReq) error
 	ThumbsUpTweetComment(*web.TweetCommentThumbsReq) error
+	EditCommentReply(*web.EditCommentReplyReq) (*web.EditCommentReplyResp, error)
 	DeleteCommentReply(*web.DeleteCommentReplyReq) error
 	CreateCommentReply(*web.CreateCommentReplyReq) (*web.CreateCommentReplyResp, error)
 	HighlightComment(*web.HighlightCommentReq) (*web.HighlightCommentResp, error)
+	EditComment(*web.EditCommentReq) (*web.EditCommentResp, error)
 	DeleteComment(*web.DeleteCommentReq) error
 	CreateComment(*web.CreateCommentReq) (*web.CreateCommentResp, error)
 	VisibleTweet(*web.VisibleTweetReq) (*web.VisibleTweetResp, error)
 	HighlightTweet(*web.HighlightTweetReq) (*web.HighlightTweetResp, error)
+	PinTweet(*web.PinTweetReq) (*web.PinTweetResp, error)
 	StickTweet(*web.StickTweetReq) (*web.StickTweetResp, error)
 	LockTweet(*web.LockTweetReq) (*web.LockTweetResp, error)
+	DeleteCollectionFolder(*web.DeleteCollectionFolderReq) error
+	UpdateCollectionFolder(*web.UpdateCollectionFolderReq) (*web.UpdateCollectionFolderResp, error)
+	CreateCollectionFolder(*web.CreateCollectionFolderReq) (*web.CreateCollectionFolderResp, error)
+	MoveCollectionTweet(*web.MoveCollectionTweetReq) error
 	CollectionTweet(*web.CollectionTweetReq) (*web.CollectionTweetResp, error)
 	StarTweet(*web.StarTweetReq) (*web.StarTweetResp, error)
 	DeleteTweet(*web.DeleteTweetReq) error
+	DeleteCommentReaction(*web.DeleteCommentReactionReq) (*web.DeleteCommentReactionResp, error)
+	CreateCommentReaction(*web.CreateCommentReactionReq) (*web.CreateCommentReactionResp, error)
+	DeleteTweetReaction(*web.DeleteTweetReactionReq) (*web.DeleteTweetReactionResp, error)
+	CreateTweetReaction(*web.CreateTweetReactionReq) (*web.CreateTweetReactionResp, error)
+	VotePoll(*web.VotePollReq) (*web.VotePollResp, error)
+	PublishDraft(*web.PublishDraftReq) (*web.PublishDraftResp, error)
+	DeleteDraft(*web.DeleteDraftReq) error
+	UpdateDraft(*web.UpdateDraftReq) (*web.UpdateDraftResp, error)
+	CreateDraft(*web.CreateDraftReq) (*web.CreateDraftResp, error)
+	RestoreTweet(*web.RestoreTweetReq) (*web.RestoreTweetResp, error)
+	TrashTweets(*web.TrashTweetsReq) (*web.TrashTweetsResp, error)
+	Drafts(*web.DraftsReq) (*web.DraftsResp, error)
+	CancelScheduledTweet(*web.CancelScheduledTweetReq) error
+	RescheduleTweet(*web.RescheduleTweetReq) (*web.RescheduleTweetResp, error)
+	ArchivedTweets(*web.ArchivedTweetsReq) (*web.ArchivedTweetsResp, error)
+	ScheduledTweets(*web.ScheduledTweetsReq) (*web.ScheduledTweetsResp, error)
+	EditTweet(*web.EditTweetReq) (*web.EditTweetResp, error)
 	CreateTweet(*web.CreateTweetReq) (*web.CreateTweetResp, error)
 	DownloadAttachment(*web.DownloadAttachmentReq) (*web.DownloadAttachmentResp, error)
 	DownloadAttachmentPrecheck(*web.DownloadAttachmentPrecheckReq) (*web.DownloadAttachmentPrecheckResp, error)
@@ -47,6 +71,8 @@ type Priv interface {
 }
 
 type PrivChain interface {
+	ChainPublishDraft() gin.HandlersChain
+	ChainEditTweet() gin.HandlersChain
 	ChainCreateTweet() gin.HandlersChain
 
 	mustEmbedUnimplementedPrivChain()
@@ -172,6 +198,20 @@ func RegisterPrivServant(e *gin.Engine, s Priv, m ...PrivChain) {
 		}
 		s.Render(c, nil, s.ThumbsUpTweetComment(req))
 	})
+	router.Handle("POST", "post/comment/reply/edit", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.EditCommentReplyReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.EditCommentReply(req)
+		s.Render(c, resp, err)
+	})
 	router.Handle("DELETE", "post/comment/reply", func(c *gin.Context) {
 		select {
 		case <-c.Request.Context().Done():
@@ -214,6 +254,20 @@ func RegisterPrivServant(e *gin.Engine, s Priv, m ...PrivChain) {
 		resp, err := s.HighlightComment(req)
 		s.Render(c, resp, err)
 	})
+	router.Handle("POST", "post/comment/edit", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.EditCommentReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.EditComment(req)
+		s.Render(c, resp, err)
+	})
 	router.Handle("DELETE", "post/comment", func(c *gin.Context) {
 		select {
 		case <-c.Request.Context().Done():
@@ -270,6 +324,20 @@ func RegisterPrivServant(e *gin.Engine, s Priv, m ...PrivChain) {
 		resp, err := s.HighlightTweet(req)
 		s.Render(c, resp, err)
 	})
+	router.Handle("POST", "post/pin", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.PinTweetReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.PinTweet(req)
+		s.Render(c, resp, err)
+	})
 	router.Handle("POST", "post/stick", func(c *gin.Context) {
 		select {
 		case <-c.Request.Context().Done():
@@ -298,6 +366,60 @@ func RegisterPrivServant(e *gin.Engine, s Priv, m ...PrivChain) {
 		resp, err := s.LockTweet(req)
 		s.Render(c, resp, err)
 	})
+	router.Handle("DELETE", "collection/folder", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.DeleteCollectionFolderReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		s.Render(c, nil, s.DeleteCollectionFolder(req))
+	})
+	router.Handle("POST", "collection/folder/update", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.UpdateCollectionFolderReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.UpdateCollectionFolder(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("POST", "collection/folder", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.CreateCollectionFolderReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.CreateCollectionFolder(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("POST", "post/collection/move", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.MoveCollectionTweetReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		s.Render(c, nil, s.MoveCollectionTweet(req))
+	})
 	router.Handle("POST", "post/collection", func(c *gin.Context) {
 		select {
 		case <-c.Request.Context().Done():
@@ -339,6 +461,257 @@ func RegisterPrivServant(e *gin.Engine, s Priv, m ...PrivChain) {
 		}
 		s.Render(c, nil, s.DeleteTweet(req))
 	})
+	router.Handle("DELETE", "post/comment/reaction", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.DeleteCommentReactionReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.DeleteCommentReaction(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("POST", "post/comment/reaction", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.CreateCommentReactionReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.CreateCommentReaction(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("DELETE", "post/reaction", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.DeleteTweetReactionReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.DeleteTweetReaction(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("POST", "post/reaction", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.CreateTweetReactionReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.CreateTweetReaction(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("POST", "post/poll/vote", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.VotePollReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.VotePoll(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("POST", "post/draft/publish", append(cc.ChainPublishDraft(), func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.PublishDraftReq)
+		var bv _binding_ = req
+		if err := bv.Bind(c); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.PublishDraft(req)
+		if err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		var rv _render_ = resp
+		rv.Render(c)
+	})...)
+	router.Handle("DELETE", "post/draft", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.DeleteDraftReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		s.Render(c, nil, s.DeleteDraft(req))
+	})
+	router.Handle("POST", "post/draft/update", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.UpdateDraftReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.UpdateDraft(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("POST", "post/draft", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.CreateDraftReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.CreateDraft(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("POST", "post/restore", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.RestoreTweetReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.RestoreTweet(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("GET", "post/trash", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.TrashTweetsReq)
+		var bv _binding_ = req
+		if err := bv.Bind(c); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.TrashTweets(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("GET", "post/drafts", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.DraftsReq)
+		var bv _binding_ = req
+		if err := bv.Bind(c); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.Drafts(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("DELETE", "post/schedule", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.CancelScheduledTweetReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		s.Render(c, nil, s.CancelScheduledTweet(req))
+	})
+	router.Handle("POST", "post/schedule", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.RescheduleTweetReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.RescheduleTweet(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("GET", "post/archive", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.ArchivedTweetsReq)
+		var bv _binding_ = req
+		if err := bv.Bind(c); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.ArchivedTweets(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("GET", "post/scheduled", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.ScheduledTweetsReq)
+		var bv _binding_ = req
+		if err := bv.Bind(c); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.ScheduledTweets(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("POST", "post/edit", append(cc.ChainEditTweet(), func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.EditTweetReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.EditTweet(req)
+		if err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		var rv _render_ = resp
+		rv.Render(c)
+	})...)
 	router.Handle("POST", "post", append(cc.ChainCreateTweet(), func(c *gin.Context) {
 		select {
 		case <-c.Request.Context().Done():
@@ -445,6 +818,10 @@ func (UnimplementedPrivServant) ThumbsUpTweetComment(req *web.TweetCommentThumbs
 	return mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
 }
 
+func (UnimplementedPrivServant) EditCommentReply(req *web.EditCommentReplyReq) (*web.EditCommentReplyResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
 func (UnimplementedPrivServant) DeleteCommentReply(req *web.DeleteCommentReplyReq) error {
 	return mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
 }
@@ -457,6 +834,10 @@ func (UnimplementedPrivServant) HighlightComment(req *web.HighlightCommentReq) (
 	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
 }
 
+func (UnimplementedPrivServant) EditComment(req *web.EditCommentReq) (*web.EditCommentResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
 func (UnimplementedPrivServant) DeleteComment(req *web.DeleteCommentReq) error {
 	return mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
 }
@@ -473,6 +854,10 @@ func (UnimplementedPrivServant) HighlightTweet(req *web.HighlightTweetReq) (*web
 	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
 }
 
+func (UnimplementedPrivServant) PinTweet(req *web.PinTweetReq) (*web.PinTweetResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
 func (UnimplementedPrivServant) StickTweet(req *web.StickTweetReq) (*web.StickTweetResp, error) {
 	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
 }
@@ -481,6 +866,22 @@ func (UnimplementedPrivServant) LockTweet(req *web.LockTweetReq) (*web.LockTweet
 	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
 }
 
+func (UnimplementedPrivServant) DeleteCollectionFolder(req *web.DeleteCollectionFolderReq) error {
+	return mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedPrivServant) UpdateCollectionFolder(req *web.UpdateCollectionFolderReq) (*web.UpdateCollectionFolderResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedPrivServant) CreateCollectionFolder(req *web.CreateCollectionFolderReq) (*web.CreateCollectionFolderResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedPrivServant) MoveCollectionTweet(req *web.MoveCollectionTweetReq) error {
+	return mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
 func (UnimplementedPrivServant) CollectionTweet(req *web.CollectionTweetReq) (*web.CollectionTweetResp, error) {
 	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
 }
@@ -493,6 +894,74 @@ func (UnimplementedPrivServant) DeleteTweet(req *web.DeleteTweetReq) error {
 	return mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
 }
 
+func (UnimplementedPrivServant) DeleteCommentReaction(req *web.DeleteCommentReactionReq) (*web.DeleteCommentReactionResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedPrivServant) CreateCommentReaction(req *web.CreateCommentReactionReq) (*web.CreateCommentReactionResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedPrivServant) DeleteTweetReaction(req *web.DeleteTweetReactionReq) (*web.DeleteTweetReactionResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedPrivServant) CreateTweetReaction(req *web.CreateTweetReactionReq) (*web.CreateTweetReactionResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedPrivServant) VotePoll(req *web.VotePollReq) (*web.VotePollResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedPrivServant) PublishDraft(req *web.PublishDraftReq) (*web.PublishDraftResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedPrivServant) DeleteDraft(req *web.DeleteDraftReq) error {
+	return mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedPrivServant) UpdateDraft(req *web.UpdateDraftReq) (*web.UpdateDraftResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedPrivServant) CreateDraft(req *web.CreateDraftReq) (*web.CreateDraftResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedPrivServant) RestoreTweet(req *web.RestoreTweetReq) (*web.RestoreTweetResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedPrivServant) TrashTweets(req *web.TrashTweetsReq) (*web.TrashTweetsResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedPrivServant) Drafts(req *web.DraftsReq) (*web.DraftsResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedPrivServant) CancelScheduledTweet(req *web.CancelScheduledTweetReq) error {
+	return mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedPrivServant) RescheduleTweet(req *web.RescheduleTweetReq) (*web.RescheduleTweetResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedPrivServant) ArchivedTweets(req *web.ArchivedTweetsReq) (*web.ArchivedTweetsResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedPrivServant) ScheduledTweets(req *web.ScheduledTweetsReq) (*web.ScheduledTweetsResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedPrivServant) EditTweet(req *web.EditTweetReq) (*web.EditTweetResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
 func (UnimplementedPrivServant) CreateTweet(req *web.CreateTweetReq) (*web.CreateTweetResp, error) {
 	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
 }
@@ -514,6 +983,14 @@ func (UnimplementedPrivServant) mustEmbedUnimplementedPrivServant() {}
 // UnimplementedPrivChain can be embedded to have forward compatible implementations.
 type UnimplementedPrivChain struct{}
 
+func (b *UnimplementedPrivChain) ChainPublishDraft() gin.HandlersChain {
+	return nil
+}
+
+func (b *UnimplementedPrivChain) ChainEditTweet() gin.HandlersChain {
+	return nil
+}
+
 func (b *UnimplementedPrivChain) ChainCreateTweet() gin.HandlersChain {
 	return nil
 }
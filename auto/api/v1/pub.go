@@ -18,6 +18,11 @@ type Pub interface {
 	SendCaptcha(*web.SendCaptchaReq) error
 	GetCaptcha() (*web.GetCaptchaResp, error)
 	Register(*web.RegisterReq) (*web.RegisterResp, error)
+	ResetPassword(*web.ResetPasswordReq) error
+	ForgotPassword(*web.ForgotPasswordReq) (*web.ForgotPasswordResp, error)
+	TwoFactorLogin(*web.TwoFactorLoginReq) (*web.TwoFactorLoginResp, error)
+	TwoFactorSetup(*web.TwoFactorSetupReq) (*web.TwoFactorSetupResp, error)
+	RefreshToken(*web.RefreshTokenReq) (*web.RefreshTokenResp, error)
 	Login(*web.LoginReq) (*web.LoginResp, error)
 	Version() (*web.VersionResp, error)
 
@@ -66,6 +71,77 @@ func RegisterPubServant(e *gin.Engine, s Pub) {
 		resp, err := s.Register(req)
 		s.Render(c, resp, err)
 	})
+	router.Handle("POST", "/auth/password/reset", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.ResetPasswordReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		s.Render(c, nil, s.ResetPassword(req))
+	})
+	router.Handle("POST", "/auth/password/forgot", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.ForgotPasswordReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.ForgotPassword(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("POST", "/auth/2fa/login", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.TwoFactorLoginReq)
+		var bv _binding_ = req
+		if err := bv.Bind(c); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.TwoFactorLogin(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("POST", "/auth/2fa/setup", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.TwoFactorSetupReq)
+		if err := s.Bind(c, req); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.TwoFactorSetup(req)
+		s.Render(c, resp, err)
+	})
+	router.Handle("POST", "/auth/refresh", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		default:
+		}
+		req := new(web.RefreshTokenReq)
+		var bv _binding_ = req
+		if err := bv.Bind(c); err != nil {
+			s.Render(c, nil, err)
+			return
+		}
+		resp, err := s.RefreshToken(req)
+		s.Render(c, resp, err)
+	})
 	router.Handle("POST", "/auth/login", func(c *gin.Context) {
 		select {
 		case <-c.Request.Context().Done():
@@ -73,7 +149,8 @@ func RegisterPubServant(e *gin.Engine, s Pub) {
 		default:
 		}
 		req := new(web.LoginReq)
-		if err := s.Bind(c, req); err != nil {
+		var bv _binding_ = req
+		if err := bv.Bind(c); err != nil {
 			s.Render(c, nil, err)
 			return
 		}
@@ -107,6 +184,26 @@ func (UnimplementedPubServant) Register(req *web.RegisterReq) (*web.RegisterResp
 	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
 }
 
+func (UnimplementedPubServant) ResetPassword(req *web.ResetPasswordReq) error {
+	return mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedPubServant) ForgotPassword(req *web.ForgotPasswordReq) (*web.ForgotPasswordResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedPubServant) TwoFactorLogin(req *web.TwoFactorLoginReq) (*web.TwoFactorLoginResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedPubServant) TwoFactorSetup(req *web.TwoFactorSetupReq) (*web.TwoFactorSetupResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
+func (UnimplementedPubServant) RefreshToken(req *web.RefreshTokenReq) (*web.RefreshTokenResp, error) {
+	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
+}
+
 func (UnimplementedPubServant) Login(req *web.LoginReq) (*web.LoginResp, error) {
 	return nil, mir.Errorln(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented))
 }
@@ -35,6 +35,7 @@ const (
 	PrefixUserProfile        = "paopao:user:profile:"
 	PrefixUserInfoById       = "paopao:user:info:id:"
 	PrefixUserInfoByName     = "paopao:user:info:name:"
+	PrefixUserSession        = "paopao:user:session:"
 	prefixUserProfileByName  = "paopao:user:profile:name:"
 	PrefixMyFriendIds        = "paopao:myfriendids:"
 	PrefixMyFollowIds        = "paopao:myfollowids:"
@@ -53,6 +54,7 @@ var (
 	KeyUserInfoById      cache.KeyPool[int64]
 	KeyUserInfoByName    cache.KeyPool[string]
 	KeyUserProfileByName cache.KeyPool[string]
+	KeyUserSession       cache.KeyPool[int64]
 	KeyMyFriendIds       cache.KeyPool[int64]
 	KeyMyFollowIds       cache.KeyPool[int64]
 )
@@ -70,6 +72,7 @@ func initCacheKeyPool() {
 	KeyUserInfoById = intKeyPool[int64](poolSize, PrefixUserInfoById)
 	KeyUserInfoByName = strKeyPool(poolSize, PrefixUserInfoByName)
 	KeyUserProfileByName = strKeyPool(poolSize, prefixUserProfileByName)
+	KeyUserSession = intKeyPool[int64](poolSize, PrefixUserSession)
 	KeyMyFriendIds = intKeyPool[int64](poolSize, PrefixMyFriendIds)
 	KeyMyFollowIds = intKeyPool[int64](poolSize, PrefixMyFollowIds)
 }
@@ -38,6 +38,7 @@ var (
 	MobileServerSetting     *grpcServerConf
 	AppSetting              *appConf
 	CacheSetting            *cacheConf
+	LinkPreviewSetting      *linkPreviewConf
 	EventManagerSetting     *eventManagerConf
 	MetricManagerSetting    *metricManagerConf
 	JobManagerSetting       *jobManagerConf
@@ -80,6 +81,7 @@ func setupSetting(suite []string, noDefault bool) error {
 		"App":               &AppSetting,
 		"SmsType":           &SmsType,
 		"Cache":             &CacheSetting,
+		"LinkPreview":       &LinkPreviewSetting,
 		"EventManager":      &EventManagerSetting,
 		"MetricManager":     &MetricManagerSetting,
 		"JobManager":        &JobManagerSetting,
@@ -137,6 +139,8 @@ func setupSetting(suite []string, noDefault bool) error {
 	EventManagerSetting.MaxIdleTime *= time.Second
 	MetricManagerSetting.MaxIdleTime *= time.Second
 	JWTSetting.Expire *= time.Second
+	JWTSetting.RefreshExpire *= time.Second
+	LinkPreviewSetting.Timeout *= time.Second
 	SimpleCacheIndexSetting.CheckTickDuration *= time.Second
 	SimpleCacheIndexSetting.ExpireTickDuration *= time.Second
 	BigCacheIndexSetting.ExpireInSecond *= time.Second
@@ -18,32 +18,49 @@ var (
 )
 
 const (
-	TableAnouncement        = "user"
-	TableAnouncementContent = "anouncement_content"
-	TableAttachment         = "attachment"
-	TableCaptcha            = "captcha"
-	TableComment            = "comment"
-	TableCommentMetric      = "comment_metric"
-	TableCommentContent     = "comment_content"
-	TableCommentReply       = "comment_reply"
-	TableFollowing          = "following"
-	TableContact            = "contact"
-	TableContactGroup       = "contact_group"
-	TableMessage            = "message"
-	TablePost               = "post"
-	TablePostMetric         = "post_metric"
-	TablePostByComment      = "post_by_comment"
-	TablePostByMedia        = "post_by_media"
-	TablePostAttachmentBill = "post_attachment_bill"
-	TablePostCollection     = "post_collection"
-	TablePostContent        = "post_content"
-	TablePostStar           = "post_star"
-	TableTag                = "tag"
-	TableUser               = "user"
-	TableUserRelation       = "user_relation"
-	TableUserMetric         = "user_metric"
-	TableWalletRecharge     = "wallet_recharge"
-	TableWalletStatement    = "wallet_statement"
+	TableAnouncement         = "user"
+	TableAnouncementContent  = "anouncement_content"
+	TableAttachment          = "attachment"
+	TableCaptcha             = "captcha"
+	TableComment             = "comment"
+	TableCommentMetric       = "comment_metric"
+	TableCommentContent      = "comment_content"
+	TableCommentReply        = "comment_reply"
+	TableCommentReaction     = "comment_reaction"
+	TableCommentRevision     = "comment_revision"
+	TableFollowing           = "following"
+	TableContact             = "contact"
+	TableContactGroup        = "contact_group"
+	TableLinkPreview         = "link_preview"
+	TableMention             = "mention"
+	TableMessage             = "message"
+	TablePost                = "post"
+	TablePostMetric          = "post_metric"
+	TablePostByComment       = "post_by_comment"
+	TablePostByMedia         = "post_by_media"
+	TablePostAttachmentBill  = "post_attachment_bill"
+	TablePostAudience        = "post_audience"
+	TablePostCollection      = "post_collection"
+	TableCollectionFolder    = "post_collection_folder"
+	TablePostContent         = "post_content"
+	TablePostContentRevision = "post_content_revision"
+	TablePostDraft           = "post_draft"
+	TablePostPoll            = "post_poll"
+	TablePostPollOption      = "post_poll_option"
+	TablePostPollVote        = "post_poll_vote"
+	TablePostReaction        = "post_reaction"
+	TablePostStar            = "post_star"
+	TablePostTrash           = "post_trash"
+	TableTag                 = "tag"
+	TableUser                = "user"
+	TableUserRelation        = "user_relation"
+	TableUserMetric          = "user_metric"
+	TableUserSponsor         = "user_sponsor"
+	TableUserSession         = "user_session"
+	TableUserOTP             = "user_otp"
+	TableUserRecoveryCode    = "user_recovery_code"
+	TableWalletRecharge      = "wallet_recharge"
+	TableWalletStatement     = "wallet_statement"
 )
 
 type TableNameMap map[string]string
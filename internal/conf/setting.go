@@ -103,12 +103,37 @@ type appConf struct {
 	MaxWhisperDaily       int64
 	MaxCaptchaTimes       int
 	AttachmentIncomeRate  float64
+	SponsorIncomeRate     float64
+	MinChargeAmount       int64
+	SubscriptionPrice     int64
+	SubscriptionDays      int
+	TeaserLength          int
 	DefaultContextTimeout time.Duration
 	DefaultPageSize       int
 	MaxPageSize           int
+	DraftExpireDays       int
+	TrashRetentionDays    int
+	TweetViewWindow       int64
+	MaxPinnedTweets       int64
+	MaxContactGroups      int64
+	StoryDefaultTTL       int64
+	StoryMaxTTL           int64
+	PreviewRepliesSize    int
+	CommentEditWindow     int64
+	TwoFactorIssuer       string
+	AdminTwoFactor        bool
 	UserPhoneLimitation   int
 }
 
+type linkPreviewConf struct {
+	Enable        bool
+	Timeout       time.Duration
+	MaxBodySize   int64
+	MaxImageSize  int64
+	RehostImage   bool
+	RetryInterval int64
+}
+
 type cacheConf struct {
 	KeyPoolSize          int
 	CientSideCacheExpire time.Duration
@@ -122,6 +147,7 @@ type cacheConf struct {
 	UserInfoExpire       int64
 	UserProfileExpire    int64
 	UserRelationExpire   int64
+	UserSessionExpire    int64
 }
 
 type eventManagerConf struct {
@@ -141,8 +167,14 @@ type metricManagerConf struct {
 }
 
 type jobManagerConf struct {
-	MaxOnlineInterval     string
-	UpdateMetricsInterval string
+	MaxOnlineInterval        string
+	UpdateMetricsInterval    string
+	PublishScheduledInterval string
+	CleanupDraftsInterval    string
+	PurgeTrashInterval       string
+	ClosePollsInterval       string
+	FlushTweetViewsInterval  string
+	ExpireTweetsInterval     string
 }
 
 type cacheIndexConf struct {
@@ -296,30 +328,32 @@ type redisConf struct {
 }
 
 type jwtConf struct {
-	Secret string
-	Issuer string
-	Expire time.Duration
+	Secret        string
+	Issuer        string
+	Expire        time.Duration
+	RefreshExpire time.Duration
 }
 
 type WebProfileConf struct {
-	UseFriendship             bool   `json:"use_friendship"`
-	EnableTrendsBar           bool   `json:"enable_trends_bar"`
-	EnableWallet              bool   `json:"enable_wallet"`
-	AllowTweetAttachment      bool   `json:"allow_tweet_attachment"`
-	AllowTweetAttachmentPrice bool   `json:"allow_tweet_attachment_price"`
-	AllowTweetVideo           bool   `json:"allow_tweet_video"`
-	AllowUserRegister         bool   `json:"allow_user_register"`
-	AllowPhoneBind            bool   `json:"allow_phone_bind"`
-	DefaultTweetMaxLength     int    `json:"default_tweet_max_length"`
-	TweetWebEllipsisSize      int    `json:"tweet_web_ellipsis_size"`
-	TweetMobileEllipsisSize   int    `json:"tweet_mobile_ellipsis_size"`
-	DefaultTweetVisibility    string `json:"default_tweet_visibility"`
-	DefaultMsgLoopInterval    int    `json:"default_msg_loop_interval"`
-	CopyrightTop              string `json:"copyright_top"`
-	CopyrightLeft             string `json:"copyright_left"`
-	CopyrightLeftLink         string `json:"copyright_left_link"`
-	CopyrightRight            string `json:"copyright_right"`
-	CopyrightRightLink        string `json:"copyright_right_link"`
+	UseFriendship             bool     `json:"use_friendship"`
+	EnableTrendsBar           bool     `json:"enable_trends_bar"`
+	EnableWallet              bool     `json:"enable_wallet"`
+	AllowTweetAttachment      bool     `json:"allow_tweet_attachment"`
+	AllowTweetAttachmentPrice bool     `json:"allow_tweet_attachment_price"`
+	AllowTweetVideo           bool     `json:"allow_tweet_video"`
+	AllowUserRegister         bool     `json:"allow_user_register"`
+	AllowPhoneBind            bool     `json:"allow_phone_bind"`
+	DefaultTweetMaxLength     int      `json:"default_tweet_max_length"`
+	TweetWebEllipsisSize      int      `json:"tweet_web_ellipsis_size"`
+	TweetMobileEllipsisSize   int      `json:"tweet_mobile_ellipsis_size"`
+	DefaultTweetVisibility    string   `json:"default_tweet_visibility"`
+	DefaultMsgLoopInterval    int      `json:"default_msg_loop_interval"`
+	CopyrightTop              string   `json:"copyright_top"`
+	CopyrightLeft             string   `json:"copyright_left"`
+	CopyrightLeftLink         string   `json:"copyright_left_link"`
+	CopyrightRight            string   `json:"copyright_right"`
+	CopyrightRightLink        string   `json:"copyright_right_link"`
+	Reactions                 []string `json:"reactions"`
 }
 
 func (s *httpServerConf) GetReadTimeout() time.Duration {
@@ -394,22 +428,39 @@ func (s *databaseConf) TableNames() (res TableNameMap) {
 		TableCommentMetric,
 		TableCommentContent,
 		TableCommentReply,
+		TableCommentReaction,
+		TableCommentRevision,
 		TableFollowing,
 		TableContact,
 		TableContactGroup,
+		TableLinkPreview,
+		TableMention,
 		TableMessage,
 		TablePost,
 		TablePostMetric,
 		TablePostByComment,
 		TablePostByMedia,
 		TablePostAttachmentBill,
+		TablePostAudience,
 		TablePostCollection,
+		TableCollectionFolder,
 		TablePostContent,
+		TablePostContentRevision,
+		TablePostDraft,
+		TablePostPoll,
+		TablePostPollOption,
+		TablePostPollVote,
+		TablePostReaction,
 		TablePostStar,
+		TablePostTrash,
 		TableTag,
 		TableUser,
 		TableUserRelation,
 		TableUserMetric,
+		TableUserSponsor,
+		TableUserSession,
+		TableUserOTP,
+		TableUserRecoveryCode,
 		TableWalletRecharge,
 		TableWalletStatement,
 	}
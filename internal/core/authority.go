@@ -14,4 +14,6 @@ type AuthorizationManageService interface {
 	BeFriendFilter(userId int64) ms.FriendFilter
 	BeFriendIds(userId int64) ([]int64, error)
 	MyFriendSet(userId int64) ms.FriendSet
+	SponsorFilter(userId int64) ms.SponsorFilter
+	AudienceFilter(userId int64, postIds []int64) ms.AudienceFilter
 }
@@ -19,6 +19,7 @@ const (
 	IdxActDeletePost
 	IdxActStickPost
 	IdxActVisiblePost
+	IdxActUpdatePoll
 )
 
 type IdxAct uint8
@@ -47,6 +48,8 @@ func (a IdxAct) String() string {
 		return "stick post"
 	case IdxActVisiblePost:
 		return "visible post"
+	case IdxActUpdatePoll:
+		return "update poll"
 	default:
 		return "unknow action"
 	}
@@ -92,10 +95,16 @@ type RedisCache interface {
 	GetCountLoginErr(ctx context.Context, id int64) (int64, error)
 	DelCountLoginErr(ctx context.Context, id int64) error
 	IncrCountLoginErr(ctx context.Context, id int64) error
+	GetCountResetPasswordErr(ctx context.Context, id int64) (int64, error)
+	DelCountResetPasswordErr(ctx context.Context, id int64) error
+	IncrCountResetPasswordErr(ctx context.Context, id int64) error
 	GetCountWhisper(ctx context.Context, uid int64) (int64, error)
 	IncrCountWhisper(ctx context.Context, uid int64) error
 	SetRechargeStatus(ctx context.Context, tradeNo string) error
 	DelRechargeStatus(ctx context.Context, tradeNo string) error
+	IncrTweetView(ctx context.Context, tweetId int64, viewer string, window int64) error
+	GetTweetViews(ctx context.Context) (map[int64]int64, error)
+	DecrTweetViews(ctx context.Context, views map[int64]int64) error
 }
 
 type AppCache interface {
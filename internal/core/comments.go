@@ -14,9 +14,13 @@ type CommentService interface {
 	GetComments(tweetId int64, style cs.StyleCommentType, limit int, offset int) ([]*ms.Comment, int64, error)
 	GetCommentByID(id int64) (*ms.Comment, error)
 	GetCommentReplyByID(id int64) (*ms.CommentReply, error)
+	ListCommentsByIDs(ids []int64) ([]*ms.Comment, error)
+	ListCommentRepliesByIDs(ids []int64) ([]*ms.CommentReply, error)
 	GetCommentContentsByIDs(ids []int64) ([]*ms.CommentContent, error)
-	GetCommentRepliesByID(ids []int64) ([]*ms.CommentReplyFormated, error)
+	GetCommentRepliesByID(ids []int64, style cs.StyleReplyType, limit int) ([]*ms.CommentReplyFormated, error)
+	GetCommentReplies(commentId int64, style cs.StyleReplyType, cursor *cs.ReplyCursor, limit int) ([]*ms.CommentReplyFormated, error)
 	GetCommentThumbsMap(userId int64, tweetId int64) (cs.CommentThumbsMap, cs.CommentThumbsMap, error)
+	ListCommentRevisions(commentId int64, replyId int64) ([]*ms.CommentRevision, error)
 }
 
 // CommentManageService 评论管理服务
@@ -27,6 +31,8 @@ type CommentManageService interface {
 	CreateCommentReply(reply *ms.CommentReply) (*ms.CommentReply, error)
 	DeleteCommentReply(reply *ms.CommentReply) error
 	CreateCommentContent(content *ms.CommentContent) (*ms.CommentContent, error)
+	EditComment(comment *ms.Comment, editorId int64, contents []*ms.CommentContent) error
+	EditCommentReply(reply *ms.CommentReply, editorId int64, content string) error
 	ThumbsUpComment(userId int64, tweetId, commentId int64) error
 	ThumbsDownComment(userId int64, tweetId, commentId int64) error
 	ThumbsUpReply(userId int64, tweetId, commentId, replyId int64) error
@@ -8,6 +8,7 @@ package core
 type DataService interface {
 	// 钱包服务
 	WalletService
+	SponsorService
 
 	// 消息服务
 	MessageService
@@ -19,6 +20,14 @@ type DataService interface {
 	TweetService
 	TweetManageService
 	TweetHelpService
+	TweetDraftService
+	TweetTrashService
+	TweetPollService
+	TweetReactionService
+	TweetViewService
+	TweetFolderService
+	LinkPreviewService
+	MentionService
 
 	// 推文指标服务
 	UserMetricServantA
@@ -35,11 +44,14 @@ type DataService interface {
 	// 用户服务
 	UserManageService
 	ContactManageService
+	ContactGroupService
 	FollowingManageService
 	UserRelationService
 
 	// 安全服务
 	SecurityService
+	SessionService
+	TwoFactorService
 	AttachmentCheckService
 
 	// 实用性服务
@@ -10,8 +10,22 @@ const (
 	StyleCommentNewest
 )
 
+const (
+	StyleReplyDefault StyleReplyType = iota
+	StyleReplyNewest
+	StyleReplyHots
+)
+
 type StyleCommentType uint8
 
+type StyleReplyType uint8
+
+// ReplyCursor 回复列表的游标，记录上一页最后一条回复的排序值
+type ReplyCursor struct {
+	ID            int64
+	ThumbsUpCount int32
+}
+
 type CommentThumbs struct {
 	UserID       int64 `json:"user_id"`
 	TweetID      int64 `json:"tweet_id"`
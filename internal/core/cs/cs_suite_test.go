@@ -0,0 +1,17 @@
+// Copyright 2024 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package cs_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCs(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Cs Suite")
+}
@@ -8,6 +8,9 @@ import "errors"
 
 // internal core error variable for data logic implement.
 var (
-	ErrNotImplemented = errors.New("not implemented")
-	ErrNoPermission   = errors.New("no permission")
+	ErrNotImplemented      = errors.New("not implemented")
+	ErrNoPermission        = errors.New("no permission")
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	ErrPollAlreadyVoted    = errors.New("poll already voted")
+	ErrMaxPinnedPosts      = errors.New("max pinned posts")
 )
@@ -17,13 +17,23 @@ type TweetMetric struct {
 	UpvoteCount     int64
 	CollectionCount int64
 	ShareCount      int64
+	ReactionCount   int64
+	ViewCount       int64
 }
 
+// _rankDecayBase 默认的衰减因子，互动分乘以该值后在默认衰减下保持原有量级
+const _rankDecayBase = 100
+
+// RankScore 排序分值，在按激励因子加权的激励分上计入表情回应与浏览，并按衰减因子衰减
 func (m *TweetMetric) RankScore(motivationFactor int) int64 {
 	if m.DecayFactor == 0 {
 		return 0
 	}
-	return int64(m.IncentiveScore * motivationFactor / m.DecayFactor)
+	// 表情回应与迁移脚本中的评论(1分)、点赞(2分)、收藏(4分)一样按次计分，每次回应计2分；
+	// 浏览的权重远低于表情回应，每10次浏览计1分
+	interaction := m.ReactionCount*2 + m.ViewCount/10
+	score := int64(m.IncentiveScore*motivationFactor) + interaction*_rankDecayBase
+	return score / int64(m.DecayFactor)
 }
 
 type CommentMetric struct {
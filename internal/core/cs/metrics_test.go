@@ -0,0 +1,34 @@
+// Copyright 2024 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package cs_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rocboss/paopao-ce/internal/core/cs"
+)
+
+var _ = Describe("TweetMetric", func() {
+	It("counts reactions on the same scale as the seeded interactions", func() {
+		metric := &cs.TweetMetric{DecayFactor: 100, ReactionCount: 3}
+		Expect(metric.RankScore(0)).To(Equal(int64(6)))
+	})
+
+	It("ranks a tweet with more reactions higher", func() {
+		quiet := &cs.TweetMetric{PostId: 1, IncentiveScore: 10, DecayFactor: 100}
+		liked := &cs.TweetMetric{PostId: 2, IncentiveScore: 10, DecayFactor: 100, ReactionCount: 5}
+		Expect(liked.RankScore(10)).To(BeNumerically(">", quiet.RankScore(10)))
+
+		// 激励分略高但没有表情回应的推文排在表情回应多的推文之后
+		boosted := &cs.TweetMetric{PostId: 3, IncentiveScore: 50, DecayFactor: 100}
+		Expect(liked.RankScore(10)).To(BeNumerically(">", boosted.RankScore(10)))
+	})
+
+	It("returns zero without a decay factor", func() {
+		metric := &cs.TweetMetric{ReactionCount: 10}
+		Expect(metric.RankScore(1)).To(BeZero())
+	})
+})
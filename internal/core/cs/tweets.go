@@ -16,10 +16,13 @@ const (
 	TweetBlockChargeAttachment
 
 	// 推文可见性
-	TweetVisitPublic    TweetVisibleType = 90
-	TweetVisitPrivate   TweetVisibleType = 0
-	TweetVisitFriend    TweetVisibleType = 50
-	TweetVisitFollowing TweetVisibleType = 60
+	TweetVisitPublic       TweetVisibleType = 90
+	TweetVisitPrivate      TweetVisibleType = 0
+	TweetVisitCharge       TweetVisibleType = 10
+	TweetVisitSubscription TweetVisibleType = 20
+	TweetVisitGroup        TweetVisibleType = 30
+	TweetVisitFriend       TweetVisibleType = 50
+	TweetVisitFollowing    TweetVisibleType = 60
 
 	// 用户推文列表样式
 	StyleUserTweetsGuest uint8 = iota
@@ -39,7 +42,7 @@ type (
 	// TODO: 优化一下类型为 uint8， 需要底层数据库同步修改
 	TweetBlockType int
 
-	// TweetVisibleType 推文可见性: 0私密 10充电可见 20订阅可见 30保留 40保留 50好友可见 60关注可见 70保留 80保留 90公开',
+	// TweetVisibleType 推文可见性: 0私密 10充电可见 20订阅可见 30分组可见 40保留 50好友可见 60关注可见 70保留 80保留 90公开',
 	TweetVisibleType uint8
 
 	// AttachmentType 附件类型， 1图片， 2视频， 3其他
@@ -157,6 +160,12 @@ func (t TweetVisibleType) ToOutValue() (res uint8) {
 		res = 2
 	case TweetVisitFollowing:
 		res = 3
+	case TweetVisitCharge:
+		res = 4
+	case TweetVisitSubscription:
+		res = 5
+	case TweetVisitGroup:
+		res = 6
 	default:
 		res = 1
 	}
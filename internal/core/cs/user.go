@@ -25,6 +25,8 @@ type (
 		Username string
 		UserId   int64
 		RelTyp   RelationTyp
+		// VisitorId 访问者ID，游客访问时为0
+		VisitorId int64
 	}
 )
 
@@ -40,16 +42,17 @@ type UserInfo struct {
 }
 
 type UserProfile struct {
-	ID          int64  `json:"id" db:"id"`
-	Nickname    string `json:"nickname"`
-	Username    string `json:"username"`
-	Phone       string `json:"phone"`
-	Status      int    `json:"status"`
-	Avatar      string `json:"avatar"`
-	Balance     int64  `json:"balance"`
-	IsAdmin     bool   `json:"is_admin"`
-	CreatedOn   int64  `json:"created_on"`
-	TweetsCount int    `json:"tweets_count"`
+	ID            int64  `json:"id" db:"id"`
+	Nickname      string `json:"nickname"`
+	Username      string `json:"username"`
+	Phone         string `json:"phone"`
+	Status        int    `json:"status"`
+	Avatar        string `json:"avatar"`
+	Balance       int64  `json:"balance"`
+	IsAdmin       bool   `json:"is_admin"`
+	CreatedOn     int64  `json:"created_on"`
+	TweetsCount   int    `json:"tweets_count"`
+	ShowSensitive bool   `json:"show_sensitive"`
 }
 
 func (t RelationTyp) String() string {
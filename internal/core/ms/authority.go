@@ -34,13 +34,17 @@ const (
 	ActVisibleTweet
 	ActDeleteTweet
 	ActCreateActivationCode
+	ActViewChargeTweet
+	ActViewSubscriptionTweet
 )
 
 type (
 	act uint8
 
-	FriendFilter map[int64]types.Empty
-	FriendSet    map[string]types.Empty
+	FriendFilter   map[int64]types.Empty
+	FriendSet      map[string]types.Empty
+	SponsorFilter  map[int64]*UserSponsor
+	AudienceFilter map[int64]types.Empty
 
 	Action struct {
 		Act    act
@@ -53,6 +57,18 @@ func (f FriendFilter) IsFriend(userId int64) bool {
 	return yeah
 }
 
+// IsAllow 是否可以看到创作者付费可见的推文
+func (f SponsorFilter) IsAllow(creatorId int64, visibility PostVisibleT) bool {
+	sponsor, exist := f[creatorId]
+	return exist && sponsor.IsAllow(visibility)
+}
+
+// IsAudience 访问者是否被作者加入了分组可见推文所选的分组
+func (f AudienceFilter) IsAudience(postId int64) bool {
+	_, yeah := f[postId]
+	return yeah
+}
+
 // IsAllow default true if user is admin
 func (a act) IsAllow(user *User, userId int64, isFriend bool, isActivation bool) bool {
 	if user.IsAdmin {
@@ -14,4 +14,7 @@ type (
 	CommentReply         = dbr.CommentReply
 	CommentContent       = dbr.CommentContent
 	CommentReplyFormated = dbr.CommentReplyFormated
+
+	CommentRevision         = dbr.CommentRevision
+	CommentRevisionFormated = dbr.CommentRevisionFormated
 )
@@ -13,7 +13,7 @@ const (
 	AttachmentTypeVideo = dbr.AttachmentTypeVideo
 	AttachmentTypeOther = dbr.AttachmentTypeOther
 
-	// 类型，1标题，2文字段落，3图片地址，4视频地址，5语音地址，6链接地址，7附件资源
+	// 类型，1标题，2文字段落，3图片地址，4视频地址，5语音地址，6链接地址，7附件资源，8收费附件资源，9投票
 	ContentTypeTitle            = dbr.ContentTypeTitle
 	ContentTypeText             = dbr.ContentTypeText
 	ContentTypeImage            = dbr.ContentTypeImage
@@ -22,22 +22,66 @@ const (
 	ContentTypeLink             = dbr.ContentTypeLink
 	ContentTypeAttachment       = dbr.ContentTypeAttachment
 	ContentTypeChargeAttachment = dbr.ContentTypeChargeAttachment
+	ContentTypePoll             = dbr.ContentTypePoll
 )
 
 const (
-	PostVisitPublic    = dbr.PostVisitPublic
-	PostVisitPrivate   = dbr.PostVisitPrivate
-	PostVisitFriend    = dbr.PostVisitFriend
-	PostVisitFollowing = dbr.PostVisitFollowing
+	PostVisitPublic       = dbr.PostVisitPublic
+	PostVisitPrivate      = dbr.PostVisitPrivate
+	PostVisitCharge       = dbr.PostVisitCharge
+	PostVisitSubscription = dbr.PostVisitSubscription
+	PostVisitGroup        = dbr.PostVisitGroup
+	PostVisitFriend       = dbr.PostVisitFriend
+	PostVisitFollowing    = dbr.PostVisitFollowing
+)
+
+const (
+	PostSensitiveNone     = dbr.PostSensitiveNone
+	PostSensitiveByAuthor = dbr.PostSensitiveByAuthor
+	PostSensitiveByAdmin  = dbr.PostSensitiveByAdmin
+)
+
+const (
+	PostReplyEveryone  = dbr.PostReplyEveryone
+	PostReplyFollowers = dbr.PostReplyFollowers
+	PostReplyFriends   = dbr.PostReplyFriends
+	PostReplyMentioned = dbr.PostReplyMentioned
+	PostReplyNobody    = dbr.PostReplyNobody
+)
+
+const (
+	LinkPreviewFetched = dbr.LinkPreviewFetched
+	LinkPreviewFailed  = dbr.LinkPreviewFailed
 )
 
 type (
-	PostStar           = dbr.PostStar
-	PostCollection     = dbr.PostCollection
-	PostAttachmentBill = dbr.PostAttachmentBill
-	PostContent        = dbr.PostContent
-	Attachment         = dbr.Attachment
-	AttachmentType     = dbr.AttachmentType
-	PostContentT       = dbr.PostContentT
-	PostVisibleT       = dbr.PostVisibleT
+	PostStar                     = dbr.PostStar
+	PostCollection               = dbr.PostCollection
+	PostCollectionFolder         = dbr.PostCollectionFolder
+	PostCollectionFolderFormated = dbr.PostCollectionFolderFormated
+	PostAttachmentBill           = dbr.PostAttachmentBill
+	PostContent                  = dbr.PostContent
+	LinkPreview                  = dbr.LinkPreview
+	LinkPreviewFormated          = dbr.LinkPreviewFormated
+	Mention                      = dbr.Mention
+	MentionFormated              = dbr.MentionFormated
+	PostContentRevision          = dbr.PostContentRevision
+	PostContentRevisionFormated  = dbr.PostContentRevisionFormated
+	PostDraft                    = dbr.PostDraft
+	PostTrash                    = dbr.PostTrash
+	PostTrashFormated            = dbr.PostTrashFormated
+	PostPoll                     = dbr.PostPoll
+	PostPollOption               = dbr.PostPollOption
+	PostPollVote                 = dbr.PostPollVote
+	PostPollFormated             = dbr.PostPollFormated
+	PostPollOptionFormated       = dbr.PostPollOptionFormated
+	PostReaction                 = dbr.PostReaction
+	CommentReaction              = dbr.CommentReaction
+	ReactionCount                = dbr.ReactionCount
+	Attachment                   = dbr.Attachment
+	AttachmentType               = dbr.AttachmentType
+	PostContentT                 = dbr.PostContentT
+	PostVisibleT                 = dbr.PostVisibleT
+	PostSensitiveT               = dbr.PostSensitiveT
+	PostReplyPolicyT             = dbr.PostReplyPolicyT
 )
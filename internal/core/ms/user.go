@@ -4,13 +4,24 @@
 
 package ms
 
+import (
+	"github.com/rocboss/paopao-ce/internal/dao/jinzhu/dbr"
+)
+
 type (
+	ContactGroup         = dbr.ContactGroup
+	ContactGroupFormated = dbr.ContactGroupFormated
+	UserSession          = dbr.UserSession
+	UserSessionFormated  = dbr.UserSessionFormated
+	UserOTP              = dbr.UserOTP
+
 	ContactItem struct {
 		UserId      int64  `json:"user_id"`
 		Username    string `db:"username" json:"username"`
 		Nickname    string `json:"nickname"`
 		Avatar      string `json:"avatar"`
 		Phone       string `json:"phone,omitempty"`
+		GroupId     int64  `json:"group_id"`
 		IsFollowing bool   `json:"is_following"`
 		CreatedOn   int64  `json:"created_on"`
 	}
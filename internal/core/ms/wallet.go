@@ -11,4 +11,5 @@ import (
 type (
 	WalletStatement = dbr.WalletStatement
 	WalletRecharge  = dbr.WalletRecharge
+	UserSponsor     = dbr.UserSponsor
 )
@@ -15,14 +15,17 @@ const (
 )
 
 const (
-	PostVisitPublic    = dbr.PostVisitPublic
-	PostVisitPrivate   = dbr.PostVisitPrivate
-	PostVisitFriend    = dbr.PostVisitFriend
-	PostVisitFollowing = dbr.PostVisitFollowing
+	PostVisitPublic       = dbr.PostVisitPublic
+	PostVisitPrivate      = dbr.PostVisitPrivate
+	PostVisitCharge       = dbr.PostVisitCharge
+	PostVisitSubscription = dbr.PostVisitSubscription
+	PostVisitGroup        = dbr.PostVisitGroup
+	PostVisitFriend       = dbr.PostVisitFriend
+	PostVisitFollowing    = dbr.PostVisitFollowing
 )
 
 type (
-	// PostVisibleT 可访问类型，可见性: 0私密 10充电可见 20订阅可见 30保留 40保留 50好友可见 60关注可见 70保留 80保留 90公开
+	// PostVisibleT 可访问类型，可见性: 0私密 10充电可见 20订阅可见 30分组可见 40保留 50好友可见 60关注可见 70保留 80保留 90公开
 	PostVisibleT = dbr.PostVisibleT
 
 	SearchType string
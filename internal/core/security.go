@@ -13,10 +13,35 @@ import (
 // SecurityService 安全相关服务
 type SecurityService interface {
 	GetLatestPhoneCaptcha(phone string) (*ms.Captcha, error)
-	UsePhoneCaptcha(captcha *ms.Captcha) error
+	UsePhoneCaptcha(captcha *ms.Captcha, maxTimes int) error
 	SendPhoneCaptcha(phone string) error
 }
 
+// SessionService 用户登录会话服务
+type SessionService interface {
+	CreateSession(session *ms.UserSession) (*ms.UserSession, error)
+	GetSession(id int64) (*ms.UserSession, error)
+	GetSessionByRefreshToken(token string) (*ms.UserSession, error)
+	GetSessionByRotatedToken(token string) (*ms.UserSession, error)
+	RotateSession(session *ms.UserSession, oldToken string) error
+	TouchSession(session *ms.UserSession) error
+	ListSessions(userId int64) ([]*ms.UserSession, error)
+	RevokeSession(session *ms.UserSession) error
+	RevokeAllSessions(userId int64, exceptId int64) error
+}
+
+// TwoFactorService 用户二次验证服务
+type TwoFactorService interface {
+	// GetUserOTP 获取用户的二次验证，优先返回已开启的，未设置时返回nil
+	GetUserOTP(userId int64) (*ms.UserOTP, error)
+	SetupUserOTP(userId int64, secret string) (*ms.UserOTP, error)
+	EnableUserOTP(otp *ms.UserOTP, counter int64) error
+	UseUserOTP(otp *ms.UserOTP, counter int64) error
+	DisableUserOTP(userId int64) error
+	ResetRecoveryCodes(userId int64, codes []string) error
+	UseRecoveryCode(userId int64, code string) error
+}
+
 // AttachmentCheckService 附件检测服务
 type AttachmentCheckService interface {
 	CheckAttachment(uri string) error
@@ -14,6 +14,7 @@ type TopicService interface {
 	DecrTagsById(ids []int64) error
 	ListTags(typ cs.TagType, limit int, offset int) (cs.TagList, error)
 	TagsByKeyword(keyword string) (cs.TagInfoList, error)
+	TagsByNames(tags []string) (cs.TagInfoList, error)
 	GetHotTags(userId int64, limit int, offset int) (cs.TagList, error)
 	GetNewestTags(userId int64, limit int, offset int) (cs.TagList, error)
 	GetFollowTags(userId int64, isPin bool, limit int, offset int) (cs.TagList, error)
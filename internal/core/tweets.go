@@ -23,10 +23,16 @@ type TweetService interface {
 	GetPostAttatchmentBill(postID, userID int64) (*ms.PostAttachmentBill, error)
 	GetPostContentsByIDs(ids []int64) ([]*ms.PostContent, error)
 	GetPostContentByID(id int64) (*ms.PostContent, error)
+	ListPostContentRevisions(postId int64) ([]*ms.PostContentRevision, error)
+	ListThreadTweets(rootId int64) ([]*ms.Post, error)
+	ListScheduledTweets(userId int64, limit, offset int) ([]*ms.Post, int64, error)
+	ListDueScheduledTweets(now int64, limit int) ([]*ms.Post, error)
+	ListDueExpiredTweets(now int64, limit int) ([]*ms.Post, error)
+	ListArchivedTweets(userId int64, limit, offset int) ([]*ms.Post, int64, error)
 	ListUserStarTweets(user *cs.VistUser, limit int, offset int) ([]*ms.PostStar, int64, error)
 	ListUserMediaTweets(user *cs.VistUser, limit int, offset int) ([]*ms.Post, int64, error)
 	ListUserCommentTweets(user *cs.VistUser, limit int, offset int) ([]*ms.Post, int64, error)
-	ListUserTweets(userId int64, style uint8, justEssence bool, limit, offset int) ([]*ms.Post, int64, error)
+	ListUserTweets(user *cs.VistUser, style uint8, justEssence bool, limit, offset int) ([]*ms.Post, int64, error)
 	ListFollowingTweets(userId int64, limit, offset int) ([]*ms.Post, int64, error)
 	ListIndexNewestTweets(limit, offset int) ([]*ms.Post, int64, error)
 	ListIndexHotsTweets(limit, offset int) ([]*ms.Post, int64, error)
@@ -39,15 +45,24 @@ type TweetManageService interface {
 	DeletePost(post *ms.Post) ([]string, error)
 	LockPost(post *ms.Post) error
 	StickPost(post *ms.Post) error
+	PinPost(post *ms.Post, maxPinned int64) error
 	HighlightPost(userId, postId int64) (int, error)
 	VisiblePost(post *ms.Post, visibility cs.TweetVisibleType) error
 	UpdatePost(post *ms.Post) error
+	IncrPostShareCount(post *ms.Post, delta int64) error
+	IncrPostThreadCount(post *ms.Post, delta int64, latestRepliedOn int64) error
+	PromoteThreadHead(head *ms.Post) (*ms.Post, error)
+	EditPost(post *ms.Post, contents []*ms.PostContent) error
+	SchedulePost(post *ms.Post, publishAt int64) error
+	PublishPost(post *ms.Post) (bool, error)
+	ExpirePost(post *ms.Post) error
 	CreatePostStar(postID, userID int64) (*ms.PostStar, error)
 	DeletePostStar(p *ms.PostStar) error
-	CreatePostCollection(postID, userID int64) (*ms.PostCollection, error)
+	CreatePostCollection(postID, userID, folderID int64) (*ms.PostCollection, error)
 	DeletePostCollection(p *ms.PostCollection) error
 	CreatePostContent(content *ms.PostContent) (*ms.PostContent, error)
 	CreateAttachment(obj *ms.Attachment) (int64, error)
+	FilterUserAttachments(userId int64, contents []string) ([]string, error)
 }
 
 // TweetHelpService 推文辅助服务
@@ -56,6 +71,83 @@ type TweetHelpService interface {
 	MergePosts(posts []*ms.Post) ([]*ms.PostFormated, error)
 }
 
+// TweetDraftService 推文草稿服务
+type TweetDraftService interface {
+	GetDraftByID(id int64) (*ms.PostDraft, error)
+	CreateDraft(draft *ms.PostDraft) (*ms.PostDraft, error)
+	UpdateDraft(draft *ms.PostDraft) error
+	DeleteDraft(draft *ms.PostDraft) error
+	PublishDraft(draft *ms.PostDraft) (bool, error)
+	RestoreDraft(draft *ms.PostDraft) error
+	ListUserDrafts(userId int64, limit, offset int) ([]*ms.PostDraft, int64, error)
+	ListExpiredDrafts(before int64, limit int) ([]*ms.PostDraft, error)
+}
+
+// TweetTrashService 推文回收站服务，删除的推文保留一段时间后再彻底清除
+type TweetTrashService interface {
+	TrashPost(post *ms.Post, operatorId int64) error
+	GetPostTrash(postId int64) (*ms.PostTrash, error)
+	ListPostTrash(userId int64, limit, offset int) ([]*ms.PostTrashFormated, int64, error)
+	ListExpiredPostTrash(before int64, limit int) ([]*ms.PostTrash, error)
+	RestorePost(trash *ms.PostTrash) (*ms.Post, error)
+	PurgePost(trash *ms.PostTrash) ([]string, error)
+}
+
+// TweetPollService 推文投票服务
+type TweetPollService interface {
+	CreatePostPoll(poll *ms.PostPoll, options []string) (*ms.PostPoll, error)
+	GetPostPollByID(id int64) (*ms.PostPoll, error)
+	VotePostPoll(poll *ms.PostPoll, userId int64, optionIds []int64) error
+	ClosePostPoll(poll *ms.PostPoll) error
+	ListUserPollVotes(userId int64, pollIds []int64) ([]*ms.PostPollVote, error)
+	ListDueClosedPolls(now int64, limit int) ([]*ms.PostPoll, error)
+}
+
+// TweetReactionService 推文/评论表情回应服务
+type TweetReactionService interface {
+	CreatePostReaction(post *ms.Post, userId int64, reaction string) error
+	DeletePostReaction(post *ms.Post, userId int64, reaction string) error
+	ListUserPostReactions(userId int64, postIds []int64) ([]*ms.PostReaction, error)
+	CreateCommentReaction(comment *ms.Comment, userId int64, reaction string) error
+	DeleteCommentReaction(comment *ms.Comment, userId int64, reaction string) error
+	ListUserCommentReactions(userId int64, commentIds []int64) ([]*ms.CommentReaction, error)
+	GetPostReactionCounts(postIds []int64) (map[int64][]*ms.ReactionCount, error)
+	GetCommentReactionCounts(commentIds []int64) (map[int64][]*ms.ReactionCount, error)
+}
+
+// TweetFolderService 收藏夹服务
+type TweetFolderService interface {
+	GetCollectionFolderByID(id int64) (*ms.PostCollectionFolder, error)
+	GetDefaultCollectionFolder(userId int64) (*ms.PostCollectionFolder, error)
+	ListUserCollectionFolders(userId int64, justPublic bool) ([]*ms.PostCollectionFolderFormated, error)
+	CreateCollectionFolder(folder *ms.PostCollectionFolder) (*ms.PostCollectionFolder, error)
+	UpdateCollectionFolder(folder *ms.PostCollectionFolder) error
+	DeleteCollectionFolder(folder *ms.PostCollectionFolder) error
+	MoveCollectionToFolder(collection *ms.PostCollection, folderId int64) error
+	ListFolderCollections(folderId int64, justPublic bool, limit, offset int) ([]*ms.PostCollection, int64, error)
+}
+
+// TweetViewService 推文浏览数服务
+type TweetViewService interface {
+	AddPostViews(views map[int64]int64) error
+}
+
+// LinkPreviewService 链接预览服务
+type LinkPreviewService interface {
+	GetLinkPreview(url string) (*ms.LinkPreview, error)
+	GetLinkPreviews(urls []string) (map[string]*ms.LinkPreview, error)
+	SaveLinkPreview(preview *ms.LinkPreview) error
+}
+
+// MentionService @提及服务，提及来源由动态/评论/回复ID确定
+type MentionService interface {
+	SaveMentions(src *ms.Mention, userIds []int64) (added []int64, removed []int64, err error)
+	DeleteMentions(src *ms.Mention) ([]int64, error)
+	IsMentioned(src *ms.Mention, userId int64) bool
+	// ListUserMentions 获取用户被@的记录及总数，只包括用户当前仍可见的动态
+	ListUserMentions(user *ms.User, limit, offset int) ([]*ms.Mention, int64, error)
+}
+
 // TweetServantA 推文检索服务(版本A)
 type TweetServantA interface {
 	TweetInfoById(id int64) (*cs.TweetInfo, error)
@@ -19,6 +19,7 @@ type UserManageService interface {
 	UserProfileByName(username string) (*cs.UserProfile, error)
 	CreateUser(user *ms.User) (*ms.User, error)
 	UpdateUser(user *ms.User) error
+	UpgradeUserPassword(user *ms.User, oldPassword string) error
 	GetRegisterUserCount() (int64, error)
 }
 
@@ -32,6 +33,20 @@ type ContactManageService interface {
 	IsFriend(userID int64, friendID int64) bool
 }
 
+// ContactGroupService 联系人分组服务
+type ContactGroupService interface {
+	CreateContactGroup(userId int64, name string) (*ms.ContactGroup, error)
+	GetContactGroup(id int64) (*ms.ContactGroup, error)
+	ListContactGroups(userId int64) ([]*ms.ContactGroupFormated, error)
+	CountContactGroups(userId int64) (int64, error)
+	RenameContactGroup(group *ms.ContactGroup, name string) error
+	DeleteContactGroup(group *ms.ContactGroup) error
+	SetContactGroup(userId int64, friendId int64, groupId int64) error
+	SetPostAudience(postId int64, groupIds []int64) error
+	GetPostAudience(postId int64) ([]int64, error)
+	IsPostAudience(postId int64, userId int64) bool
+}
+
 // FollowingManageService 关注管理服务
 type FollowingManageService interface {
 	FollowUser(userId int64, followId int64) error
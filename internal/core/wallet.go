@@ -17,3 +17,10 @@ type WalletService interface {
 	HandleRechargeSuccess(recharge *ms.WalletRecharge, tradeNo string) error
 	HandlePostAttachmentBought(post *ms.Post, user *ms.User) error
 }
+
+// SponsorService 用户对创作者的充电与订阅服务
+type SponsorService interface {
+	ChargeCreator(user *ms.User, creatorId int64, amount int64) (*ms.UserSponsor, error)
+	SubscribeCreator(user *ms.User, creatorId int64, price int64, days int) (*ms.UserSponsor, error)
+	GetSponsorFilter(userId int64, creatorIds ...int64) (ms.SponsorFilter, error)
+}
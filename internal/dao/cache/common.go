@@ -122,3 +122,58 @@ func (s *cacheDataService) IsMyFollow(userId int64, followIds ...int64) (res map
 	OnCacheMyFollowIdsEvent(s.DataService, userId, key)
 	return s.DataService.IsMyFollow(userId, followIds...)
 }
+
+func (s *cacheDataService) GetSession(id int64) (res *ms.UserSession, err error) {
+	// 先从缓存获取， 不处理错误
+	key := conf.KeyUserSession.Get(id)
+	if data, xerr := s.ac.Get(key); xerr == nil {
+		buf := bytes.NewBuffer(data)
+		res = &ms.UserSession{}
+		err = gob.NewDecoder(buf).Decode(res)
+		return
+	}
+	// 最后查库，同步写入缓存，避免异步写入覆盖撤销会话时的缓存清除
+	if res, err = s.DataService.GetSession(id); err == nil {
+		buffer := &bytes.Buffer{}
+		if xerr := gob.NewEncoder(buffer).Encode(res); xerr == nil {
+			s.ac.Set(key, buffer.Bytes(), conf.CacheSetting.UserSessionExpire)
+		}
+	}
+	return
+}
+
+func (s *cacheDataService) RotateSession(session *ms.UserSession, oldToken string) error {
+	defer s.ac.Delete(conf.KeyUserSession.Get(session.ID))
+	return s.DataService.RotateSession(session, oldToken)
+}
+
+func (s *cacheDataService) TouchSession(session *ms.UserSession) error {
+	defer s.ac.Delete(conf.KeyUserSession.Get(session.ID))
+	return s.DataService.TouchSession(session)
+}
+
+func (s *cacheDataService) RevokeSession(session *ms.UserSession) error {
+	defer s.ac.Delete(conf.KeyUserSession.Get(session.ID))
+	return s.DataService.RevokeSession(session)
+}
+
+func (s *cacheDataService) RevokeAllSessions(userId int64, exceptId int64) error {
+	// 撤销前获取有效的会话，撤销后清除这些会话的缓存
+	sessions, err := s.DataService.ListSessions(userId)
+	if err != nil {
+		return err
+	}
+	if err = s.DataService.RevokeAllSessions(userId, exceptId); err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		if session.ID != exceptId {
+			keys = append(keys, conf.KeyUserSession.Get(session.ID))
+		}
+	}
+	if len(keys) > 0 {
+		s.ac.Delete(keys...)
+	}
+	return nil
+}
@@ -94,6 +94,19 @@ func OnExpireIndexTweetEvent(userId int64) {
 	})
 }
 
+// OnExpireViewerTweetsEvent 过期指定访问者看到的推文列表缓存
+func OnExpireViewerTweetsEvent(username string) {
+	events.OnEvent(&expireIndexTweetsEvent{
+		ac: _appCache,
+		keysPattern: []string{
+			conf.PrefixIdxTweetsNewest + username + ":*",
+			conf.PrefixIdxTweetsHots + username + ":*",
+			conf.PrefixIdxTweetsFollowing + username + ":*",
+			conf.PrefixUserTweets + "*:*:" + username + ":*",
+		},
+	})
+}
+
 func OnExpireHotsTweetEvent() {
 	events.OnEvent(&expireHotsTweetsEvent{
 		ac:         _appCache,
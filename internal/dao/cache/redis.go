@@ -10,6 +10,7 @@ import (
 	"github.com/Masterminds/semver/v3"
 	"github.com/redis/rueidis"
 	"github.com/rocboss/paopao-ce/internal/core"
+	"strconv"
 	"time"
 )
 
@@ -18,14 +19,26 @@ var (
 	_ tweetsCache     = (*redisCacheTweetsCache)(nil)
 )
 
+// _decrTweetViewsScript 扣减已写入数据库的浏览计数，扣减到0的计数一并删除
+var _decrTweetViewsScript = rueidis.NewLuaScript(`
+for i = 1, #ARGV, 2 do
+	if redis.call('HINCRBY', KEYS[1], ARGV[i], -tonumber(ARGV[i+1])) <= 0 then
+		redis.call('HDEL', KEYS[1], ARGV[i])
+	end
+end
+return 0`)
+
 const (
 	_cacheIndexKeyPattern = _cacheIndexKey + "*"
 	_pushToSearchJobKey   = "paopao_push_to_search_job"
 	_countLoginErrKey     = "paopao_count_login_err"
+	_countResetPwdErrKey  = "paopao_count_reset_pwd_err"
 	_imgCaptchaKey        = "paopao_img_captcha:"
 	_smsCaptchaKey        = "paopao_sms_captcha"
 	_countWhisperKey      = "paopao_whisper_key"
 	_rechargeStatusKey    = "paopao_recharge_status:"
+	_tweetViewerKey       = "paopao_tweet_viewer"
+	_tweetViewsKey        = "paopao_tweet_views"
 )
 
 type redisCache struct {
@@ -142,6 +155,22 @@ func (r *redisCache) IncrCountLoginErr(ctx context.Context, id int64) error {
 	return err
 }
 
+func (r *redisCache) GetCountResetPasswordErr(ctx context.Context, id int64) (int64, error) {
+	return r.c.Do(ctx, r.c.B().Get().Key(fmt.Sprintf("%s:%d", _countResetPwdErrKey, id)).Build()).AsInt64()
+}
+
+func (r *redisCache) DelCountResetPasswordErr(ctx context.Context, id int64) error {
+	return r.c.Do(ctx, r.c.B().Del().Key(fmt.Sprintf("%s:%d", _countResetPwdErrKey, id)).Build()).Error()
+}
+
+func (r *redisCache) IncrCountResetPasswordErr(ctx context.Context, id int64) error {
+	err := r.c.Do(ctx, r.c.B().Incr().Key(fmt.Sprintf("%s:%d", _countResetPwdErrKey, id)).Build()).Error()
+	if err == nil {
+		err = r.c.Do(ctx, r.c.B().Expire().Key(fmt.Sprintf("%s:%d", _countResetPwdErrKey, id)).Seconds(3600).Build()).Error()
+	}
+	return err
+}
+
 func (r *redisCache) GetCountWhisper(ctx context.Context, uid int64) (int64, error) {
 	return r.c.Do(ctx, r.c.B().Get().Key(fmt.Sprintf("%s:%d", _countWhisperKey, uid)).Build()).AsInt64()
 }
@@ -165,3 +194,43 @@ func (r *redisCache) SetRechargeStatus(ctx context.Context, tradeNo string) erro
 func (r *redisCache) DelRechargeStatus(ctx context.Context, tradeNo string) error {
 	return r.c.Do(ctx, r.c.B().Del().Key(_rechargeStatusKey+tradeNo).Build()).Error()
 }
+
+// IncrTweetView 记录一次推文浏览，同一浏览者在window秒内的重复浏览只计一次
+func (r *redisCache) IncrTweetView(ctx context.Context, tweetId int64, viewer string, window int64) error {
+	err := r.c.Do(ctx, r.c.B().Set().
+		Key(fmt.Sprintf("%s:%d:%s", _tweetViewerKey, tweetId, viewer)).Value("1").
+		Nx().ExSeconds(window).Build()).Error()
+	if rueidis.IsRedisNil(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return r.c.Do(ctx, r.c.B().Hincrby().Key(_tweetViewsKey).Field(strconv.FormatInt(tweetId, 10)).Increment(1).Build()).Error()
+}
+
+// GetTweetViews 获取缓冲的推文浏览计数，写入数据库后需调用 DecrTweetViews 扣减
+func (r *redisCache) GetTweetViews(ctx context.Context) (map[int64]int64, error) {
+	views, err := r.c.Do(ctx, r.c.B().Hgetall().Key(_tweetViewsKey).Build()).AsIntMap()
+	if err != nil || len(views) == 0 {
+		return nil, err
+	}
+	res := make(map[int64]int64, len(views))
+	for field, count := range views {
+		if tweetId, err := strconv.ParseInt(field, 10, 64); err == nil && count > 0 {
+			res[tweetId] = count
+		}
+	}
+	return res, nil
+}
+
+// DecrTweetViews 扣减已写入数据库的浏览计数，读取之后新增的计数保留到下次写入
+func (r *redisCache) DecrTweetViews(ctx context.Context, views map[int64]int64) error {
+	if len(views) == 0 {
+		return nil
+	}
+	args := make([]string, 0, len(views)*2)
+	for tweetId, count := range views {
+		args = append(args, strconv.FormatInt(tweetId, 10), strconv.FormatInt(count, 10))
+	}
+	return _decrTweetViewsScript.Exec(ctx, r.c, []string{_tweetViewsKey}, args).Error()
+}
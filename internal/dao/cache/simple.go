@@ -93,7 +93,8 @@ func (s *simpleCacheIndexServant) startIndexPosts() {
 				core.IdxActUpdatePost,
 				core.IdxActDeletePost,
 				core.IdxActStickPost,
-				core.IdxActVisiblePost:
+				core.IdxActVisiblePost,
+				core.IdxActUpdatePoll:
 				// prevent many update post in least time
 				if s.indexPosts != nil {
 					logrus.Debugf("remove index posts by action %s", action)
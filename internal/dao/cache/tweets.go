@@ -25,6 +25,8 @@ func (s *eventCacheIndexSrv) SendAction(act core.IdxAct, post *ms.Post) {
 			UpvoteCount:     post.UpvoteCount,
 			CollectionCount: post.CollectionCount,
 			ShareCount:      post.ShareCount,
+			ReactionCount:   post.ReactionCount,
+			ViewCount:       post.ViewCount,
 		})
 		OnExpireIndexTweetEvent(post.UserID)
 	case core.IdxActCreatePost:
@@ -33,7 +35,7 @@ func (s *eventCacheIndexSrv) SendAction(act core.IdxAct, post *ms.Post) {
 	case core.IdxActDeletePost:
 		err = s.tms.DeleteTweetMetric(post.ID)
 		OnExpireIndexTweetEvent(post.UserID)
-	case core.IdxActStickPost, core.IdxActVisiblePost:
+	case core.IdxActStickPost, core.IdxActVisiblePost, core.IdxActUpdatePoll:
 		OnExpireIndexTweetEvent(post.UserID)
 	}
 	if err != nil {
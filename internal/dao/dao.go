@@ -20,6 +20,7 @@ import (
 var (
 	ts     core.TweetSearchService
 	ds     core.DataService
+	ams    core.AuthorizationManageService
 	oss    core.ObjectStorageService
 	webDsa core.WebDataServantA
 
@@ -31,6 +32,11 @@ func DataService() core.DataService {
 	return ds
 }
 
+func AuthorizationManageService() core.AuthorizationManageService {
+	lazyInitial()
+	return ams
+}
+
 func WebDataServantA() core.WebDataServantA {
 	lazyInitial()
 	return webDsa
@@ -62,6 +68,7 @@ func newAuthorizationManageService() (ams core.AuthorizationManageService) {
 // lazyInitial do some package lazy initialize for performance
 func lazyInitial() {
 	_onceInitial.Do(func() {
+		ams = newAuthorizationManageService()
 		initDsX()
 		initOSS()
 		initTsX()
@@ -115,7 +122,6 @@ func initOSS() {
 
 func initTsX() {
 	var v core.VersionInfo
-	ams := newAuthorizationManageService()
 	cfg.On(cfg.Actions{
 		"Zinc": func() {
 			ts, v = search.NewZincTweetSearchService(ams)
@@ -5,6 +5,8 @@
 package jinzhu
 
 import (
+	"fmt"
+
 	"github.com/rocboss/paopao-ce/internal/core"
 	"github.com/rocboss/paopao-ce/internal/core/ms"
 	"github.com/rocboss/paopao-ce/internal/dao/jinzhu/dbr"
@@ -27,6 +29,19 @@ func newAuthorizationManageService(db *gorm.DB) core.AuthorizationManageService
 }
 
 func (s *authorizationManageSrv) IsAllow(user *ms.User, action *ms.Action) bool {
+	// 付费可见的推文需要访问者对作者充电或订阅过
+	switch action.Act {
+	case ms.ActViewChargeTweet, ms.ActViewSubscriptionTweet:
+		if user.IsAdmin || user.ID == action.UserId {
+			return true
+		}
+		visibility := ms.PostVisitCharge
+		if action.Act == ms.ActViewSubscriptionTweet {
+			visibility = ms.PostVisitSubscription
+		}
+		sponsor, err := (&dbr.UserSponsor{UserID: user.ID, CreatorID: action.UserId}).Get(s.db)
+		return err == nil && sponsor.IsAllow(visibility)
+	}
 	// user is activation if had bind phone
 	isActivation := (len(user.Phone) != 0)
 	isFriend := s.isFriend(user.ID, action.UserId)
@@ -60,6 +75,35 @@ func (s *authorizationManageSrv) BeFriendFilter(userId int64) ms.FriendFilter {
 	return resp
 }
 
+// SponsorFilter 用户仍然有效的充电与订阅，以创作者ID为键
+func (s *authorizationManageSrv) SponsorFilter(userId int64) ms.SponsorFilter {
+	sponsors, err := (&dbr.UserSponsor{}).ListValid(s.db, userId, nil)
+	if err != nil {
+		return ms.SponsorFilter{}
+	}
+	resp := make(ms.SponsorFilter, len(sponsors))
+	for _, sponsor := range sponsors {
+		resp[sponsor.CreatorID] = sponsor
+	}
+	return resp
+}
+
+// AudienceFilter 指定推文中访问者可见的分组可见推文，以推文ID为键
+func (s *authorizationManageSrv) AudienceFilter(userId int64, postIds []int64) ms.AudienceFilter {
+	if len(postIds) == 0 {
+		return ms.AudienceFilter{}
+	}
+	var ids []int64
+	if err := s.db.Table(_postAudience_).Where(fmt.Sprintf("post_id IN ? AND is_del = 0 AND group_id IN (%s)", audienceGroupsSQL()), postIds, userId).Distinct().Pluck("post_id", &ids).Error; err != nil {
+		return ms.AudienceFilter{}
+	}
+	resp := make(ms.AudienceFilter, len(ids))
+	for _, id := range ids {
+		resp[id] = types.Empty{}
+	}
+	return resp
+}
+
 func (s *authorizationManageSrv) BeFriendIds(userId int64) ([]int64, error) {
 	return (&dbr.Contact{FriendId: userId}).BeFriendIds(s.db)
 }
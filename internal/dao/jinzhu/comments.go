@@ -102,6 +102,18 @@ func (s *commentSrv) GetCommentReplyByID(id int64) (*ms.CommentReply, error) {
 	return reply.Get(s.db)
 }
 
+func (s *commentSrv) ListCommentsByIDs(ids []int64) ([]*ms.Comment, error) {
+	return (&dbr.Comment{}).List(s.db, &dbr.ConditionsT{
+		"id IN ?": ids,
+	}, 0, 0)
+}
+
+func (s *commentSrv) ListCommentRepliesByIDs(ids []int64) ([]*ms.CommentReply, error) {
+	return (&dbr.CommentReply{}).List(s.db, &dbr.ConditionsT{
+		"id IN ?": ids,
+	}, 0, 0)
+}
+
 func (s *commentSrv) GetCommentCount(conditions *ms.ConditionsT) (int64, error) {
 	return (&dbr.Comment{}).Count(s.db, conditions)
 }
@@ -113,15 +125,55 @@ func (s *commentSrv) GetCommentContentsByIDs(ids []int64) ([]*ms.CommentContent,
 	}, 0, 0)
 }
 
-func (s *commentSrv) GetCommentRepliesByID(ids []int64) ([]*ms.CommentReplyFormated, error) {
-	CommentReply := &dbr.CommentReply{}
-	replies, err := CommentReply.List(s.db, &dbr.ConditionsT{
-		"comment_id IN ?": ids,
-		"ORDER":           "id ASC",
-	}, 0, 0)
+// GetCommentRepliesByID 获取每条评论的前limit条回复
+func (s *commentSrv) GetCommentRepliesByID(ids []int64, style cs.StyleReplyType, limit int) ([]*ms.CommentReplyFormated, error) {
+	var replies []*dbr.CommentReply
+	for _, id := range ids {
+		items, err := s.listReplies(id, style, nil, limit)
+		if err != nil {
+			return nil, err
+		}
+		replies = append(replies, items...)
+	}
+	return s.formatReplies(replies)
+}
+
+// GetCommentReplies 从游标处开始分页获取评论的回复
+func (s *commentSrv) GetCommentReplies(commentId int64, style cs.StyleReplyType, cursor *cs.ReplyCursor, limit int) ([]*ms.CommentReplyFormated, error) {
+	replies, err := s.listReplies(commentId, style, cursor, limit)
 	if err != nil {
 		return nil, err
 	}
+	return s.formatReplies(replies)
+}
+
+func (s *commentSrv) listReplies(commentId int64, style cs.StyleReplyType, cursor *cs.ReplyCursor, limit int) (res []*dbr.CommentReply, err error) {
+	db := s.db.Table(_commentReply_).Where("comment_id=? AND is_del=0", commentId)
+	sort := "id ASC"
+	switch style {
+	case cs.StyleReplyHots:
+		// 点赞数相同的按最新排序
+		sort = "thumbs_up_count DESC, id DESC"
+		if cursor != nil {
+			db = db.Where("(thumbs_up_count<? OR (thumbs_up_count=? AND id<?))", cursor.ThumbsUpCount, cursor.ThumbsUpCount, cursor.ID)
+		}
+	case cs.StyleReplyNewest:
+		sort = "id DESC"
+		if cursor != nil {
+			db = db.Where("id<?", cursor.ID)
+		}
+	case cs.StyleReplyDefault:
+		fallthrough
+	default:
+		if cursor != nil {
+			db = db.Where("id>?", cursor.ID)
+		}
+	}
+	err = db.Order(sort).Limit(limit).Find(&res).Error
+	return
+}
+
+func (s *commentSrv) formatReplies(replies []*dbr.CommentReply) ([]*ms.CommentReplyFormated, error) {
 	userIds := []int64{}
 	for _, reply := range replies {
 		userIds = append(userIds, reply.UserID, reply.AtUserID)
@@ -148,6 +200,11 @@ func (s *commentSrv) GetCommentRepliesByID(ids []int64) ([]*ms.CommentReplyForma
 	return repliesFormated, nil
 }
 
+// ListCommentRevisions 获取评论或回复的编辑历史，replyId为0时获取评论本身的编辑历史
+func (s *commentSrv) ListCommentRevisions(commentId int64, replyId int64) ([]*ms.CommentRevision, error) {
+	return (&dbr.CommentRevision{}).List(s.db, commentId, replyId)
+}
+
 func (s *commentManageSrv) HighlightComment(userId, commentId int64) (isEssence int8, err error) {
 	post := &dbr.Post{}
 	comment := &dbr.Comment{}
@@ -223,6 +280,79 @@ func (s *commentManageSrv) CreateCommentContent(content *ms.CommentContent) (*ms
 	return content.Create(s.db)
 }
 
+// EditComment 编辑评论内容，旧内容归档为新的历史版本后再写入新内容
+func (s *commentManageSrv) EditComment(comment *ms.Comment, editorId int64, contents []*ms.CommentContent) error {
+	commentContent := &dbr.CommentContent{}
+	revision := &dbr.CommentRevision{}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		oldContents, err := commentContent.List(tx, &dbr.ConditionsT{
+			"comment_id = ?": comment.ID,
+			"ORDER":          "sort ASC",
+		}, 0, 0)
+		if err != nil {
+			return err
+		}
+		latest, err := revision.LatestRevision(tx, comment.ID, 0)
+		if err != nil {
+			return err
+		}
+		// 归档旧内容
+		for _, c := range oldContents {
+			r := &dbr.CommentRevision{
+				CommentID: comment.ID,
+				UserID:    comment.UserID,
+				EditorID:  editorId,
+				Revision:  latest + 1,
+				Content:   c.Content,
+				Type:      c.Type,
+				Sort:      c.Sort,
+			}
+			if _, err = r.Create(tx); err != nil {
+				return err
+			}
+		}
+		if err = commentContent.DeleteByCommentIds(tx, []int64{comment.ID}); err != nil {
+			return err
+		}
+		for _, c := range contents {
+			c.CommentID = comment.ID
+			if _, err = c.Create(tx); err != nil {
+				return err
+			}
+		}
+		comment.EditedOn = time.Now().Unix()
+		return tx.Model(comment).Update("edited_on", comment.EditedOn).Error
+	})
+}
+
+// EditCommentReply 编辑回复内容，旧内容归档为新的历史版本
+func (s *commentManageSrv) EditCommentReply(reply *ms.CommentReply, editorId int64, content string) error {
+	revision := &dbr.CommentRevision{}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		latest, err := revision.LatestRevision(tx, reply.CommentID, reply.ID)
+		if err != nil {
+			return err
+		}
+		r := &dbr.CommentRevision{
+			CommentID: reply.CommentID,
+			ReplyID:   reply.ID,
+			UserID:    reply.UserID,
+			EditorID:  editorId,
+			Revision:  latest + 1,
+			Content:   reply.Content,
+			Type:      dbr.ContentTypeText,
+		}
+		if _, err = r.Create(tx); err != nil {
+			return err
+		}
+		reply.Content, reply.EditedOn = content, time.Now().Unix()
+		return tx.Model(reply).Updates(map[string]any{
+			"content":   reply.Content,
+			"edited_on": reply.EditedOn,
+		}).Error
+	})
+}
+
 func (s *commentManageSrv) ThumbsUpComment(userId int64, tweetId, commentId int64) error {
 	db := s.db.Begin()
 	defer db.Rollback()
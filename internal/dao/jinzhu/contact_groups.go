@@ -0,0 +1,135 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package jinzhu
+
+import (
+	"fmt"
+
+	"github.com/rocboss/paopao-ce/internal/core"
+	"github.com/rocboss/paopao-ce/internal/core/ms"
+	"github.com/rocboss/paopao-ce/internal/dao/jinzhu/dbr"
+	"gorm.io/gorm"
+)
+
+var (
+	_ core.ContactGroupService = (*contactGroupSrv)(nil)
+)
+
+type contactGroupSrv struct {
+	db *gorm.DB
+}
+
+func newContactGroupService(db *gorm.DB) core.ContactGroupService {
+	return &contactGroupSrv{
+		db: db,
+	}
+}
+
+// audienceGroupsSQL 访问者所在的分组，即作者把访问者加入的分组，使用时需传入访问者ID
+func audienceGroupsSQL() string {
+	return fmt.Sprintf("SELECT group_id FROM %s WHERE friend_id = ? AND status = %d AND group_id > 0 AND is_del = 0", _contact_, dbr.ContactStatusAgree)
+}
+
+// audienceWhere 分组可见的推文只对被作者加入所选分组的好友可见，使用时需传入访问者ID
+func audienceWhere() string {
+	return fmt.Sprintf("(visibility = %d AND id IN (SELECT post_id FROM %s WHERE is_del = 0 AND group_id IN (%s)))", dbr.PostVisitGroup, _postAudience_, audienceGroupsSQL())
+}
+
+func (s *contactGroupSrv) CreateContactGroup(userId int64, name string) (*ms.ContactGroup, error) {
+	return (&dbr.ContactGroup{
+		UserId: userId,
+		Name:   name,
+	}).Create(s.db)
+}
+
+func (s *contactGroupSrv) GetContactGroup(id int64) (*ms.ContactGroup, error) {
+	return (&dbr.ContactGroup{Model: &dbr.Model{ID: id}}).Get(s.db)
+}
+
+func (s *contactGroupSrv) ListContactGroups(userId int64) ([]*ms.ContactGroupFormated, error) {
+	groups, err := (&dbr.ContactGroup{}).ListByUser(s.db, userId)
+	if err != nil {
+		return nil, err
+	}
+	var counts []struct {
+		GroupId int64
+		Total   int64
+	}
+	if err = s.db.Table(_contact_).Select("group_id, count(*) AS total").
+		Where("user_id = ? AND status = ? AND group_id > 0 AND is_del = 0", userId, dbr.ContactStatusAgree).
+		Group("group_id").Find(&counts).Error; err != nil {
+		return nil, err
+	}
+	countMap := make(map[int64]int64, len(counts))
+	for _, c := range counts {
+		countMap[c.GroupId] = c.Total
+	}
+	res := make([]*ms.ContactGroupFormated, 0, len(groups))
+	for _, group := range groups {
+		item := group.Format()
+		item.ContactCount = countMap[group.ID]
+		res = append(res, item)
+	}
+	return res, nil
+}
+
+func (s *contactGroupSrv) CountContactGroups(userId int64) (int64, error) {
+	return (&dbr.ContactGroup{}).CountByUser(s.db, userId)
+}
+
+func (s *contactGroupSrv) RenameContactGroup(group *ms.ContactGroup, name string) error {
+	group.Name = name
+	return group.Update(s.db)
+}
+
+func (s *contactGroupSrv) DeleteContactGroup(group *ms.ContactGroup) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := group.Delete(tx); err != nil {
+			return err
+		}
+		// 分组内的好友移出分组
+		if err := tx.Table(_contact_).Where("user_id = ? AND group_id = ?", group.UserId, group.ID).Update("group_id", 0).Error; err != nil {
+			return err
+		}
+		return (&dbr.PostAudience{}).DeleteByGroup(tx, group.ID)
+	})
+}
+
+// SetContactGroup 把好友加入分组，groupId为0时移出分组
+func (s *contactGroupSrv) SetContactGroup(userId int64, friendId int64, groupId int64) error {
+	db := s.db.Table(_contact_).Where("user_id = ? AND friend_id = ? AND status = ? AND is_del = 0", userId, friendId, dbr.ContactStatusAgree).Update("group_id", groupId)
+	if db.Error != nil {
+		return db.Error
+	}
+	if db.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// SetPostAudience 重新设置推文所选的分组
+func (s *contactGroupSrv) SetPostAudience(postId int64, groupIds []int64) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := (&dbr.PostAudience{}).DeleteByPost(tx, postId); err != nil {
+			return err
+		}
+		for _, groupId := range groupIds {
+			if _, err := (&dbr.PostAudience{PostId: postId, GroupId: groupId}).Create(tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *contactGroupSrv) GetPostAudience(postId int64) ([]int64, error) {
+	return (&dbr.PostAudience{}).GroupIds(s.db, postId)
+}
+
+func (s *contactGroupSrv) IsPostAudience(postId int64, userId int64) bool {
+	var count int64
+	err := s.db.Table(_postAudience_).Where(fmt.Sprintf("post_id = ? AND is_del = 0 AND group_id IN (%s)", audienceGroupsSQL()), postId, userId).Count(&count).Error
+	return err == nil && count > 0
+}
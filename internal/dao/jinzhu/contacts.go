@@ -253,6 +253,7 @@ func (s *contactManageSrv) GetContacts(userId int64, offset int, limit int) (*ms
 				Nickname:  c.User.Nickname,
 				Avatar:    c.User.Avatar,
 				Phone:     c.User.Phone,
+				GroupId:   c.GroupId,
 				CreatedOn: c.User.CreatedOn,
 			})
 		}
@@ -29,3 +29,9 @@ func (a *Attachment) Create(db *gorm.DB) (*Attachment, error) {
 
 	return a, err
 }
+
+// ListUserContents 获取contents中由该用户上传的附件地址
+func (a *Attachment) ListUserContents(db *gorm.DB, userId int64, contents []string) (res []string, err error) {
+	err = db.Model(a).Where("user_id = ? AND content IN ? AND is_del = 0", userId, contents).Distinct().Pluck("content", &res).Error
+	return
+}
@@ -24,6 +24,12 @@ func (c *Captcha) Update(db *gorm.DB) error {
 	return db.Model(&Captcha{}).Where("id = ? AND is_del = ?", c.Model.ID, 0).Save(c).Error
 }
 
+// Use 使用次数未达到maxTimes时增加一次使用次数
+func (c *Captcha) Use(db *gorm.DB, maxTimes int) (int64, error) {
+	res := db.Model(&Captcha{}).Where("id = ? AND use_times < ? AND is_del = 0", c.Model.ID, maxTimes).Update("use_times", gorm.Expr("use_times + 1"))
+	return res.RowsAffected, res.Error
+}
+
 func (c *Captcha) Get(db *gorm.DB) (*Captcha, error) {
 	var captcha Captcha
 	if c.Model != nil && c.ID > 0 {
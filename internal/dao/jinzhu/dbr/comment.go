@@ -21,6 +21,7 @@ type Comment struct {
 	ReplyCount      int32  `json:"reply_count"`
 	ThumbsUpCount   int32  `json:"thumbs_up_count"`
 	ThumbsDownCount int32  `json:"-"`
+	EditedOn        int64  `json:"edited_on"`
 }
 
 type CommentFormated struct {
@@ -36,6 +37,9 @@ type CommentFormated struct {
 	IsEssence     int8                    `json:"is_essence"`
 	IsThumbsUp    int8                    `json:"is_thumbs_up"`
 	IsThumbsDown  int8                    `json:"is_thumbs_down"`
+	Reactions     []*ReactionCount        `json:"reactions"`
+	MyReactions   []string                `json:"my_reactions"`
+	EditedOn      int64                   `json:"edited_on"`
 	CreatedOn     int64                   `json:"created_on"`
 	ModifiedOn    int64                   `json:"modified_on"`
 }
@@ -57,6 +61,9 @@ func (c *Comment) Format() *CommentFormated {
 		IsEssence:     c.IsEssence,
 		IsThumbsUp:    types.No,
 		IsThumbsDown:  types.No,
+		Reactions:     []*ReactionCount{},
+		MyReactions:   []string{},
+		EditedOn:      c.EditedOn,
 		CreatedOn:     c.CreatedOn,
 		ModifiedOn:    c.ModifiedOn,
 	}
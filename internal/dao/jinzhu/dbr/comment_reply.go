@@ -21,6 +21,7 @@ type CommentReply struct {
 	IPLoc           string `json:"ip_loc"`
 	ThumbsUpCount   int32  `json:"thumbs_up_count"`
 	ThumbsDownCount int32  `json:"-"`
+	EditedOn        int64  `json:"edited_on"`
 }
 
 type CommentReplyFormated struct {
@@ -35,6 +36,7 @@ type CommentReplyFormated struct {
 	ThumbsUpCount int32         `json:"thumbs_up_count"`
 	IsThumbsUp    int8          `json:"is_thumbs_up"`
 	IsThumbsDown  int8          `json:"is_thumbs_down"`
+	EditedOn      int64         `json:"edited_on"`
 	CreatedOn     int64         `json:"created_on"`
 	ModifiedOn    int64         `json:"modified_on"`
 }
@@ -56,6 +58,7 @@ func (c *CommentReply) Format() *CommentReplyFormated {
 		ThumbsUpCount: c.ThumbsUpCount,
 		IsThumbsUp:    types.No,
 		IsThumbsDown:  types.No,
+		EditedOn:      c.EditedOn,
 		CreatedOn:     c.CreatedOn,
 		ModifiedOn:    c.ModifiedOn,
 	}
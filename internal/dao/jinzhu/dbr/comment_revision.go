@@ -0,0 +1,67 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package dbr
+
+import (
+	"gorm.io/gorm"
+)
+
+// CommentRevision 评论或回复编辑前的历史内容，ReplyID为0时是评论本身的历史内容
+type CommentRevision struct {
+	*Model
+	CommentID int64        `json:"comment_id"`
+	ReplyID   int64        `json:"reply_id"`
+	UserID    int64        `json:"user_id"`
+	EditorID  int64        `json:"editor_id"`
+	Revision  int64        `json:"revision"`
+	Content   string       `json:"content"`
+	Type      PostContentT `json:"type"`
+	Sort      int64        `json:"sort"`
+}
+
+type CommentRevisionFormated struct {
+	ID        int64        `json:"id"`
+	CommentID int64        `json:"comment_id"`
+	ReplyID   int64        `json:"reply_id"`
+	EditorID  int64        `json:"editor_id"`
+	Revision  int64        `json:"revision"`
+	Content   string       `json:"content"`
+	Type      PostContentT `json:"type"`
+	Sort      int64        `json:"sort"`
+	CreatedOn int64        `json:"created_on"`
+}
+
+func (c *CommentRevision) Format() *CommentRevisionFormated {
+	if c.Model == nil {
+		return nil
+	}
+	return &CommentRevisionFormated{
+		ID:        c.ID,
+		CommentID: c.CommentID,
+		ReplyID:   c.ReplyID,
+		EditorID:  c.EditorID,
+		Revision:  c.Revision,
+		Content:   c.Content,
+		Type:      c.Type,
+		Sort:      c.Sort,
+		CreatedOn: c.CreatedOn,
+	}
+}
+
+func (c *CommentRevision) Create(db *gorm.DB) (*CommentRevision, error) {
+	err := db.Create(&c).Error
+	return c, err
+}
+
+// LatestRevision 获取评论或回复当前最大的历史版本号，没有历史版本时返回0
+func (c *CommentRevision) LatestRevision(db *gorm.DB, commentId int64, replyId int64) (revision int64, err error) {
+	err = db.Model(c).Where("comment_id = ? AND reply_id = ? AND is_del = 0", commentId, replyId).Select("COALESCE(MAX(revision), 0)").Scan(&revision).Error
+	return
+}
+
+func (c *CommentRevision) List(db *gorm.DB, commentId int64, replyId int64) (res []*CommentRevision, err error) {
+	err = db.Where("comment_id = ? AND reply_id = ? AND is_del = 0", commentId, replyId).Order("revision DESC, sort ASC").Find(&res).Error
+	return
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package dbr
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ContactGroup 联系人分组，用户可以把好友加入自己的分组，发布仅分组可见的推文
+type ContactGroup struct {
+	*Model
+	UserId int64  `json:"user_id"`
+	Name   string `json:"name"`
+}
+
+type ContactGroupFormated struct {
+	ID           int64  `json:"id"`
+	UserId       int64  `json:"user_id"`
+	Name         string `json:"name"`
+	ContactCount int64  `json:"contact_count"`
+	CreatedOn    int64  `json:"created_on"`
+}
+
+func (g *ContactGroup) Format() *ContactGroupFormated {
+	if g.Model == nil {
+		return nil
+	}
+	return &ContactGroupFormated{
+		ID:        g.ID,
+		UserId:    g.UserId,
+		Name:      g.Name,
+		CreatedOn: g.CreatedOn,
+	}
+}
+
+func (g *ContactGroup) Get(db *gorm.DB) (*ContactGroup, error) {
+	var group ContactGroup
+	if err := db.Where("id = ? AND is_del = 0", g.ID).First(&group).Error; err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (g *ContactGroup) Create(db *gorm.DB) (*ContactGroup, error) {
+	err := db.Create(&g).Error
+	return g, err
+}
+
+func (g *ContactGroup) Update(db *gorm.DB) error {
+	return db.Model(&ContactGroup{}).Where("id = ? AND is_del = 0", g.ID).Update("name", g.Name).Error
+}
+
+func (g *ContactGroup) Delete(db *gorm.DB) error {
+	return db.Model(g).Where("id = ?", g.ID).Updates(map[string]any{
+		"deleted_on": time.Now().Unix(),
+		"is_del":     1,
+	}).Error
+}
+
+// ListByUser 获取用户的全部分组
+func (g *ContactGroup) ListByUser(db *gorm.DB, userId int64) (res []*ContactGroup, err error) {
+	err = db.Where("user_id = ? AND is_del = 0", userId).Order("id ASC").Find(&res).Error
+	return
+}
+
+// CountByUser 用户已创建的分组数
+func (g *ContactGroup) CountByUser(db *gorm.DB, userId int64) (res int64, err error) {
+	err = db.Model(g).Where("user_id = ? AND is_del = 0", userId).Count(&res).Error
+	return
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package dbr
+
+import (
+	"gorm.io/gorm"
+)
+
+// 链接预览抓取状态
+const (
+	LinkPreviewFetched int8 = iota + 1
+	LinkPreviewFailed
+)
+
+// LinkPreview 链接预览，按链接的MD5去重
+type LinkPreview struct {
+	*Model
+	UrlHash     string `json:"url_hash"`
+	URL         string `json:"url"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Image       string `json:"image"`
+	SiteName    string `json:"site_name"`
+	Status      int8   `json:"status"`
+}
+
+type LinkPreviewFormated struct {
+	URL         string `json:"url"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Image       string `json:"image"`
+	SiteName    string `json:"site_name"`
+}
+
+func (p *LinkPreview) Format() *LinkPreviewFormated {
+	if p.Model == nil || p.Status != LinkPreviewFetched {
+		return nil
+	}
+	return &LinkPreviewFormated{
+		URL:         p.URL,
+		Title:       p.Title,
+		Description: p.Description,
+		Image:       p.Image,
+		SiteName:    p.SiteName,
+	}
+}
+
+func (p *LinkPreview) Get(db *gorm.DB) (*LinkPreview, error) {
+	var preview LinkPreview
+	if err := db.Where("url_hash = ? AND is_del = ?", p.UrlHash, 0).First(&preview).Error; err != nil {
+		return nil, err
+	}
+	return &preview, nil
+}
+
+func (p *LinkPreview) ListByHashes(db *gorm.DB, hashes []string) (res []*LinkPreview, err error) {
+	err = db.Where("url_hash IN ? AND is_del = ?", hashes, 0).Find(&res).Error
+	return
+}
+
+func (p *LinkPreview) Create(db *gorm.DB) (*LinkPreview, error) {
+	err := db.Create(&p).Error
+	return p, err
+}
+
+func (p *LinkPreview) Update(db *gorm.DB) error {
+	return db.Model(&LinkPreview{}).Where("id = ? AND is_del = ?", p.Model.ID, 0).Save(p).Error
+}
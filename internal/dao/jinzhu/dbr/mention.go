@@ -0,0 +1,121 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package dbr
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Mention @提及，来源为动态、评论或评论回复，由post_id/comment_id/reply_id共同确定
+type Mention struct {
+	*Model
+	Post      *Post `json:"-"`
+	UserID    int64 `json:"user_id"`
+	AuthorID  int64 `json:"author_id"`
+	PostID    int64 `json:"post_id"`
+	CommentID int64 `json:"comment_id"`
+	ReplyID   int64 `json:"reply_id"`
+}
+
+type MentionFormated struct {
+	ID        int64         `json:"id"`
+	UserID    int64         `json:"user_id"`
+	AuthorID  int64         `json:"author_id"`
+	Author    *UserFormated `json:"author"`
+	PostID    int64         `json:"post_id"`
+	Post      *PostFormated `json:"post"`
+	CommentID int64         `json:"comment_id"`
+	Comment   *Comment      `json:"comment"`
+	ReplyID   int64         `json:"reply_id"`
+	Reply     *CommentReply `json:"reply"`
+	CreatedOn int64         `json:"created_on"`
+}
+
+func (m *Mention) Format() *MentionFormated {
+	if m.Model == nil {
+		return nil
+	}
+	return &MentionFormated{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		AuthorID:  m.AuthorID,
+		PostID:    m.PostID,
+		CommentID: m.CommentID,
+		ReplyID:   m.ReplyID,
+		CreatedOn: m.CreatedOn,
+	}
+}
+
+func (m *Mention) Create(db *gorm.DB) (*Mention, error) {
+	err := db.Omit("Post").Create(&m).Error
+	return m, err
+}
+
+// ListBySource 获取指定来源本身的提及
+func (m *Mention) ListBySource(db *gorm.DB) (res []*Mention, err error) {
+	err = db.Omit("Post").Where("post_id = ? AND comment_id = ? AND reply_id = ? AND is_del = 0", m.PostID, m.CommentID, m.ReplyID).Find(&res).Error
+	return
+}
+
+// ListUnder 获取指定来源及其下级来源的提及，如动态下所有评论与回复中的提及
+func (m *Mention) ListUnder(db *gorm.DB) (res []*Mention, err error) {
+	switch {
+	case m.ReplyID > 0:
+		db = db.Where("reply_id = ?", m.ReplyID)
+	case m.CommentID > 0:
+		db = db.Where("comment_id = ?", m.CommentID)
+	default:
+		db = db.Where("post_id = ?", m.PostID)
+	}
+	err = db.Omit("Post").Where("is_del = 0").Find(&res).Error
+	return
+}
+
+func (m *Mention) DeleteByIds(db *gorm.DB, ids []int64) error {
+	return db.Model(&Mention{}).Omit("Post").Where("id IN ?", ids).Updates(map[string]any{
+		"deleted_on": time.Now().Unix(),
+		"is_del":     1,
+	}).Error
+}
+
+// List 获取用户在指定动态范围内被@的记录，postIds为动态ID的子查询
+func (m *Mention) List(db *gorm.DB, userId int64, postIds *gorm.DB, limit int, offset int) (res []*Mention, total int64, err error) {
+	query := db.Model(m).Where("user_id = ? AND post_id IN (?) AND is_del = 0", userId, postIds)
+	if err = query.Count(&total).Error; err != nil || total == 0 {
+		return
+	}
+	if offset >= 0 && limit > 0 {
+		query = query.Offset(offset).Limit(limit)
+	}
+	if err = query.Omit("Post").Order("id DESC").Find(&res).Error; err != nil {
+		return
+	}
+	err = m.fillPosts(db, res)
+	return
+}
+
+func (m *Mention) fillPosts(db *gorm.DB, mentions []*Mention) error {
+	if len(mentions) == 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(mentions))
+	for _, mention := range mentions {
+		ids = append(ids, mention.PostID)
+	}
+	var posts []*Post
+	if err := db.Where("id IN ?", ids).Find(&posts).Error; err != nil {
+		return err
+	}
+	postMap := make(map[int64]*Post, len(posts))
+	for _, post := range posts {
+		postMap[post.ID] = post
+	}
+	for _, mention := range mentions {
+		mention.Post = postMap[mention.PostID]
+	}
+	return nil
+}
@@ -11,14 +11,45 @@ import (
 	"gorm.io/gorm"
 )
 
-// PostVisibleT 可访问类型，可见性: 0私密 10充电可见 20订阅可见 30保留 40保留 50好友可见 60关注可见 70保留 80保留 90公开',
+// PostVisibleT 可访问类型，可见性: 0私密 10充电可见 20订阅可见 30分组可见 40保留 50好友可见 60关注可见 70保留 80保留 90公开',
 type PostVisibleT uint8
 
 const (
-	PostVisitPublic    PostVisibleT = 90
-	PostVisitPrivate   PostVisibleT = 0
-	PostVisitFriend    PostVisibleT = 50
-	PostVisitFollowing PostVisibleT = 60
+	PostVisitPublic       PostVisibleT = 90
+	PostVisitPrivate      PostVisibleT = 0
+	PostVisitCharge       PostVisibleT = 10
+	PostVisitSubscription PostVisibleT = 20
+	PostVisitGroup        PostVisibleT = 30
+	PostVisitFriend       PostVisibleT = 50
+	PostVisitFollowing    PostVisibleT = 60
+)
+
+var (
+	// PaidVisibility 付费可见的可见性，未付费的访问者只能看到预览
+	PaidVisibility = []PostVisibleT{
+		PostVisitCharge,
+		PostVisitSubscription,
+	}
+)
+
+// PostSensitiveT 敏感标记: 0未标记 1作者标记 2管理员强制标记，管理员强制的标记作者不能取消
+type PostSensitiveT int8
+
+const (
+	PostSensitiveNone PostSensitiveT = iota
+	PostSensitiveByAuthor
+	PostSensitiveByAdmin
+)
+
+// PostReplyPolicyT 回复权限: 0所有人 1关注者 2好友 3被@的用户 4仅作者，作者本人总是可以回复
+type PostReplyPolicyT int8
+
+const (
+	PostReplyEveryone PostReplyPolicyT = iota
+	PostReplyFollowers
+	PostReplyFriends
+	PostReplyMentioned
+	PostReplyNobody
 )
 
 type PostByMedia = Post
@@ -27,20 +58,33 @@ type PostByComment = Post
 
 type Post struct {
 	*Model
-	UserID          int64        `json:"user_id"`
-	CommentCount    int64        `json:"comment_count"`
-	CollectionCount int64        `json:"collection_count"`
-	ShareCount      int64        `json:"share_count"`
-	UpvoteCount     int64        `json:"upvote_count"`
-	Visibility      PostVisibleT `json:"visibility"`
-	IsTop           int          `json:"is_top"`
-	IsEssence       int          `json:"is_essence"`
-	IsLock          int          `json:"is_lock"`
-	LatestRepliedOn int64        `json:"latest_replied_on"`
-	Tags            string       `json:"tags"`
-	AttachmentPrice int64        `json:"attachment_price"`
-	IP              string       `json:"ip"`
-	IPLoc           string       `json:"ip_loc"`
+	UserID          int64            `json:"user_id"`
+	CommentCount    int64            `json:"comment_count"`
+	CollectionCount int64            `json:"collection_count"`
+	ShareCount      int64            `json:"share_count"`
+	UpvoteCount     int64            `json:"upvote_count"`
+	Visibility      PostVisibleT     `json:"visibility"`
+	IsTop           int              `json:"is_top"`
+	IsEssence       int              `json:"is_essence"`
+	IsLock          int              `json:"is_lock"`
+	LatestRepliedOn int64            `json:"latest_replied_on"`
+	Tags            string           `json:"tags"`
+	AttachmentPrice int64            `json:"attachment_price"`
+	IP              string           `json:"ip"`
+	IPLoc           string           `json:"ip_loc"`
+	EditedOn        int64            `json:"edited_on"`
+	RepostID        int64            `json:"repost_id"`
+	ThreadRootID    int64            `json:"thread_root_id"`
+	ThreadParentID  int64            `json:"thread_parent_id"`
+	ThreadCount     int64            `json:"thread_count"`
+	PublishAt       int64            `json:"publish_at"`
+	ReactionCount   int64            `json:"reaction_count"`
+	ViewCount       int64            `json:"view_count"`
+	PinnedOn        int64            `json:"pinned_on"`
+	IsSensitive     PostSensitiveT   `json:"is_sensitive"`
+	ContentWarning  string           `json:"content_warning"`
+	ExpiresAt       int64            `json:"expires_at"`
+	ReplyPolicy     PostReplyPolicyT `json:"reply_policy"`
 }
 
 type PostFormated struct {
@@ -62,6 +106,25 @@ type PostFormated struct {
 	Tags            map[string]int8        `json:"tags"`
 	AttachmentPrice int64                  `json:"attachment_price"`
 	IPLoc           string                 `json:"ip_loc"`
+	EditedOn        int64                  `json:"edited_on"`
+	RepostID        int64                  `json:"repost_id"`
+	Repost          *PostFormated          `json:"repost"`
+	ThreadRootID    int64                  `json:"thread_root_id"`
+	ThreadParentID  int64                  `json:"thread_parent_id"`
+	ThreadCount     int64                  `json:"thread_count"`
+	PublishAt       int64                  `json:"publish_at"`
+	Poll            *PostPollFormated      `json:"poll"`
+	ReactionCount   int64                  `json:"reaction_count"`
+	ViewCount       int64                  `json:"view_count"`
+	PinnedOn        int64                  `json:"pinned_on"`
+	IsSensitive     bool                   `json:"is_sensitive"`
+	ContentWarning  string                 `json:"content_warning"`
+	ExpiresAt       int64                  `json:"expires_at"`
+	ReplyPolicy     PostReplyPolicyT       `json:"reply_policy"`
+	Collapsed       bool                   `json:"collapsed"`
+	IsLocked        bool                   `json:"is_locked"`
+	Reactions       []*ReactionCount       `json:"reactions"`
+	MyReactions     []string               `json:"my_reactions"`
 }
 
 func (t PostVisibleT) ToOutValue() (res uint8) {
@@ -74,6 +137,12 @@ func (t PostVisibleT) ToOutValue() (res uint8) {
 		res = 2
 	case PostVisitFollowing:
 		res = 3
+	case PostVisitCharge:
+		res = 4
+	case PostVisitSubscription:
+		res = 5
+	case PostVisitGroup:
+		res = 6
 	default:
 		res = 1
 	}
@@ -105,12 +174,36 @@ func (p *Post) Format() *PostFormated {
 			AttachmentPrice: p.AttachmentPrice,
 			Tags:            tagsMap,
 			IPLoc:           p.IPLoc,
+			EditedOn:        p.EditedOn,
+			RepostID:        p.RepostID,
+			ThreadRootID:    p.ThreadRootID,
+			ThreadParentID:  p.ThreadParentID,
+			ThreadCount:     p.ThreadCount,
+			PublishAt:       p.PublishAt,
+			ReactionCount:   p.ReactionCount,
+			ViewCount:       p.ViewCount,
+			PinnedOn:        p.PinnedOn,
+			IsSensitive:     p.IsSensitive != PostSensitiveNone,
+			ContentWarning:  p.ContentWarning,
+			ExpiresAt:       p.ExpiresAt,
+			ReplyPolicy:     p.ReplyPolicy,
+			Reactions:       []*ReactionCount{},
+			MyReactions:     []string{},
 		}
 	}
 
 	return nil
 }
 
+func (t PostReplyPolicyT) IsValid() bool {
+	return t >= PostReplyEveryone && t <= PostReplyNobody
+}
+
+// IsExpired 限时推文是否已过期
+func (p *Post) IsExpired() bool {
+	return p.ExpiresAt > 0 && p.ExpiresAt <= time.Now().Unix()
+}
+
 func (p *Post) Create(db *gorm.DB) (*Post, error) {
 	err := db.Create(&p).Error
 
@@ -227,7 +320,37 @@ func (p PostVisibleT) String() string {
 		return "private"
 	case PostVisitFriend:
 		return "friend"
+	case PostVisitCharge:
+		return "charge"
+	case PostVisitSubscription:
+		return "subscription"
+	case PostVisitGroup:
+		return "group"
 	default:
 		return "unknow"
 	}
 }
+
+// IsPaid 是否为付费可见
+func (p PostVisibleT) IsPaid() bool {
+	return p == PostVisitCharge || p == PostVisitSubscription
+}
+
+// Lock 未付费的访问者只能看到标题与截断后的首段文字，其余内容与投票均隐藏
+func (p *PostFormated) Lock(teaserLength int) {
+	var contents []*PostContentFormated
+	hasText := false
+	for _, content := range p.Contents {
+		switch {
+		case content.Type == ContentTypeTitle:
+			contents = append(contents, content)
+		case content.Type == ContentTypeText && !hasText:
+			hasText = true
+			if text := []rune(content.Content); len(text) > teaserLength {
+				content.Content = string(text[:teaserLength]) + "..."
+			}
+			contents = append(contents, content)
+		}
+	}
+	p.Contents, p.Poll, p.IsLocked = contents, nil, true
+}
@@ -0,0 +1,45 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package dbr
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PostAudience 分组可见推文所选的联系人分组，被作者加入其中任一分组的好友可见
+type PostAudience struct {
+	*Model
+	PostId  int64 `json:"post_id"`
+	GroupId int64 `json:"group_id"`
+}
+
+func (a *PostAudience) Create(db *gorm.DB) (*PostAudience, error) {
+	err := db.Create(&a).Error
+	return a, err
+}
+
+// GroupIds 获取推文所选的分组
+func (a *PostAudience) GroupIds(db *gorm.DB, postId int64) (res []int64, err error) {
+	err = db.Model(a).Where("post_id = ? AND is_del = 0", postId).Pluck("group_id", &res).Error
+	return
+}
+
+// DeleteByPost 删除推文所选的全部分组
+func (a *PostAudience) DeleteByPost(db *gorm.DB, postId int64) error {
+	return db.Model(a).Where("post_id = ? AND is_del = 0", postId).Updates(map[string]any{
+		"deleted_on": time.Now().Unix(),
+		"is_del":     1,
+	}).Error
+}
+
+// DeleteByGroup 分组删除后其可见记录一并删除，相关推文只有作者本人可见
+func (a *PostAudience) DeleteByGroup(db *gorm.DB, groupId int64) error {
+	return db.Model(a).Where("group_id = ? AND is_del = 0", groupId).Updates(map[string]any{
+		"deleted_on": time.Now().Unix(),
+		"is_del":     1,
+	}).Error
+}
@@ -13,9 +13,10 @@ import (
 
 type PostCollection struct {
 	*Model
-	Post   *Post `json:"-"`
-	PostID int64 `db:"post_id" json:"post_id"`
-	UserID int64 `db:"user_id" json:"user_id"`
+	Post     *Post `json:"-"`
+	PostID   int64 `db:"post_id" json:"post_id"`
+	UserID   int64 `db:"user_id" json:"user_id"`
+	FolderID int64 `db:"folder_id" json:"folder_id"`
 }
 
 func (p *PostCollection) Get(db *gorm.DB) (*PostCollection, error) {
@@ -105,3 +106,27 @@ func (p *PostCollection) Count(db *gorm.DB, conditions *ConditionsT) (int64, err
 
 	return count, nil
 }
+
+// ListByFolderId 获取收藏夹中的收藏，justPublic为true时只包含公开的推文
+func (p *PostCollection) ListByFolderId(db *gorm.DB, folderId int64, justPublic bool, limit, offset int) (res []*PostCollection, total int64, err error) {
+	tn := db.NamingStrategy.TableName("PostCollection") + "."
+	db = db.Model(p).Joins("Post").Where(tn+"folder_id = ? AND "+tn+"is_del = ?", folderId, 0)
+	if justPublic {
+		db = db.Where(clause.Eq{Column: clause.Column{Table: "Post", Name: "visibility"}, Value: PostVisitPublic})
+	} else {
+		db = db.Where("visibility <> ? OR (visibility = ? AND ? = ?)", PostVisitPrivate, PostVisitPrivate, clause.Column{Table: "Post", Name: "user_id"}, clause.Column{Table: tn[:len(tn)-1], Name: "user_id"})
+	}
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	if offset >= 0 && limit > 0 {
+		db = db.Offset(offset).Limit(limit)
+	}
+	err = db.Order(clause.OrderByColumn{Column: clause.Column{Table: tn[:len(tn)-1], Name: "id"}, Desc: true}).Find(&res).Error
+	return
+}
+
+// MoveToFolder 将收藏移动到指定收藏夹
+func (p *PostCollection) MoveToFolder(db *gorm.DB, folderId int64) error {
+	return db.Model(&PostCollection{}).Omit("Post").Where("id = ? AND is_del = ?", p.Model.ID, 0).Update("folder_id", folderId).Error
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package dbr
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PostCollectionFolder 收藏夹，每个用户有一个不可删除的默认收藏夹
+type PostCollectionFolder struct {
+	*Model
+	UserID    int64  `json:"user_id"`
+	Name      string `json:"name"`
+	IsPublic  int8   `json:"is_public"`
+	IsDefault int8   `json:"is_default"`
+}
+
+type PostCollectionFolderFormated struct {
+	ID              int64  `json:"id"`
+	UserID          int64  `json:"user_id"`
+	Name            string `json:"name"`
+	IsPublic        bool   `json:"is_public"`
+	IsDefault       bool   `json:"is_default"`
+	CollectionCount int64  `json:"collection_count"`
+	CreatedOn       int64  `json:"created_on"`
+	ModifiedOn      int64  `json:"modified_on"`
+}
+
+func (f *PostCollectionFolder) Format() *PostCollectionFolderFormated {
+	if f.Model == nil {
+		return nil
+	}
+	return &PostCollectionFolderFormated{
+		ID:         f.ID,
+		UserID:     f.UserID,
+		Name:       f.Name,
+		IsPublic:   f.IsPublic == 1,
+		IsDefault:  f.IsDefault == 1,
+		CreatedOn:  f.CreatedOn,
+		ModifiedOn: f.ModifiedOn,
+	}
+}
+
+func (f *PostCollectionFolder) Get(db *gorm.DB) (*PostCollectionFolder, error) {
+	var folder PostCollectionFolder
+	if f.Model != nil && f.ID > 0 {
+		db = db.Where("id = ? AND is_del = ?", f.ID, 0)
+	} else {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if err := db.First(&folder).Error; err != nil {
+		return nil, err
+	}
+	return &folder, nil
+}
+
+// GetDefault 获取用户的默认收藏夹
+func (f *PostCollectionFolder) GetDefault(db *gorm.DB, userId int64) (*PostCollectionFolder, error) {
+	var folder PostCollectionFolder
+	if err := db.Where("user_id = ? AND is_default = 1 AND is_del = 0", userId).First(&folder).Error; err != nil {
+		return nil, err
+	}
+	return &folder, nil
+}
+
+func (f *PostCollectionFolder) Create(db *gorm.DB) (*PostCollectionFolder, error) {
+	err := db.Create(&f).Error
+	return f, err
+}
+
+// CreateDefault 创建默认收藏夹，用户已有默认收藏夹时不创建并返回false
+func (f *PostCollectionFolder) CreateDefault(db *gorm.DB) (bool, error) {
+	res := db.Clauses(clause.OnConflict{DoNothing: true}).Create(f)
+	return res.RowsAffected > 0, res.Error
+}
+
+func (f *PostCollectionFolder) Update(db *gorm.DB) error {
+	return db.Model(&PostCollectionFolder{}).Where("id = ? AND is_del = ?", f.Model.ID, 0).Save(f).Error
+}
+
+func (f *PostCollectionFolder) Delete(db *gorm.DB) error {
+	return db.Model(f).Where("id = ?", f.Model.ID).Updates(map[string]any{
+		"deleted_on": time.Now().Unix(),
+		"is_del":     1,
+	}).Error
+}
+
+// ListByUserId 获取用户的收藏夹，默认收藏夹排在最前
+func (f *PostCollectionFolder) ListByUserId(db *gorm.DB, userId int64, justPublic bool) (res []*PostCollectionFolder, err error) {
+	db = db.Where("user_id = ? AND is_del = 0", userId)
+	if justPublic {
+		db = db.Where("is_public = 1")
+	}
+	err = db.Order("is_default DESC, id ASC").Find(&res).Error
+	return
+}
+
+// CountCollections 统计各收藏夹中的收藏数
+func (f *PostCollectionFolder) CountCollections(db *gorm.DB, folderIds []int64) (map[int64]int64, error) {
+	var items []struct {
+		FolderID int64
+		Count    int64
+	}
+	err := db.Model(&PostCollection{}).Select("folder_id, count(*) AS count").
+		Where("folder_id IN ? AND is_del = 0", folderIds).Group("folder_id").Scan(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]int64, len(items))
+	for _, item := range items {
+		res[item.FolderID] = item.Count
+	}
+	return res, nil
+}
+
+// MoveCollections 将一个收藏夹中的收藏全部移动到另一个收藏夹
+func (f *PostCollectionFolder) MoveCollections(db *gorm.DB, toFolderId int64) error {
+	return db.Model(&PostCollection{}).Where("folder_id = ? AND is_del = 0", f.Model.ID).Update("folder_id", toFolderId).Error
+}
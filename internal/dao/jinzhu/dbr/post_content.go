@@ -10,7 +10,7 @@ import (
 	"gorm.io/gorm"
 )
 
-// 类型，1标题，2文字段落，3图片地址，4视频地址，5语音地址，6链接地址，7附件资源
+// 类型，1标题，2文字段落，3图片地址，4视频地址，5语音地址，6链接地址，7附件资源，8收费附件资源，9投票
 type PostContentT int
 
 const (
@@ -22,6 +22,7 @@ const (
 	ContentTypeLink
 	ContentTypeAttachment
 	ContentTypeChargeAttachment
+	ContentTypePoll
 )
 
 var (
@@ -36,19 +37,22 @@ var (
 
 type PostContent struct {
 	*Model
-	PostID  int64        `json:"post_id"`
-	UserID  int64        `json:"user_id"`
-	Content string       `json:"content"`
-	Type    PostContentT `json:"type"`
-	Sort    int64        `json:"sort"`
+	PostID      int64        `json:"post_id"`
+	UserID      int64        `json:"user_id"`
+	Content     string       `json:"content"`
+	Type        PostContentT `json:"type"`
+	Sort        int64        `json:"sort"`
+	IsSensitive int8         `json:"is_sensitive"`
 }
 
 type PostContentFormated struct {
-	ID      int64        `db:"id" json:"id"`
-	PostID  int64        `json:"post_id"`
-	Content string       `json:"content"`
-	Type    PostContentT `json:"type"`
-	Sort    int64        `json:"sort"`
+	ID          int64                `db:"id" json:"id"`
+	PostID      int64                `json:"post_id"`
+	Content     string               `json:"content"`
+	Type        PostContentT         `json:"type"`
+	Sort        int64                `json:"sort"`
+	IsSensitive bool                 `json:"is_sensitive"`
+	Preview     *LinkPreviewFormated `json:"preview,omitempty"`
 }
 
 func (p *PostContent) DeleteByPostId(db *gorm.DB, postId int64) error {
@@ -74,11 +78,12 @@ func (p *PostContent) Format() *PostContentFormated {
 		return nil
 	}
 	return &PostContentFormated{
-		ID:      p.ID,
-		PostID:  p.PostID,
-		Content: p.Content,
-		Type:    p.Type,
-		Sort:    p.Sort,
+		ID:          p.ID,
+		PostID:      p.PostID,
+		Content:     p.Content,
+		Type:        p.Type,
+		Sort:        p.Sort,
+		IsSensitive: p.IsSensitive == 1,
 	}
 }
 
@@ -0,0 +1,59 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package dbr
+
+import (
+	"gorm.io/gorm"
+)
+
+// PostContentRevision 推文编辑前的历史内容，同一次编辑归档的内容共享同一版本号
+type PostContentRevision struct {
+	*Model
+	PostID   int64        `json:"post_id"`
+	UserID   int64        `json:"user_id"`
+	Revision int64        `json:"revision"`
+	Content  string       `json:"content"`
+	Type     PostContentT `json:"type"`
+	Sort     int64        `json:"sort"`
+}
+
+type PostContentRevisionFormated struct {
+	ID       int64        `json:"id"`
+	PostID   int64        `json:"post_id"`
+	Revision int64        `json:"revision"`
+	Content  string       `json:"content"`
+	Type     PostContentT `json:"type"`
+	Sort     int64        `json:"sort"`
+}
+
+func (p *PostContentRevision) Format() *PostContentRevisionFormated {
+	if p.Model == nil {
+		return nil
+	}
+	return &PostContentRevisionFormated{
+		ID:       p.ID,
+		PostID:   p.PostID,
+		Revision: p.Revision,
+		Content:  p.Content,
+		Type:     p.Type,
+		Sort:     p.Sort,
+	}
+}
+
+func (p *PostContentRevision) Create(db *gorm.DB) (*PostContentRevision, error) {
+	err := db.Create(&p).Error
+	return p, err
+}
+
+// LatestRevision 获取推文当前最大的历史版本号，没有历史版本时返回0
+func (p *PostContentRevision) LatestRevision(db *gorm.DB, postId int64) (revision int64, err error) {
+	err = db.Model(p).Where("post_id = ? AND is_del = 0", postId).Select("COALESCE(MAX(revision), 0)").Scan(&revision).Error
+	return
+}
+
+func (p *PostContentRevision) ListByPostId(db *gorm.DB, postId int64) (res []*PostContentRevision, err error) {
+	err = db.Where("post_id = ? AND is_del = 0", postId).Order("revision DESC, sort ASC").Find(&res).Error
+	return
+}
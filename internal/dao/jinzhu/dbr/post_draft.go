@@ -0,0 +1,89 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package dbr
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PostDraft 推文草稿，Contents 为内容项的 JSON 序列化结果
+type PostDraft struct {
+	*Model
+	UserID          int64            `json:"user_id"`
+	Contents        string           `json:"contents"`
+	Tags            string           `json:"tags"`
+	Users           string           `json:"users"`
+	Visibility      PostVisibleT     `json:"visibility"`
+	AttachmentPrice int64            `json:"attachment_price"`
+	IsSensitive     int8             `json:"is_sensitive"`
+	ContentWarning  string           `json:"content_warning"`
+	GroupIds        string           `json:"group_ids"`
+	IsStory         int8             `json:"is_story"`
+	ExpiresIn       int64            `json:"expires_in"`
+	ReplyPolicy     PostReplyPolicyT `json:"reply_policy"`
+}
+
+func (p *PostDraft) Get(db *gorm.DB) (*PostDraft, error) {
+	var draft PostDraft
+	if p.Model != nil && p.ID > 0 {
+		db = db.Where("id = ? AND is_del = ?", p.ID, 0)
+	} else {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if err := db.First(&draft).Error; err != nil {
+		return nil, err
+	}
+	return &draft, nil
+}
+
+func (p *PostDraft) Create(db *gorm.DB) (*PostDraft, error) {
+	err := db.Create(&p).Error
+	return p, err
+}
+
+func (p *PostDraft) Update(db *gorm.DB) error {
+	return db.Model(&PostDraft{}).Where("id = ? AND is_del = ?", p.Model.ID, 0).Save(p).Error
+}
+
+func (p *PostDraft) Delete(db *gorm.DB) error {
+	return db.Model(p).Where("id = ?", p.Model.ID).Updates(map[string]any{
+		"deleted_on": time.Now().Unix(),
+		"is_del":     1,
+	}).Error
+}
+
+// Publish 发布时以条件更新删除草稿，返回是否由本次调用删除，并发发布同一草稿时只有一个成功
+func (p *PostDraft) Publish(db *gorm.DB) (bool, error) {
+	res := db.Model(&PostDraft{}).Where("id = ? AND is_del = 0", p.Model.ID).Updates(map[string]any{
+		"deleted_on": time.Now().Unix(),
+		"is_del":     1,
+	})
+	return res.RowsAffected > 0, res.Error
+}
+
+// Restore 恢复发布失败的草稿
+func (p *PostDraft) Restore(db *gorm.DB) error {
+	return db.Unscoped().Model(&PostDraft{}).Where("id = ?", p.Model.ID).Updates(map[string]any{
+		"deleted_on": 0,
+		"is_del":     0,
+	}).Error
+}
+
+func (p *PostDraft) ListByUserId(db *gorm.DB, userId int64, limit, offset int) (res []*PostDraft, total int64, err error) {
+	db = db.Model(p).Where("user_id = ? AND is_del = 0", userId)
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Order("modified_on DESC").Limit(limit).Offset(offset).Find(&res).Error
+	return
+}
+
+// ListExpired 获取最后修改时间早于 before 的草稿
+func (p *PostDraft) ListExpired(db *gorm.DB, before int64, limit int) (res []*PostDraft, err error) {
+	err = db.Where("modified_on < ? AND is_del = 0", before).Order("id ASC").Limit(limit).Find(&res).Error
+	return
+}
@@ -0,0 +1,153 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package dbr
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PostPoll 推文中的投票，每条推文最多一个投票
+type PostPoll struct {
+	*Model
+	PostID    int64 `json:"post_id"`
+	UserID    int64 `json:"user_id"`
+	Multiple  int8  `json:"multiple"`
+	ExpiresAt int64 `json:"expires_at"`
+	VoteCount int64 `json:"vote_count"`
+	ClosedOn  int64 `json:"closed_on"`
+}
+
+// PostPollOption 投票选项
+type PostPollOption struct {
+	*Model
+	PollID    int64  `json:"poll_id"`
+	Content   string `json:"content"`
+	Sort      int64  `json:"sort"`
+	VoteCount int64  `json:"vote_count"`
+}
+
+// PostPollVote 用户的投票记录，多选时每个选项一条记录
+type PostPollVote struct {
+	*Model
+	PollID   int64 `json:"poll_id"`
+	OptionID int64 `json:"option_id"`
+	UserID   int64 `json:"user_id"`
+}
+
+type PostPollFormated struct {
+	ID             int64                     `json:"id"`
+	Multiple       bool                      `json:"multiple"`
+	ExpiresAt      int64                     `json:"expires_at"`
+	IsClosed       bool                      `json:"is_closed"`
+	VoteCount      int64                     `json:"vote_count"`
+	Voted          bool                      `json:"voted"`
+	VotedOptions   []int64                   `json:"voted_options"`
+	ResultsVisible bool                      `json:"results_visible"`
+	Options        []*PostPollOptionFormated `json:"options"`
+}
+
+type PostPollOptionFormated struct {
+	ID        int64  `json:"id"`
+	Content   string `json:"content"`
+	Sort      int64  `json:"sort"`
+	VoteCount int64  `json:"vote_count"`
+}
+
+// IsClosed 投票是否已经结束
+func (p *PostPoll) IsClosed() bool {
+	return p.ClosedOn > 0 || (p.ExpiresAt > 0 && p.ExpiresAt <= time.Now().Unix())
+}
+
+func (p *PostPoll) Format() *PostPollFormated {
+	if p.Model == nil {
+		return nil
+	}
+	return &PostPollFormated{
+		ID:             p.ID,
+		Multiple:       p.Multiple > 0,
+		ExpiresAt:      p.ExpiresAt,
+		IsClosed:       p.IsClosed(),
+		VoteCount:      p.VoteCount,
+		VotedOptions:   []int64{},
+		ResultsVisible: true,
+		Options:        []*PostPollOptionFormated{},
+	}
+}
+
+// HideResults 隐藏各选项的得票数
+func (p *PostPollFormated) HideResults() {
+	p.ResultsVisible = false
+	for _, option := range p.Options {
+		option.VoteCount = 0
+	}
+}
+
+func (p *PostPollOption) Format() *PostPollOptionFormated {
+	if p.Model == nil {
+		return nil
+	}
+	return &PostPollOptionFormated{
+		ID:        p.ID,
+		Content:   p.Content,
+		Sort:      p.Sort,
+		VoteCount: p.VoteCount,
+	}
+}
+
+func (p *PostPoll) Get(db *gorm.DB) (*PostPoll, error) {
+	var poll PostPoll
+	if p.Model != nil && p.ID > 0 {
+		db = db.Where("id = ? AND is_del = ?", p.ID, 0)
+	} else {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if err := db.First(&poll).Error; err != nil {
+		return nil, err
+	}
+	return &poll, nil
+}
+
+func (p *PostPoll) Create(db *gorm.DB) (*PostPoll, error) {
+	err := db.Create(&p).Error
+	return p, err
+}
+
+func (p *PostPoll) ListByPostIds(db *gorm.DB, postIds []int64) (res []*PostPoll, err error) {
+	err = db.Where("post_id IN ? AND is_del = 0", postIds).Find(&res).Error
+	return
+}
+
+// ListDueClosed 获取已到截止时间但还未做结束处理的投票
+func (p *PostPoll) ListDueClosed(db *gorm.DB, now int64, limit int) (res []*PostPoll, err error) {
+	err = db.Where("closed_on = 0 AND expires_at > 0 AND expires_at <= ? AND is_del = 0", now).Order("expires_at ASC").Limit(limit).Find(&res).Error
+	return
+}
+
+func (p *PostPoll) Close(db *gorm.DB) error {
+	p.ClosedOn = time.Now().Unix()
+	return db.Model(p).Where("id = ?", p.Model.ID).Update("closed_on", p.ClosedOn).Error
+}
+
+func (p *PostPollOption) Create(db *gorm.DB) (*PostPollOption, error) {
+	err := db.Create(&p).Error
+	return p, err
+}
+
+func (p *PostPollOption) ListByPollIds(db *gorm.DB, pollIds []int64) (res []*PostPollOption, err error) {
+	err = db.Where("poll_id IN ? AND is_del = 0", pollIds).Order("sort ASC, id ASC").Find(&res).Error
+	return
+}
+
+func (p *PostPollVote) Create(db *gorm.DB) (*PostPollVote, error) {
+	err := db.Create(&p).Error
+	return p, err
+}
+
+func (p *PostPollVote) ListByUserId(db *gorm.DB, userId int64, pollIds []int64) (res []*PostPollVote, err error) {
+	err = db.Where("user_id = ? AND poll_id IN ? AND is_del = 0", userId, pollIds).Find(&res).Error
+	return
+}
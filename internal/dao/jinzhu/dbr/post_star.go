@@ -73,11 +73,11 @@ func (p *PostStar) List(db *gorm.DB, conditions *ConditionsT, typ cs.RelationTyp
 	case cs.RelationAdmin:
 		// admin have all permition to visit all type tweets
 	case cs.RelationFriend:
-		db = db.Where("visibility = ? OR visibility = ?", PostVisitPublic, PostVisitFriend)
+		db = db.Where("visibility = ? OR visibility = ? OR visibility IN ?", PostVisitPublic, PostVisitFriend, PaidVisibility)
 	case cs.RelationSelf:
 		db = db.Where("visibility <> ? OR (visibility = ? AND ? = ?)", PostVisitPrivate, PostVisitPrivate, clause.Column{Table: "Post", Name: "user_id"}, p.UserID)
 	default:
-		db = db.Where("visibility=?", PostVisitPublic)
+		db = db.Where("visibility = ? OR visibility IN ?", PostVisitPublic, PaidVisibility)
 	}
 	db = db.Order(clause.OrderByColumn{Column: clause.Column{Table: "Post", Name: "id"}, Desc: true})
 	err = db.Find(&res).Error
@@ -102,11 +102,11 @@ func (p *PostStar) Count(db *gorm.DB, typ cs.RelationTyp, conditions *Conditions
 	case cs.RelationAdmin:
 		// admin have all permition to visit all type tweets
 	case cs.RelationFriend:
-		db = db.Where("visibility = ? OR visibility = ?", PostVisitPublic, PostVisitFriend)
+		db = db.Where("visibility = ? OR visibility = ? OR visibility IN ?", PostVisitPublic, PostVisitFriend, PaidVisibility)
 	case cs.RelationSelf:
 		db = db.Where("visibility <> ? OR (visibility = ? AND ? = ?)", PostVisitPrivate, PostVisitPrivate, clause.Column{Table: "Post", Name: "user_id"}, p.UserID)
 	default:
-		db = db.Where("visibility=?", PostVisitPublic)
+		db = db.Where("visibility = ? OR visibility IN ?", PostVisitPublic, PaidVisibility)
 	}
 	err = db.Model(p).Count(&res).Error
 	return
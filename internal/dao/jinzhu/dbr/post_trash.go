@@ -0,0 +1,219 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package dbr
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PostTrash 回收站中的推文，推文删除时其内容、评论等一并软删除，
+// 恢复时只找回删除时间不早于进入回收站时间的记录，之前单独删除的评论等不会被找回
+type PostTrash struct {
+	*Model
+	Post       *Post `json:"-"`
+	PostID     int64 `json:"post_id"`
+	UserID     int64 `json:"user_id"`
+	OperatorID int64 `json:"operator_id"`
+}
+
+type PostTrashFormated struct {
+	ID         int64         `json:"id"`
+	PostID     int64         `json:"post_id"`
+	UserID     int64         `json:"user_id"`
+	OperatorID int64         `json:"operator_id"`
+	Post       *PostFormated `json:"post"`
+	TrashedOn  int64         `json:"trashed_on"`
+	PurgeOn    int64         `json:"purge_on"`
+}
+
+func (t *PostTrash) Format() *PostTrashFormated {
+	if t.Model == nil {
+		return nil
+	}
+	return &PostTrashFormated{
+		ID:         t.ID,
+		PostID:     t.PostID,
+		UserID:     t.UserID,
+		OperatorID: t.OperatorID,
+		TrashedOn:  t.CreatedOn,
+	}
+}
+
+func (t *PostTrash) Create(db *gorm.DB) (*PostTrash, error) {
+	err := db.Omit("Post").Create(&t).Error
+	return t, err
+}
+
+// GetByPostId 获取推文在回收站中的记录
+func (t *PostTrash) GetByPostId(db *gorm.DB, postId int64) (*PostTrash, error) {
+	var trash PostTrash
+	if err := db.Omit("Post").Where("post_id = ? AND is_del = 0", postId).First(&trash).Error; err != nil {
+		return nil, err
+	}
+	return &trash, nil
+}
+
+// List 获取回收站中的推文，userId为0时获取全部用户的
+func (t *PostTrash) List(db *gorm.DB, userId int64, limit int, offset int) (res []*PostTrash, total int64, err error) {
+	db = db.Model(t).Where("is_del = 0")
+	if userId > 0 {
+		db = db.Where("user_id = ?", userId)
+	}
+	if err = db.Count(&total).Error; err != nil || total == 0 {
+		return
+	}
+	if offset >= 0 && limit > 0 {
+		db = db.Offset(offset).Limit(limit)
+	}
+	if err = db.Omit("Post").Order("id DESC").Find(&res).Error; err != nil {
+		return
+	}
+	err = t.fillPosts(db.Session(&gorm.Session{NewDB: true}), res)
+	return
+}
+
+// ListExpired 获取在before之前进入回收站的推文
+func (t *PostTrash) ListExpired(db *gorm.DB, before int64, limit int) (res []*PostTrash, err error) {
+	err = db.Omit("Post").Where("created_on < ? AND is_del = 0", before).Order("id ASC").Limit(limit).Find(&res).Error
+	return
+}
+
+// fillPosts 回收站中的推文已被软删除，需要不带删除条件查询
+func (t *PostTrash) fillPosts(db *gorm.DB, trashes []*PostTrash) error {
+	if len(trashes) == 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(trashes))
+	for _, trash := range trashes {
+		ids = append(ids, trash.PostID)
+	}
+	var posts []*Post
+	if err := db.Unscoped().Where("id IN ?", ids).Find(&posts).Error; err != nil {
+		return err
+	}
+	postMap := make(map[int64]*Post, len(posts))
+	for _, post := range posts {
+		postMap[post.ID] = post
+	}
+	for _, trash := range trashes {
+		trash.Post = postMap[trash.PostID]
+	}
+	return nil
+}
+
+// Restore 恢复推文及随推文一起删除的内容、评论、回复、统计与@提及，并移出回收站
+func (t *PostTrash) Restore(db *gorm.DB) (*Post, error) {
+	restored := map[string]any{
+		"deleted_on": 0,
+		"is_del":     0,
+	}
+	since := t.CreatedOn
+	// 需要以新会话复用，否则多次查询的条件会累加在一起
+	udb := db.Unscoped().Session(&gorm.Session{})
+	if err := udb.Model(&Post{}).Where("id = ? AND is_del = 1", t.PostID).Updates(restored).Error; err != nil {
+		return nil, err
+	}
+	if err := udb.Model(&PostContent{}).Where("post_id = ? AND is_del = 1 AND deleted_on >= ?", t.PostID, since).Updates(restored).Error; err != nil {
+		return nil, err
+	}
+	var commentIds []int64
+	if err := udb.Model(&Comment{}).Where("post_id = ? AND is_del = 1 AND deleted_on >= ?", t.PostID, since).Pluck("id", &commentIds).Error; err != nil {
+		return nil, err
+	}
+	if len(commentIds) > 0 {
+		if err := udb.Model(&Comment{}).Where("id IN ?", commentIds).Updates(restored).Error; err != nil {
+			return nil, err
+		}
+		for _, m := range []any{&CommentContent{}, &CommentReply{}} {
+			if err := udb.Model(m).Where("comment_id IN ? AND is_del = 1 AND deleted_on >= ?", commentIds, since).Updates(restored).Error; err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, m := range []any{&PostMetric{}, &Mention{}} {
+		if err := udb.Model(m).Where("post_id = ? AND is_del = 1 AND deleted_on >= ?", t.PostID, since).Updates(restored).Error; err != nil {
+			return nil, err
+		}
+	}
+	if err := t.Delete(db); err != nil {
+		return nil, err
+	}
+	return (&Post{Model: &Model{ID: t.PostID}}).Get(db)
+}
+
+// Purge 彻底删除推文及其关联记录，返回需要从对象存储中删除的媒体内容
+func (t *PostTrash) Purge(db *gorm.DB) ([]string, error) {
+	udb := db.Unscoped().Session(&gorm.Session{})
+	var commentIds, pollIds []int64
+	if err := udb.Model(&Comment{}).Where("post_id = ?", t.PostID).Pluck("id", &commentIds).Error; err != nil {
+		return nil, err
+	}
+	if err := udb.Model(&PostPoll{}).Where("post_id = ?", t.PostID).Pluck("id", &pollIds).Error; err != nil {
+		return nil, err
+	}
+	// 当前内容与历史版本可能引用相同的媒体
+	var contents, revisions, commentContents, commentRevisions []string
+	if err := udb.Model(&PostContent{}).Where("post_id = ? AND type IN ?", t.PostID, mediaContentType).Pluck("content", &contents).Error; err != nil {
+		return nil, err
+	}
+	if err := udb.Model(&PostContentRevision{}).Where("post_id = ? AND type IN ?", t.PostID, mediaContentType).Pluck("content", &revisions).Error; err != nil {
+		return nil, err
+	}
+	if len(commentIds) > 0 {
+		if err := udb.Model(&CommentContent{}).Where("comment_id IN ? AND type = ?", commentIds, ContentTypeImage).Pluck("content", &commentContents).Error; err != nil {
+			return nil, err
+		}
+		if err := udb.Model(&CommentRevision{}).Where("comment_id IN ? AND type = ?", commentIds, ContentTypeImage).Pluck("content", &commentRevisions).Error; err != nil {
+			return nil, err
+		}
+		for _, m := range []any{&CommentContent{}, &CommentRevision{}, &CommentReply{}, &CommentMetric{}, &CommentReaction{}} {
+			if err := udb.Where("comment_id IN ?", commentIds).Delete(m).Error; err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(pollIds) > 0 {
+		for _, m := range []any{&PostPollOption{}, &PostPollVote{}} {
+			if err := udb.Where("poll_id IN ?", pollIds).Delete(m).Error; err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, m := range []any{&PostContent{}, &PostContentRevision{}, &Comment{}, &PostMetric{}, &Mention{},
+		&PostPoll{}, &PostReaction{}, &PostStar{}, &PostCollection{}, &PostAudience{}} {
+		if err := udb.Where("post_id = ?", t.PostID).Delete(m).Error; err != nil {
+			return nil, err
+		}
+	}
+	if err := udb.Where("id = ?", t.PostID).Delete(&Post{}).Error; err != nil {
+		return nil, err
+	}
+	// 包括之前恢复过的记录在内，推文在回收站中的记录全部物理删除，避免清理任务重复扫描
+	if err := udb.Where("post_id = ?", t.PostID).Delete(&PostTrash{}).Error; err != nil {
+		return nil, err
+	}
+	var mediaContents []string
+	seen := make(map[string]struct{})
+	for _, items := range [][]string{contents, revisions, commentContents, commentRevisions} {
+		for _, item := range items {
+			if _, exist := seen[item]; !exist && strings.TrimSpace(item) != "" {
+				seen[item] = struct{}{}
+				mediaContents = append(mediaContents, item)
+			}
+		}
+	}
+	return mediaContents, nil
+}
+
+// Delete 移出回收站
+func (t *PostTrash) Delete(db *gorm.DB) error {
+	return db.Model(&PostTrash{}).Where("id = ?", t.ID).Updates(map[string]any{
+		"deleted_on": time.Now().Unix(),
+		"is_del":     1,
+	}).Error
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package dbr
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PostReaction 推文的表情回应，同一用户可以对一条推文做出多个不同的回应
+type PostReaction struct {
+	*Model
+	PostID   int64  `json:"post_id"`
+	UserID   int64  `json:"user_id"`
+	Reaction string `json:"reaction"`
+}
+
+// CommentReaction 评论的表情回应
+type CommentReaction struct {
+	*Model
+	CommentID int64  `json:"comment_id"`
+	UserID    int64  `json:"user_id"`
+	Reaction  string `json:"reaction"`
+}
+
+// ReactionCount 某个表情回应的汇总数
+type ReactionCount struct {
+	Reaction string `json:"reaction"`
+	Count    int64  `json:"count"`
+}
+
+// ReactionCountItem 按推文或评论分组的表情回应汇总
+type ReactionCountItem struct {
+	TargetID int64
+	Reaction string
+	Count    int64
+}
+
+func (p *PostReaction) Get(db *gorm.DB) (*PostReaction, error) {
+	var reaction PostReaction
+	err := db.Where("post_id = ? AND user_id = ? AND reaction = ? AND is_del = 0", p.PostID, p.UserID, p.Reaction).First(&reaction).Error
+	if err != nil {
+		return nil, err
+	}
+	return &reaction, nil
+}
+
+// Create 新建表情回应，已存在相同回应时不做处理，返回是否实际插入了记录
+func (p *PostReaction) Create(db *gorm.DB) (bool, error) {
+	res := db.Clauses(clause.OnConflict{DoNothing: true}).Create(p)
+	return res.RowsAffected > 0, res.Error
+}
+
+// Delete 物理删除表情回应，以便唯一索引下可以再次回应
+func (p *PostReaction) Delete(db *gorm.DB) error {
+	return db.Unscoped().Delete(p).Error
+}
+
+func (p *PostReaction) ListByUserId(db *gorm.DB, userId int64, postIds []int64) (res []*PostReaction, err error) {
+	err = db.Where("user_id = ? AND post_id IN ? AND is_del = 0", userId, postIds).Order("id ASC").Find(&res).Error
+	return
+}
+
+func (p *PostReaction) CountByPostIds(db *gorm.DB, postIds []int64) (res []*ReactionCountItem, err error) {
+	err = db.Model(p).Select("post_id AS target_id, reaction, COUNT(*) AS count").
+		Where("post_id IN ? AND is_del = 0", postIds).Group("post_id, reaction").Order("count DESC").Scan(&res).Error
+	return
+}
+
+func (c *CommentReaction) Get(db *gorm.DB) (*CommentReaction, error) {
+	var reaction CommentReaction
+	err := db.Where("comment_id = ? AND user_id = ? AND reaction = ? AND is_del = 0", c.CommentID, c.UserID, c.Reaction).First(&reaction).Error
+	if err != nil {
+		return nil, err
+	}
+	return &reaction, nil
+}
+
+// Create 新建表情回应，已存在相同回应时不做处理，返回是否实际插入了记录
+func (c *CommentReaction) Create(db *gorm.DB) (bool, error) {
+	res := db.Clauses(clause.OnConflict{DoNothing: true}).Create(c)
+	return res.RowsAffected > 0, res.Error
+}
+
+// Delete 物理删除表情回应，以便唯一索引下可以再次回应
+func (c *CommentReaction) Delete(db *gorm.DB) error {
+	return db.Unscoped().Delete(c).Error
+}
+
+func (c *CommentReaction) ListByUserId(db *gorm.DB, userId int64, commentIds []int64) (res []*CommentReaction, err error) {
+	err = db.Where("user_id = ? AND comment_id IN ? AND is_del = 0", userId, commentIds).Order("id ASC").Find(&res).Error
+	return
+}
+
+func (c *CommentReaction) CountByCommentIds(db *gorm.DB, commentIds []int64) (res []*ReactionCountItem, err error) {
+	err = db.Model(c).Select("comment_id AS target_id, reaction, COUNT(*) AS count").
+		Where("comment_id IN ? AND is_del = 0", commentIds).Group("comment_id, reaction").Order("count DESC").Scan(&res).Error
+	return
+}
@@ -16,16 +16,17 @@ const (
 
 type User struct {
 	*Model
-	Nickname   string `json:"nickname"`
-	Username   string `json:"username"`
-	Phone      string `json:"phone"`
-	Password   string `json:"password"`
-	Salt       string `json:"salt"`
-	Status     int    `json:"status"`
-	Avatar     string `json:"avatar"`
-	Balance    int64  `json:"balance"`
-	IsAdmin    bool   `json:"is_admin"`
-	Experience int    `gorm:"-" json:"experience"`
+	Nickname      string `json:"nickname"`
+	Username      string `json:"username"`
+	Phone         string `json:"phone"`
+	Password      string `json:"password"`
+	Salt          string `json:"salt"`
+	Status        int    `json:"status"`
+	Avatar        string `json:"avatar"`
+	Balance       int64  `json:"balance"`
+	IsAdmin       bool   `json:"is_admin"`
+	ShowSensitive bool   `json:"show_sensitive"`
+	Experience    int    `gorm:"-" json:"experience"`
 }
 
 type UserFormated struct {
@@ -0,0 +1,93 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package dbr
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// UserOTP 用户的TOTP二次验证，确认验证码后才算开启
+type UserOTP struct {
+	*Model
+	UserId      int64  `json:"user_id"`
+	Secret      string `json:"-"`
+	LastCounter int64  `json:"-"`
+	EnabledOn   int64  `json:"enabled_on"`
+}
+
+// UserRecoveryCode 二次验证的一次性恢复码，只保存摘要
+type UserRecoveryCode struct {
+	*Model
+	UserId int64  `json:"user_id"`
+	Code   string `json:"-"`
+	UsedOn int64  `json:"used_on"`
+}
+
+// IsEnabled 是否已开启二次验证
+func (o *UserOTP) IsEnabled() bool {
+	return o.EnabledOn > 0
+}
+
+func (o *UserOTP) Create(db *gorm.DB) (*UserOTP, error) {
+	err := db.Create(&o).Error
+	return o, err
+}
+
+// GetByUser 获取用户的二次验证，已开启的优先，其次是最新待确认的
+func (o *UserOTP) GetByUser(db *gorm.DB, userId int64) (*UserOTP, error) {
+	var res UserOTP
+	if err := db.Where("user_id = ? AND is_del = 0", userId).Order("enabled_on DESC, id DESC").First(&res).Error; err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// Enable 确认开启二次验证
+func (o *UserOTP) Enable(db *gorm.DB) error {
+	return db.Model(&UserOTP{}).Where("id = ? AND is_del = 0", o.ID).Updates(map[string]any{
+		"enabled_on":   o.EnabledOn,
+		"last_counter": o.LastCounter,
+		"modified_on":  time.Now().Unix(),
+	}).Error
+}
+
+// UseCounter 记录已使用的验证码周期，同一周期的验证码只能使用一次
+func (o *UserOTP) UseCounter(db *gorm.DB, counter int64) (int64, error) {
+	res := db.Model(&UserOTP{}).Where("id = ? AND last_counter < ? AND is_del = 0", o.ID, counter).Update("last_counter", counter)
+	return res.RowsAffected, res.Error
+}
+
+// DeleteByUser 删除用户的二次验证，onlyPending为true时只删除待确认的
+func (o *UserOTP) DeleteByUser(db *gorm.DB, userId int64, onlyPending bool) error {
+	db = db.Model(&UserOTP{}).Where("user_id = ? AND is_del = 0", userId)
+	if onlyPending {
+		db = db.Where("enabled_on = 0")
+	}
+	return db.Updates(map[string]any{
+		"deleted_on": time.Now().Unix(),
+		"is_del":     1,
+	}).Error
+}
+
+func (c *UserRecoveryCode) Create(db *gorm.DB) (*UserRecoveryCode, error) {
+	err := db.Create(&c).Error
+	return c, err
+}
+
+// Use 使用恢复码，每个恢复码只能使用一次
+func (c *UserRecoveryCode) Use(db *gorm.DB) (int64, error) {
+	res := db.Model(&UserRecoveryCode{}).Where("user_id = ? AND code = ? AND used_on = 0 AND is_del = 0", c.UserId, c.Code).Update("used_on", time.Now().Unix())
+	return res.RowsAffected, res.Error
+}
+
+// DeleteByUser 删除用户全部的恢复码
+func (c *UserRecoveryCode) DeleteByUser(db *gorm.DB, userId int64) error {
+	return db.Model(&UserRecoveryCode{}).Where("user_id = ? AND is_del = 0", userId).Updates(map[string]any{
+		"deleted_on": time.Now().Unix(),
+		"is_del":     1,
+	}).Error
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package dbr
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// UserSession 用户登录会话，每个设备登录后各自持有一个可轮换的刷新令牌
+type UserSession struct {
+	*Model
+	UserId       int64  `json:"user_id"`
+	Device       string `json:"device"`
+	IP           string `json:"ip"`
+	IPLoc        string `json:"ip_loc"`
+	RefreshToken string `json:"-"`
+	RotatedToken string `json:"-"`
+	ExpiresOn    int64  `json:"expires_on"`
+	LastSeenOn   int64  `json:"last_seen_on"`
+}
+
+type UserSessionFormated struct {
+	ID         int64  `json:"id"`
+	Device     string `json:"device"`
+	IP         string `json:"ip"`
+	IPLoc      string `json:"ip_loc"`
+	LastSeenOn int64  `json:"last_seen_on"`
+	CreatedOn  int64  `json:"created_on"`
+	IsCurrent  bool   `json:"is_current"`
+}
+
+func (s *UserSession) Format() *UserSessionFormated {
+	if s.Model == nil {
+		return nil
+	}
+	return &UserSessionFormated{
+		ID:         s.ID,
+		Device:     s.Device,
+		IP:         s.IP,
+		IPLoc:      s.IPLoc,
+		LastSeenOn: s.LastSeenOn,
+		CreatedOn:  s.CreatedOn,
+	}
+}
+
+// IsExpired 刷新令牌是否已过期
+func (s *UserSession) IsExpired() bool {
+	return s.ExpiresOn <= time.Now().Unix()
+}
+
+func (s *UserSession) Create(db *gorm.DB) (*UserSession, error) {
+	err := db.Create(&s).Error
+	return s, err
+}
+
+// Get 获取未撤销的会话
+func (s *UserSession) Get(db *gorm.DB) (*UserSession, error) {
+	var session UserSession
+	if err := db.Where("id = ? AND is_del = 0", s.ID).First(&session).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// GetByRefreshToken 根据刷新令牌摘要获取未撤销的会话
+func (s *UserSession) GetByRefreshToken(db *gorm.DB, token string) (*UserSession, error) {
+	var session UserSession
+	if err := db.Where("refresh_token = ? AND is_del = 0", token).First(&session).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// GetByRotatedToken 根据已轮换掉的刷新令牌摘要获取未撤销的会话
+func (s *UserSession) GetByRotatedToken(db *gorm.DB, token string) (*UserSession, error) {
+	var session UserSession
+	if err := db.Where("rotated_token = ? AND is_del = 0", token).First(&session).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Rotate 轮换刷新令牌，只有旧令牌仍匹配时才更新，避免同一令牌被并发重复使用
+func (s *UserSession) Rotate(db *gorm.DB, oldToken string) (int64, error) {
+	res := db.Model(&UserSession{}).Where("id = ? AND refresh_token = ? AND is_del = 0", s.ID, oldToken).Updates(map[string]any{
+		"refresh_token": s.RefreshToken,
+		"rotated_token": oldToken,
+		"expires_on":    s.ExpiresOn,
+		"ip":            s.IP,
+		"ip_loc":        s.IPLoc,
+		"last_seen_on":  s.LastSeenOn,
+		"modified_on":   time.Now().Unix(),
+	})
+	return res.RowsAffected, res.Error
+}
+
+// Touch 更新会话的最近访问时间与IP
+func (s *UserSession) Touch(db *gorm.DB) error {
+	return db.Model(&UserSession{}).Where("id = ? AND is_del = 0", s.ID).Updates(map[string]any{
+		"ip":           s.IP,
+		"ip_loc":       s.IPLoc,
+		"last_seen_on": s.LastSeenOn,
+	}).Error
+}
+
+// ListByUser 获取用户全部未过期的会话，最近访问的排在前面
+func (s *UserSession) ListByUser(db *gorm.DB, userId int64) (res []*UserSession, err error) {
+	err = db.Where("user_id = ? AND expires_on > ? AND is_del = 0", userId, time.Now().Unix()).Order("last_seen_on DESC, id DESC").Find(&res).Error
+	return
+}
+
+// Revoke 撤销会话
+func (s *UserSession) Revoke(db *gorm.DB) error {
+	return db.Model(&UserSession{}).Where("id = ? AND is_del = 0", s.ID).Updates(map[string]any{
+		"deleted_on": time.Now().Unix(),
+		"is_del":     1,
+	}).Error
+}
+
+// RevokeByUser 撤销用户的全部会话，exceptId大于0时保留该会话
+func (s *UserSession) RevokeByUser(db *gorm.DB, userId int64, exceptId int64) error {
+	return db.Model(&UserSession{}).Where("user_id = ? AND id != ? AND is_del = 0", userId, exceptId).Updates(map[string]any{
+		"deleted_on": time.Now().Unix(),
+		"is_del":     1,
+	}).Error
+}
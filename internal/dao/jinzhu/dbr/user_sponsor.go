@@ -0,0 +1,67 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package dbr
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// UserSponsor 用户对创作者的付费支持，充电过即可看到充电可见的推文，订阅有效期内可看到全部付费可见的推文
+type UserSponsor struct {
+	*Model
+	UserID             int64 `json:"user_id"`
+	CreatorID          int64 `json:"creator_id"`
+	ChargedAmount      int64 `json:"charged_amount"`
+	SubscribeExpiredOn int64 `json:"subscribe_expired_on"`
+}
+
+// IsSubscribed 订阅是否在有效期内
+func (s *UserSponsor) IsSubscribed() bool {
+	return s.SubscribeExpiredOn > time.Now().Unix()
+}
+
+// IsAllow 是否可以看到创作者指定可见性的推文
+func (s *UserSponsor) IsAllow(visibility PostVisibleT) bool {
+	switch visibility {
+	case PostVisitCharge:
+		return s.ChargedAmount > 0 || s.IsSubscribed()
+	case PostVisitSubscription:
+		return s.IsSubscribed()
+	}
+	return false
+}
+
+func (s *UserSponsor) Get(db *gorm.DB) (*UserSponsor, error) {
+	var sponsor UserSponsor
+	err := db.Where("user_id = ? AND creator_id = ? AND is_del = 0", s.UserID, s.CreatorID).First(&sponsor).Error
+	if err != nil {
+		return nil, err
+	}
+	return &sponsor, nil
+}
+
+func (s *UserSponsor) Create(db *gorm.DB) (*UserSponsor, error) {
+	err := db.Create(&s).Error
+	return s, err
+}
+
+func (s *UserSponsor) Update(db *gorm.DB) error {
+	return db.Model(&UserSponsor{}).Where("id = ? AND is_del = 0", s.ID).Updates(map[string]any{
+		"charged_amount":       s.ChargedAmount,
+		"subscribe_expired_on": s.SubscribeExpiredOn,
+	}).Error
+}
+
+// ListValid 获取用户仍然有效的付费支持，creatorIds为空时获取全部创作者的
+func (s *UserSponsor) ListValid(db *gorm.DB, userId int64, creatorIds []int64) (res []*UserSponsor, err error) {
+	db = db.Where("user_id = ? AND (charged_amount > 0 OR subscribe_expired_on > ?) AND is_del = 0", userId, time.Now().Unix())
+	if len(creatorIds) > 0 {
+		db = db.Where("creator_id IN ?", creatorIds)
+	}
+	err = db.Find(&res).Error
+	return
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package jinzhu
+
+import (
+	"github.com/rocboss/paopao-ce/internal/core"
+	"github.com/rocboss/paopao-ce/internal/core/ms"
+	"github.com/rocboss/paopao-ce/internal/dao/jinzhu/dbr"
+	"gorm.io/gorm"
+)
+
+var (
+	_ core.TweetDraftService = (*tweetDraftSrv)(nil)
+)
+
+type tweetDraftSrv struct {
+	db *gorm.DB
+}
+
+func newTweetDraftService(db *gorm.DB) core.TweetDraftService {
+	return &tweetDraftSrv{
+		db: db,
+	}
+}
+
+func (s *tweetDraftSrv) GetDraftByID(id int64) (*ms.PostDraft, error) {
+	draft := &dbr.PostDraft{
+		Model: &dbr.Model{
+			ID: id,
+		},
+	}
+	return draft.Get(s.db)
+}
+
+func (s *tweetDraftSrv) CreateDraft(draft *ms.PostDraft) (*ms.PostDraft, error) {
+	return draft.Create(s.db)
+}
+
+func (s *tweetDraftSrv) UpdateDraft(draft *ms.PostDraft) error {
+	return draft.Update(s.db)
+}
+
+func (s *tweetDraftSrv) DeleteDraft(draft *ms.PostDraft) error {
+	return draft.Delete(s.db)
+}
+
+func (s *tweetDraftSrv) PublishDraft(draft *ms.PostDraft) (bool, error) {
+	return draft.Publish(s.db)
+}
+
+func (s *tweetDraftSrv) RestoreDraft(draft *ms.PostDraft) error {
+	return draft.Restore(s.db)
+}
+
+func (s *tweetDraftSrv) ListUserDrafts(userId int64, limit, offset int) ([]*ms.PostDraft, int64, error) {
+	return (&dbr.PostDraft{}).ListByUserId(s.db, userId, limit, offset)
+}
+
+func (s *tweetDraftSrv) ListExpiredDrafts(before int64, limit int) ([]*ms.PostDraft, error) {
+	return (&dbr.PostDraft{}).ListExpired(s.db, before, limit)
+}
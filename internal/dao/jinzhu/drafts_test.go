@@ -0,0 +1,54 @@
+// Copyright 2024 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package jinzhu
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rocboss/paopao-ce/internal/core"
+	"github.com/rocboss/paopao-ce/internal/core/ms"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("TweetDraftService", Ordered, func() {
+	var (
+		db *gorm.DB
+		ds core.TweetDraftService
+	)
+
+	BeforeAll(func() {
+		db = newTestDB()
+		ds = newTweetDraftService(db)
+	})
+
+	It("publishes a draft only once", func() {
+		draft, err := ds.CreateDraft(&ms.PostDraft{UserID: 1, Contents: "[]"})
+		Expect(err).To(Succeed())
+		published, err := ds.PublishDraft(draft)
+		Expect(err).To(Succeed())
+		Expect(published).To(BeTrue())
+		published, err = ds.PublishDraft(draft)
+		Expect(err).To(Succeed())
+		Expect(published).To(BeFalse())
+		_, err = ds.GetDraftByID(draft.ID)
+		Expect(err).To(MatchError(gorm.ErrRecordNotFound))
+	})
+
+	It("restores a draft whose tweet failed to publish", func() {
+		draft, err := ds.CreateDraft(&ms.PostDraft{UserID: 1, Contents: "[]"})
+		Expect(err).To(Succeed())
+		published, err := ds.PublishDraft(draft)
+		Expect(err).To(Succeed())
+		Expect(published).To(BeTrue())
+		Expect(ds.RestoreDraft(draft)).To(Succeed())
+		restored, err := ds.GetDraftByID(draft.ID)
+		Expect(err).To(Succeed())
+		Expect(restored.ID).To(Equal(draft.ID))
+		published, err = ds.PublishDraft(restored)
+		Expect(err).To(Succeed())
+		Expect(published).To(BeTrue())
+	})
+})
@@ -0,0 +1,114 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package jinzhu
+
+import (
+	"errors"
+
+	"github.com/rocboss/paopao-ce/internal/core"
+	"github.com/rocboss/paopao-ce/internal/core/ms"
+	"github.com/rocboss/paopao-ce/internal/dao/jinzhu/dbr"
+	"gorm.io/gorm"
+)
+
+var (
+	_ core.TweetFolderService = (*tweetFolderSrv)(nil)
+)
+
+// _defaultFolderName 默认收藏夹名称，与迁移脚本保持一致
+const _defaultFolderName = "默认收藏夹"
+
+type tweetFolderSrv struct {
+	db *gorm.DB
+}
+
+func newTweetFolderService(db *gorm.DB) core.TweetFolderService {
+	return &tweetFolderSrv{
+		db: db,
+	}
+}
+
+func (s *tweetFolderSrv) GetCollectionFolderByID(id int64) (*ms.PostCollectionFolder, error) {
+	folder := &dbr.PostCollectionFolder{
+		Model: &dbr.Model{
+			ID: id,
+		},
+	}
+	return folder.Get(s.db)
+}
+
+// GetDefaultCollectionFolder 获取用户的默认收藏夹，不存在时自动创建；
+// 每个用户的默认收藏夹有唯一索引约束，并发创建冲突时重新读取已创建的默认收藏夹
+func (s *tweetFolderSrv) GetDefaultCollectionFolder(userId int64) (*ms.PostCollectionFolder, error) {
+	folder, err := (&dbr.PostCollectionFolder{}).GetDefault(s.db, userId)
+	if err == nil {
+		return folder, nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	folder = &dbr.PostCollectionFolder{
+		UserID:    userId,
+		Name:      _defaultFolderName,
+		IsDefault: 1,
+	}
+	if created, err := folder.CreateDefault(s.db); err != nil {
+		return nil, err
+	} else if created {
+		return folder, nil
+	}
+	return (&dbr.PostCollectionFolder{}).GetDefault(s.db, userId)
+}
+
+func (s *tweetFolderSrv) ListUserCollectionFolders(userId int64, justPublic bool) ([]*ms.PostCollectionFolderFormated, error) {
+	folders, err := (&dbr.PostCollectionFolder{}).ListByUserId(s.db, userId, justPublic)
+	if err != nil || len(folders) == 0 {
+		return []*ms.PostCollectionFolderFormated{}, err
+	}
+	folderIds := make([]int64, 0, len(folders))
+	for _, folder := range folders {
+		folderIds = append(folderIds, folder.ID)
+	}
+	counts, err := (&dbr.PostCollectionFolder{}).CountCollections(s.db, folderIds)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*ms.PostCollectionFolderFormated, 0, len(folders))
+	for _, folder := range folders {
+		item := folder.Format()
+		item.CollectionCount = counts[folder.ID]
+		res = append(res, item)
+	}
+	return res, nil
+}
+
+func (s *tweetFolderSrv) CreateCollectionFolder(folder *ms.PostCollectionFolder) (*ms.PostCollectionFolder, error) {
+	return folder.Create(s.db)
+}
+
+func (s *tweetFolderSrv) UpdateCollectionFolder(folder *ms.PostCollectionFolder) error {
+	return folder.Update(s.db)
+}
+
+// DeleteCollectionFolder 删除收藏夹，其中的收藏移入默认收藏夹
+func (s *tweetFolderSrv) DeleteCollectionFolder(folder *ms.PostCollectionFolder) error {
+	defaultFolder, err := s.GetDefaultCollectionFolder(folder.UserID)
+	if err != nil {
+		return err
+	}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := folder.MoveCollections(tx, defaultFolder.ID); err != nil {
+			return err
+		}
+		return folder.Delete(tx)
+	})
+}
+
+func (s *tweetFolderSrv) MoveCollectionToFolder(collection *ms.PostCollection, folderId int64) error {
+	return collection.MoveToFolder(s.db, folderId)
+}
+
+func (s *tweetFolderSrv) ListFolderCollections(folderId int64, justPublic bool, limit, offset int) ([]*ms.PostCollection, int64, error) {
+	return (&dbr.PostCollection{}).ListByFolderId(s.db, folderId, justPublic, limit, offset)
+}
@@ -0,0 +1,53 @@
+// Copyright 2024 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package jinzhu
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rocboss/paopao-ce/internal/core"
+	"github.com/rocboss/paopao-ce/internal/core/ms"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("TweetFolderService", Ordered, func() {
+	var (
+		db *gorm.DB
+		fs core.TweetFolderService
+	)
+
+	BeforeAll(func() {
+		db = newTestDB()
+		fs = newTweetFolderService(db)
+	})
+
+	It("creates the default folder once", func() {
+		folder, err := fs.GetDefaultCollectionFolder(1)
+		Expect(err).To(Succeed())
+		again, err := fs.GetDefaultCollectionFolder(1)
+		Expect(err).To(Succeed())
+		Expect(again.ID).To(Equal(folder.ID))
+	})
+
+	It("rejects a second default folder and keeps other folders", func() {
+		folder, err := fs.GetDefaultCollectionFolder(2)
+		Expect(err).To(Succeed())
+		dup := &ms.PostCollectionFolder{UserID: 2, Name: "dup", IsDefault: 1}
+		created, err := dup.CreateDefault(db)
+		Expect(err).To(Succeed())
+		Expect(created).To(BeFalse())
+		for _, name := range []string{"a", "b"} {
+			_, err = fs.CreateCollectionFolder(&ms.PostCollectionFolder{UserID: 2, Name: name})
+			Expect(err).To(Succeed())
+		}
+		var count int64
+		Expect(db.Model(&ms.PostCollectionFolder{}).Where("user_id = ? AND is_default = 1", 2).Count(&count).Error).To(Succeed())
+		Expect(count).To(Equal(int64(1)))
+		again, err := fs.GetDefaultCollectionFolder(2)
+		Expect(err).To(Succeed())
+		Expect(again.ID).To(Equal(folder.ID))
+	})
+})
@@ -10,32 +10,49 @@ import (
 
 // 数据库表名，统一使用 _<table name>_ 的形式命名， 比如tag表 => _tag_
 var (
-	_anouncement_        string
-	_anouncementContent_ string
-	_attachment_         string
-	_captcha_            string
-	_comment_            string
-	_commentMetric_      string
-	_commentContent_     string
-	_commentReply_       string
-	_following_          string
-	_contact_            string
-	_contactGroup_       string
-	_message_            string
-	_post_               string
-	_post_metric_        string
-	_post_by_comment_    string
-	_post_by_media_      string
-	_postAttachmentBill_ string
-	_postCollection_     string
-	_postContent_        string
-	_postStar_           string
-	_tag_                string
-	_user_               string
-	_userRelation_       string
-	_userMetric_         string
-	_walletRecharge_     string
-	_walletStatement_    string
+	_anouncement_         string
+	_anouncementContent_  string
+	_attachment_          string
+	_captcha_             string
+	_comment_             string
+	_commentMetric_       string
+	_commentContent_      string
+	_commentReply_        string
+	_commentReaction_     string
+	_commentRevision_     string
+	_following_           string
+	_contact_             string
+	_contactGroup_        string
+	_linkPreview_         string
+	_mention_             string
+	_message_             string
+	_post_                string
+	_post_metric_         string
+	_post_by_comment_     string
+	_post_by_media_       string
+	_postAttachmentBill_  string
+	_postAudience_        string
+	_postCollection_      string
+	_collectionFolder_    string
+	_postContent_         string
+	_postContentRevision_ string
+	_postDraft_           string
+	_postPoll_            string
+	_postPollOption_      string
+	_postPollVote_        string
+	_postReaction_        string
+	_postStar_            string
+	_postTrash_           string
+	_tag_                 string
+	_user_                string
+	_userRelation_        string
+	_userMetric_          string
+	_userSponsor_         string
+	_userSession_         string
+	_userOTP_             string
+	_userRecoveryCode_    string
+	_walletRecharge_      string
+	_walletStatement_     string
 )
 
 func initTableName() {
@@ -48,22 +65,39 @@ func initTableName() {
 	_commentMetric_ = m[conf.TableCommentMetric]
 	_commentContent_ = m[conf.TableCommentContent]
 	_commentReply_ = m[conf.TableCommentReply]
+	_commentReaction_ = m[conf.TableCommentReaction]
+	_commentRevision_ = m[conf.TableCommentRevision]
 	_following_ = m[conf.TableFollowing]
 	_contact_ = m[conf.TableContact]
 	_contactGroup_ = m[conf.TableContactGroup]
+	_linkPreview_ = m[conf.TableLinkPreview]
+	_mention_ = m[conf.TableMention]
 	_message_ = m[conf.TableMessage]
 	_post_ = m[conf.TablePost]
 	_post_metric_ = m[conf.TablePostMetric]
 	_post_by_comment_ = m[conf.TablePostByComment]
 	_post_by_media_ = m[conf.TablePostByMedia]
 	_postAttachmentBill_ = m[conf.TablePostAttachmentBill]
+	_postAudience_ = m[conf.TablePostAudience]
 	_postCollection_ = m[conf.TablePostCollection]
+	_collectionFolder_ = m[conf.TableCollectionFolder]
 	_postContent_ = m[conf.TablePostContent]
+	_postContentRevision_ = m[conf.TablePostContentRevision]
+	_postDraft_ = m[conf.TablePostDraft]
+	_postPoll_ = m[conf.TablePostPoll]
+	_postPollOption_ = m[conf.TablePostPollOption]
+	_postPollVote_ = m[conf.TablePostPollVote]
+	_postReaction_ = m[conf.TablePostReaction]
 	_postStar_ = m[conf.TablePostStar]
+	_postTrash_ = m[conf.TablePostTrash]
 	_tag_ = m[conf.TableTag]
 	_user_ = m[conf.TableUser]
 	_userRelation_ = m[conf.TableUserRelation]
 	_userMetric_ = m[conf.TableUserMetric]
+	_userSponsor_ = m[conf.TableUserSponsor]
+	_userSession_ = m[conf.TableUserSession]
+	_userOTP_ = m[conf.TableUserOTP]
+	_userRecoveryCode_ = m[conf.TableUserRecoveryCode]
 	_walletRecharge_ = m[conf.TableWalletRecharge]
 	_walletStatement_ = m[conf.TableWalletStatement]
 }
@@ -30,11 +30,20 @@ var (
 
 type dataSrv struct {
 	core.WalletService
+	core.SponsorService
 	core.MessageService
 	core.TopicService
 	core.TweetService
 	core.TweetManageService
 	core.TweetHelpService
+	core.TweetDraftService
+	core.TweetTrashService
+	core.TweetPollService
+	core.TweetReactionService
+	core.TweetViewService
+	core.TweetFolderService
+	core.LinkPreviewService
+	core.MentionService
 	core.TweetMetricServantA
 	core.CommentService
 	core.CommentManageService
@@ -43,9 +52,12 @@ type dataSrv struct {
 	core.UserManageService
 	core.UserMetricServantA
 	core.ContactManageService
+	core.ContactGroupService
 	core.FollowingManageService
 	core.UserRelationService
 	core.SecurityService
+	core.SessionService
+	core.TwoFactorService
 	core.AttachmentCheckService
 }
 
@@ -69,19 +81,31 @@ func NewDataService() (core.DataService, core.VersionInfo) {
 		CommentMetricServantA:  cms,
 		UserMetricServantA:     ums,
 		WalletService:          newWalletService(db),
+		SponsorService:         newSponsorService(db),
 		MessageService:         newMessageService(db),
 		TopicService:           newTopicService(db),
 		TweetService:           newTweetService(db),
 		TweetManageService:     newTweetManageService(db, cis),
 		TweetHelpService:       newTweetHelpService(db),
+		TweetDraftService:      newTweetDraftService(db),
+		TweetTrashService:      newTweetTrashService(db, cis),
+		TweetPollService:       newTweetPollService(db, cis),
+		TweetReactionService:   newTweetReactionService(db, cis),
+		TweetViewService:       newTweetViewService(db, tms),
+		TweetFolderService:     newTweetFolderService(db),
+		LinkPreviewService:     newLinkPreviewService(db),
+		MentionService:         newMentionService(db),
 		CommentService:         newCommentService(db),
 		CommentManageService:   newCommentManageService(db),
 		TrendsManageServantA:   newTrendsManageServentA(db),
 		UserManageService:      newUserManageService(db, ums),
 		ContactManageService:   newContactManageService(db),
+		ContactGroupService:    newContactGroupService(db),
 		FollowingManageService: newFollowingManageService(db),
 		UserRelationService:    newUserRelationService(db),
 		SecurityService:        newSecurityService(db, pvs),
+		SessionService:         newSessionService(db),
+		TwoFactorService:       newTwoFactorService(db),
 		AttachmentCheckService: security.NewAttachmentCheckService(),
 	}
 	return cache.NewCacheDataService(ds), ds
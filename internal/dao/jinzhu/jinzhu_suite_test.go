@@ -0,0 +1,59 @@
+// Copyright 2024 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package jinzhu
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rocboss/paopao-ce/internal/conf"
+	"github.com/rocboss/paopao-ce/internal/core"
+	"github.com/rocboss/paopao-ce/internal/core/ms"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+func TestJinzhu(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Jinzhu Suite")
+}
+
+var _ = BeforeSuite(func() {
+	// 拼接原生SQL时使用的表名依赖数据库配置中的表名前缀
+	setting := reflect.New(reflect.TypeOf(conf.DatabaseSetting).Elem())
+	setting.Elem().FieldByName("TablePrefix").SetString("p_")
+	reflect.ValueOf(&conf.DatabaseSetting).Elem().Set(setting)
+	initTableName()
+})
+
+// newTestDB 以 sqlite 内存数据库加载完整表结构
+func newTestDB() *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+		NamingStrategy: schema.NamingStrategy{
+			TablePrefix:   "p_",
+			SingularTable: true,
+		},
+	})
+	Expect(err).To(Succeed())
+	sqlDB, err := db.DB()
+	Expect(err).To(Succeed())
+	// 内存数据库每个连接都是独立的库，只保留一个连接
+	sqlDB.SetMaxOpenConns(1)
+	ddl, err := os.ReadFile("../../../scripts/paopao-sqlite3.sql")
+	Expect(err).To(Succeed())
+	Expect(db.Exec(string(ddl)).Error).To(Succeed())
+	return db
+}
+
+type noopCacheIndex struct{}
+
+func (noopCacheIndex) SendAction(core.IdxAct, *ms.Post) {}
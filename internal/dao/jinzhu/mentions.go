@@ -0,0 +1,141 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package jinzhu
+
+import (
+	"time"
+
+	"github.com/rocboss/paopao-ce/internal/core"
+	"github.com/rocboss/paopao-ce/internal/core/ms"
+	"github.com/rocboss/paopao-ce/internal/dao/jinzhu/dbr"
+	"gorm.io/gorm"
+)
+
+var (
+	_ core.MentionService = (*mentionSrv)(nil)
+)
+
+type mentionSrv struct {
+	db *gorm.DB
+}
+
+func newMentionService(db *gorm.DB) core.MentionService {
+	return &mentionSrv{
+		db: db,
+	}
+}
+
+// IsMentioned 用户是否被来源本身@了
+func (s *mentionSrv) IsMentioned(src *ms.Mention, userId int64) bool {
+	var count int64
+	err := s.db.Model(&dbr.Mention{}).Where("post_id = ? AND comment_id = ? AND reply_id = ? AND user_id = ? AND is_del = 0", src.PostID, src.CommentID, src.ReplyID, userId).Count(&count).Error
+	return err == nil && count > 0
+}
+
+// SaveMentions 将来源的提及替换为userIds，返回新增与被移除的用户，被移除用户的@消息一并删除
+func (s *mentionSrv) SaveMentions(src *ms.Mention, userIds []int64) (added []int64, removed []int64, err error) {
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		olds, err := src.ListBySource(tx)
+		if err != nil {
+			return err
+		}
+		wanted := make(map[int64]bool, len(userIds))
+		for _, id := range userIds {
+			wanted[id] = true
+		}
+		var staleIds []int64
+		for _, old := range olds {
+			if wanted[old.UserID] {
+				delete(wanted, old.UserID)
+			} else {
+				staleIds = append(staleIds, old.ID)
+				removed = append(removed, old.UserID)
+			}
+		}
+		for _, id := range userIds {
+			if !wanted[id] {
+				continue
+			}
+			delete(wanted, id)
+			mention := &dbr.Mention{
+				UserID:    id,
+				AuthorID:  src.AuthorID,
+				PostID:    src.PostID,
+				CommentID: src.CommentID,
+				ReplyID:   src.ReplyID,
+			}
+			if _, err = mention.Create(tx); err != nil {
+				return err
+			}
+			added = append(added, id)
+		}
+		if len(staleIds) == 0 {
+			return nil
+		}
+		if err = (&dbr.Mention{}).DeleteByIds(tx, staleIds); err != nil {
+			return err
+		}
+		return s.deleteMessages(tx, src, removed)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return
+}
+
+// DeleteMentions 删除来源及其下级来源的提及与对应的@消息，返回被提及的用户
+func (s *mentionSrv) DeleteMentions(src *ms.Mention) (userIds []int64, err error) {
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		mentions, err := src.ListUnder(tx)
+		if err != nil || len(mentions) == 0 {
+			return err
+		}
+		ids := make([]int64, 0, len(mentions))
+		for _, m := range mentions {
+			ids = append(ids, m.ID)
+			userIds = append(userIds, m.UserID)
+			if err = s.deleteMessages(tx, m, []int64{m.UserID}); err != nil {
+				return err
+			}
+		}
+		return (&dbr.Mention{}).DeleteByIds(tx, ids)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// ListUserMentions 获取用户被@的记录，只包括用户当前仍可见的动态，可见范围与时间线一致，
+// 付费可见的动态同样列出，由调用方为未付费的用户展示预览
+func (s *mentionSrv) ListUserMentions(user *ms.User, limit, offset int) ([]*ms.Mention, int64, error) {
+	postIds := s.db.Table(_post_).Select("id").Where("is_del = 0")
+	if !user.IsAdmin {
+		friendIds := s.db.Table(_contact_).Select("user_id").Where("friend_id = ? AND status = ? AND is_del = 0", user.ID, dbr.ContactStatusAgree)
+		myFriendIds := s.db.Table(_contact_).Select("friend_id").Where("user_id = ? AND status = ? AND is_del = 0", user.ID, dbr.ContactStatusAgree)
+		followIds := s.db.Table(_following_).Select("follow_id").Where("user_id = ? AND is_del = 0", user.ID)
+		// 未发布的定时动态与已过期的限时动态仅作者可见
+		postIds = postIds.Where("user_id = ? OR (publish_at = 0 AND "+_notExpiredWhere+" AND (visibility = ? OR visibility IN ? OR "+
+			"(visibility = ? AND (user_id IN (?) OR user_id IN (?))) OR (visibility = ? AND user_id IN (?)) OR "+audienceWhere()+"))",
+			user.ID, time.Now().Unix(), dbr.PostVisitPublic, dbr.PaidVisibility,
+			dbr.PostVisitFriend, friendIds, myFriendIds, dbr.PostVisitFollowing, followIds, user.ID)
+	}
+	return (&dbr.Mention{}).List(s.db, user.ID, postIds, limit, offset)
+}
+
+// deleteMessages 删除来源中发给指定用户的消息提醒
+func (s *mentionSrv) deleteMessages(tx *gorm.DB, src *ms.Mention, receiverIds []int64) error {
+	typ := dbr.MsgTypePost
+	if src.ReplyID > 0 {
+		typ = dbr.MsgTypeReply
+	} else if src.CommentID > 0 {
+		typ = dbr.MsgtypeComment
+	}
+	return tx.Table(_message_).Where("receiver_user_id IN ? AND type = ? AND post_id = ? AND comment_id = ? AND reply_id = ? AND is_del = 0",
+		receiverIds, typ, src.PostID, src.CommentID, src.ReplyID).Updates(map[string]any{
+		"deleted_on": time.Now().Unix(),
+		"is_del":     1,
+	}).Error
+}
@@ -0,0 +1,89 @@
+// Copyright 2024 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package jinzhu
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rocboss/paopao-ce/internal/core"
+	"github.com/rocboss/paopao-ce/internal/core/ms"
+	"github.com/rocboss/paopao-ce/internal/dao/jinzhu/dbr"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("MentionService", Ordered, func() {
+	const userId = 10
+	var (
+		db        *gorm.DB
+		ss        core.MentionService
+		visibleId []int64
+	)
+
+	BeforeAll(func() {
+		db = newTestDB()
+		ss = newMentionService(db)
+		Expect(db.Create(&dbr.Contact{UserId: 2, FriendId: userId, Status: dbr.ContactStatusAgree}).Error).To(Succeed())
+		Expect(db.Create(&dbr.Following{UserId: userId, FollowId: 4}).Error).To(Succeed())
+		now := time.Now().Unix()
+		for _, item := range []struct {
+			post    *ms.Post
+			visible bool
+			deleted bool
+		}{
+			{post: &ms.Post{UserID: 1, Visibility: dbr.PostVisitPublic}, visible: true},
+			{post: &ms.Post{UserID: 1, Visibility: dbr.PostVisitPrivate}},
+			{post: &ms.Post{UserID: 2, Visibility: dbr.PostVisitFriend}, visible: true},
+			{post: &ms.Post{UserID: 3, Visibility: dbr.PostVisitFriend}},
+			{post: &ms.Post{UserID: 4, Visibility: dbr.PostVisitFollowing}, visible: true},
+			{post: &ms.Post{UserID: 1, Visibility: dbr.PostVisitPublic, PublishAt: now + 3600}},
+			{post: &ms.Post{UserID: 1, Visibility: dbr.PostVisitPublic, ExpiresAt: now - 60}},
+			{post: &ms.Post{UserID: 5, Visibility: dbr.PostVisitCharge}, visible: true},
+			{post: &ms.Post{UserID: 1, Visibility: dbr.PostVisitPublic}, deleted: true},
+			{post: &ms.Post{UserID: userId, Visibility: dbr.PostVisitPrivate, PublishAt: now + 3600}, visible: true},
+		} {
+			Expect(db.Create(item.post).Error).To(Succeed())
+			_, _, err := ss.SaveMentions(&ms.Mention{AuthorID: item.post.UserID, PostID: item.post.ID}, []int64{userId})
+			Expect(err).To(Succeed())
+			if item.deleted {
+				Expect(item.post.Delete(db)).To(Succeed())
+			}
+			if item.visible {
+				visibleId = append([]int64{item.post.ID}, visibleId...)
+			}
+		}
+	})
+
+	postIds := func(mentions []*ms.Mention) (ids []int64) {
+		for _, m := range mentions {
+			Expect(m.Post).NotTo(BeNil())
+			ids = append(ids, m.PostID)
+		}
+		return
+	}
+
+	It("lists only mentions in visible tweets", func() {
+		mentions, total, err := ss.ListUserMentions(&ms.User{Model: &dbr.Model{ID: userId}}, 0, 0)
+		Expect(err).To(Succeed())
+		Expect(total).To(Equal(int64(len(visibleId))))
+		Expect(postIds(mentions)).To(Equal(visibleId))
+	})
+
+	It("pages visible mentions in the database", func() {
+		user := &ms.User{Model: &dbr.Model{ID: userId}}
+		mentions, total, err := ss.ListUserMentions(user, 2, 2)
+		Expect(err).To(Succeed())
+		Expect(total).To(Equal(int64(len(visibleId))))
+		Expect(postIds(mentions)).To(Equal(visibleId[2:4]))
+	})
+
+	It("lists mentions in every existing tweet for admins", func() {
+		_, total, err := ss.ListUserMentions(&ms.User{Model: &dbr.Model{ID: userId}, IsAdmin: true}, 0, 0)
+		Expect(err).To(Succeed())
+		Expect(total).To(Equal(int64(9)))
+	})
+})
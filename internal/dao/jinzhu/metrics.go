@@ -37,6 +37,16 @@ func (s *tweetMetricSrvA) UpdateTweetMetric(metric *cs.TweetMetric) error {
 	return s.db.Transaction(func(tx *gorm.DB) error {
 		postMetric := &dbr.PostMetric{PostId: metric.PostId}
 		tx.Model(postMetric).Where("post_id=?", metric.PostId).First(postMetric)
+		// 调用方只提供互动计数时沿用已有的激励分与衰减因子
+		if metric.IncentiveScore == 0 {
+			metric.IncentiveScore = postMetric.IncentiveScore
+		}
+		if metric.DecayFactor == 0 {
+			metric.DecayFactor = postMetric.DecayFactor
+		}
+		if metric.DecayFactor == 0 {
+			metric.DecayFactor = 100
+		}
 		postMetric.RankScore = metric.RankScore(postMetric.MotivationFactor)
 		return tx.Save(postMetric).Error
 	})
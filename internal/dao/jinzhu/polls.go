@@ -0,0 +1,126 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package jinzhu
+
+import (
+	"github.com/rocboss/paopao-ce/internal/core"
+	"github.com/rocboss/paopao-ce/internal/core/cs"
+	"github.com/rocboss/paopao-ce/internal/core/ms"
+	"github.com/rocboss/paopao-ce/internal/dao/jinzhu/dbr"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	_ core.TweetPollService = (*tweetPollSrv)(nil)
+)
+
+type tweetPollSrv struct {
+	cacheIndex core.CacheIndexService
+	db         *gorm.DB
+}
+
+func newTweetPollService(db *gorm.DB, cacheIndex core.CacheIndexService) core.TweetPollService {
+	return &tweetPollSrv{
+		cacheIndex: cacheIndex,
+		db:         db,
+	}
+}
+
+// CreatePostPoll 创建投票及其选项，选项按传入顺序排序
+func (s *tweetPollSrv) CreatePostPoll(poll *ms.PostPoll, options []string) (*ms.PostPoll, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := poll.Create(tx); err != nil {
+			return err
+		}
+		for idx, content := range options {
+			option := &dbr.PostPollOption{
+				PollID:  poll.ID,
+				Content: content,
+				Sort:    int64(idx + 1),
+			}
+			if _, err := option.Create(tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return poll, nil
+}
+
+func (s *tweetPollSrv) GetPostPollByID(id int64) (*ms.PostPoll, error) {
+	poll := &dbr.PostPoll{
+		Model: &dbr.Model{
+			ID: id,
+		},
+	}
+	return poll.Get(s.db)
+}
+
+// VotePostPoll 记录用户的投票并更新计票，用户已投过票时返回cs.ErrPollAlreadyVoted
+func (s *tweetPollSrv) VotePostPoll(poll *ms.PostPoll, userId int64, optionIds []int64) error {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// 锁定投票使同一投票的计票串行执行，避免并发请求重复投票
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND is_del = 0", poll.ID).First(&dbr.PostPoll{}).Error; err != nil {
+			return err
+		}
+		votes, err := (&dbr.PostPollVote{}).ListByUserId(tx, userId, []int64{poll.ID})
+		if err != nil {
+			return err
+		}
+		if len(votes) > 0 {
+			return cs.ErrPollAlreadyVoted
+		}
+		for _, optionId := range optionIds {
+			vote := &dbr.PostPollVote{
+				PollID:   poll.ID,
+				OptionID: optionId,
+				UserID:   userId,
+			}
+			if _, err := vote.Create(tx); err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(&dbr.PostPollOption{}).Where("id IN ? AND poll_id = ?", optionIds, poll.ID).
+			Update("vote_count", gorm.Expr("vote_count + 1")).Error; err != nil {
+			return err
+		}
+		return tx.Model(poll).Where("id = ?", poll.ID).Update("vote_count", gorm.Expr("vote_count + 1")).Error
+	})
+	if err != nil {
+		return err
+	}
+	s.cacheIndex.SendAction(core.IdxActUpdatePoll, &dbr.Post{
+		Model: &dbr.Model{
+			ID: poll.PostID,
+		},
+		UserID: poll.UserID,
+	})
+	return nil
+}
+
+func (s *tweetPollSrv) ClosePostPoll(poll *ms.PostPoll) error {
+	if err := poll.Close(s.db); err != nil {
+		return err
+	}
+	s.cacheIndex.SendAction(core.IdxActUpdatePoll, &dbr.Post{
+		Model: &dbr.Model{
+			ID: poll.PostID,
+		},
+		UserID: poll.UserID,
+	})
+	return nil
+}
+
+func (s *tweetPollSrv) ListUserPollVotes(userId int64, pollIds []int64) ([]*ms.PostPollVote, error) {
+	return (&dbr.PostPollVote{}).ListByUserId(s.db, userId, pollIds)
+}
+
+func (s *tweetPollSrv) ListDueClosedPolls(now int64, limit int) ([]*ms.PostPoll, error) {
+	return (&dbr.PostPoll{}).ListDueClosed(s.db, now, limit)
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package jinzhu
+
+import (
+	"errors"
+
+	"github.com/rocboss/paopao-ce/internal/core"
+	"github.com/rocboss/paopao-ce/internal/core/ms"
+	"github.com/rocboss/paopao-ce/internal/dao/jinzhu/dbr"
+	"github.com/rocboss/paopao-ce/pkg/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	_ core.LinkPreviewService = (*linkPreviewSrv)(nil)
+)
+
+type linkPreviewSrv struct {
+	db *gorm.DB
+}
+
+func newLinkPreviewService(db *gorm.DB) core.LinkPreviewService {
+	return &linkPreviewSrv{
+		db: db,
+	}
+}
+
+func (s *linkPreviewSrv) GetLinkPreview(url string) (*ms.LinkPreview, error) {
+	return (&dbr.LinkPreview{UrlHash: utils.EncodeMD5(url)}).Get(s.db)
+}
+
+// GetLinkPreviews 批量获取链接预览，返回以链接为键的映射，未抓取过的链接不在其中
+func (s *linkPreviewSrv) GetLinkPreviews(urls []string) (map[string]*ms.LinkPreview, error) {
+	res := make(map[string]*ms.LinkPreview, len(urls))
+	if len(urls) == 0 {
+		return res, nil
+	}
+	hashes := make([]string, 0, len(urls))
+	for _, url := range urls {
+		hashes = append(hashes, utils.EncodeMD5(url))
+	}
+	previews, err := (&dbr.LinkPreview{}).ListByHashes(s.db, hashes)
+	if err != nil {
+		return nil, err
+	}
+	for _, preview := range previews {
+		res[preview.URL] = preview
+	}
+	return res, nil
+}
+
+// SaveLinkPreview 保存链接预览，同一链接已存在时覆盖
+func (s *linkPreviewSrv) SaveLinkPreview(preview *ms.LinkPreview) error {
+	preview.UrlHash = utils.EncodeMD5(preview.URL)
+	old, err := preview.Get(s.db)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		preview.Model = &dbr.Model{}
+		_, err = preview.Create(s.db)
+		return err
+	} else if err != nil {
+		return err
+	}
+	preview.Model = old.Model
+	return preview.Update(s.db)
+}
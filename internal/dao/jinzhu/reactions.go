@@ -0,0 +1,143 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package jinzhu
+
+import (
+	"errors"
+
+	"github.com/rocboss/paopao-ce/internal/core"
+	"github.com/rocboss/paopao-ce/internal/core/ms"
+	"github.com/rocboss/paopao-ce/internal/dao/jinzhu/dbr"
+	"gorm.io/gorm"
+)
+
+var (
+	_ core.TweetReactionService = (*tweetReactionSrv)(nil)
+)
+
+type tweetReactionSrv struct {
+	cacheIndex core.CacheIndexService
+	db         *gorm.DB
+}
+
+func newTweetReactionService(db *gorm.DB, cacheIndex core.CacheIndexService) core.TweetReactionService {
+	return &tweetReactionSrv{
+		cacheIndex: cacheIndex,
+		db:         db,
+	}
+}
+
+// CreatePostReaction 添加推文表情回应，已经回应过时不做处理，
+// 依赖唯一索引判断是否已回应，只有实际插入了记录才累加回应数
+func (s *tweetReactionSrv) CreatePostReaction(post *ms.Post, userId int64, reaction string) error {
+	created := false
+	err := s.db.Transaction(func(tx *gorm.DB) (err error) {
+		created, err = (&dbr.PostReaction{
+			PostID:   post.ID,
+			UserID:   userId,
+			Reaction: reaction,
+		}).Create(tx)
+		if err != nil || !created {
+			return err
+		}
+		return tx.Model(&dbr.Post{}).Where("id = ?", post.ID).Update("reaction_count", gorm.Expr("reaction_count + 1")).Error
+	})
+	if err != nil || !created {
+		return err
+	}
+	post.ReactionCount++
+	s.cacheIndex.SendAction(core.IdxActUpdatePost, post)
+	return nil
+}
+
+// DeletePostReaction 取消推文表情回应，没有回应过时不做处理
+func (s *tweetReactionSrv) DeletePostReaction(post *ms.Post, userId int64, reaction string) error {
+	deleted := false
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		r, err := (&dbr.PostReaction{
+			PostID:   post.ID,
+			UserID:   userId,
+			Reaction: reaction,
+		}).Get(tx)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err = r.Delete(tx); err != nil {
+			return err
+		}
+		deleted = true
+		return tx.Model(&dbr.Post{}).Where("id = ? AND reaction_count > 0", post.ID).Update("reaction_count", gorm.Expr("reaction_count - 1")).Error
+	})
+	if err != nil || !deleted {
+		return err
+	}
+	if post.ReactionCount > 0 {
+		post.ReactionCount--
+	}
+	s.cacheIndex.SendAction(core.IdxActUpdatePost, post)
+	return nil
+}
+
+func (s *tweetReactionSrv) ListUserPostReactions(userId int64, postIds []int64) ([]*ms.PostReaction, error) {
+	return (&dbr.PostReaction{}).ListByUserId(s.db, userId, postIds)
+}
+
+// CreateCommentReaction 添加评论表情回应，已经回应过时不做处理
+func (s *tweetReactionSrv) CreateCommentReaction(comment *ms.Comment, userId int64, reaction string) error {
+	_, err := (&dbr.CommentReaction{
+		CommentID: comment.ID,
+		UserID:    userId,
+		Reaction:  reaction,
+	}).Create(s.db)
+	return err
+}
+
+// DeleteCommentReaction 取消评论表情回应，没有回应过时不做处理
+func (s *tweetReactionSrv) DeleteCommentReaction(comment *ms.Comment, userId int64, reaction string) error {
+	r, err := (&dbr.CommentReaction{
+		CommentID: comment.ID,
+		UserID:    userId,
+		Reaction:  reaction,
+	}).Get(s.db)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return r.Delete(s.db)
+}
+
+func (s *tweetReactionSrv) ListUserCommentReactions(userId int64, commentIds []int64) ([]*ms.CommentReaction, error) {
+	return (&dbr.CommentReaction{}).ListByUserId(s.db, userId, commentIds)
+}
+
+func (s *tweetReactionSrv) GetPostReactionCounts(postIds []int64) (map[int64][]*ms.ReactionCount, error) {
+	items, err := (&dbr.PostReaction{}).CountByPostIds(s.db, postIds)
+	if err != nil {
+		return nil, err
+	}
+	return reactionCountsFrom(items), nil
+}
+
+func (s *tweetReactionSrv) GetCommentReactionCounts(commentIds []int64) (map[int64][]*ms.ReactionCount, error) {
+	items, err := (&dbr.CommentReaction{}).CountByCommentIds(s.db, commentIds)
+	if err != nil {
+		return nil, err
+	}
+	return reactionCountsFrom(items), nil
+}
+
+func reactionCountsFrom(items []*dbr.ReactionCountItem) map[int64][]*ms.ReactionCount {
+	res := make(map[int64][]*ms.ReactionCount)
+	for _, item := range items {
+		res[item.TargetID] = append(res[item.TargetID], &dbr.ReactionCount{
+			Reaction: item.Reaction,
+			Count:    item.Count,
+		})
+	}
+	return res
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package jinzhu
+
+import (
+	"os"
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rocboss/paopao-ce/internal/core"
+	"github.com/rocboss/paopao-ce/internal/core/ms"
+	"github.com/rocboss/paopao-ce/internal/dao/jinzhu/dbr"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("TweetReactionService", Ordered, func() {
+	var (
+		db   *gorm.DB
+		ts   core.TweetReactionService
+		post *ms.Post
+	)
+
+	BeforeAll(func() {
+		db = newTestDB()
+		ts = newTweetReactionService(db, noopCacheIndex{})
+		post = &ms.Post{UserID: 1}
+		Expect(db.Create(post).Error).To(Succeed())
+	})
+
+	reactionCount := func() int64 {
+		p, err := (&dbr.Post{Model: &dbr.Model{ID: post.ID}}).Get(db)
+		Expect(err).To(Succeed())
+		return p.ReactionCount
+	}
+
+	It("keeps different emoji from one user separate", func() {
+		Expect(ts.CreatePostReaction(post, 2, "👍")).To(Succeed())
+		Expect(ts.CreatePostReaction(post, 2, "🎉")).To(Succeed())
+		counts, err := ts.GetPostReactionCounts([]int64{post.ID})
+		Expect(err).To(Succeed())
+		Expect(counts[post.ID]).To(ConsistOf(
+			&dbr.ReactionCount{Reaction: "👍", Count: 1},
+			&dbr.ReactionCount{Reaction: "🎉", Count: 1},
+		))
+		Expect(reactionCount()).To(Equal(int64(2)))
+	})
+
+	It("counts a repeated reaction once", func() {
+		Expect(ts.CreatePostReaction(post, 2, "👍")).To(Succeed())
+		Expect(reactionCount()).To(Equal(int64(2)))
+		Expect(ts.CreateCommentReaction(&ms.Comment{Model: &dbr.Model{ID: 1}}, 2, "👍")).To(Succeed())
+		Expect(ts.CreateCommentReaction(&ms.Comment{Model: &dbr.Model{ID: 1}}, 2, "👍")).To(Succeed())
+		reactions, err := ts.ListUserCommentReactions(2, []int64{1})
+		Expect(err).To(Succeed())
+		Expect(reactions).To(HaveLen(1))
+	})
+
+	It("reacts again after cancelling", func() {
+		Expect(ts.DeletePostReaction(post, 2, "👍")).To(Succeed())
+		Expect(reactionCount()).To(Equal(int64(1)))
+		Expect(ts.CreatePostReaction(post, 2, "👍")).To(Succeed())
+		Expect(reactionCount()).To(Equal(int64(2)))
+	})
+
+	It("compares reactions byte by byte in mysql", func() {
+		// utf8mb4_general_ci 会把所有 4 字节字符视为相等，导致不同的 emoji 互相冲突
+		re := regexp.MustCompile("`reaction` varchar\\(32\\)[^\\n]*")
+		for _, name := range []string{
+			"../../../scripts/paopao-mysql.sql",
+			"../../../scripts/migration/mysql/0022_reaction.up.sql",
+		} {
+			ddl, err := os.ReadFile(name)
+			Expect(err).To(Succeed())
+			columns := re.FindAllString(string(ddl), -1)
+			Expect(columns).To(HaveLen(2))
+			for _, column := range columns {
+				Expect(column).To(ContainSubstring("COLLATE utf8mb4_bin"))
+			}
+		}
+	})
+})
@@ -40,10 +40,17 @@ func (s *securitySrv) GetLatestPhoneCaptcha(phone string) (*ms.Captcha, error) {
 	}).Get(s.db)
 }
 
-// UsePhoneCaptcha 更新短信验证码
-func (s *securitySrv) UsePhoneCaptcha(captcha *ms.Captcha) error {
+// UsePhoneCaptcha 原子地消耗一次短信验证码，已达到使用次数上限时返回gorm.ErrRecordNotFound
+func (s *securitySrv) UsePhoneCaptcha(captcha *ms.Captcha, maxTimes int) error {
+	affected, err := captcha.Use(s.db, maxTimes)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return gorm.ErrRecordNotFound
+	}
 	captcha.UseTimes++
-	return captcha.Update(s.db)
+	return nil
 }
 
 // SendPhoneCaptcha 发送短信验证码
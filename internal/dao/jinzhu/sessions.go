@@ -0,0 +1,70 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package jinzhu
+
+import (
+	"github.com/rocboss/paopao-ce/internal/core"
+	"github.com/rocboss/paopao-ce/internal/core/ms"
+	"github.com/rocboss/paopao-ce/internal/dao/jinzhu/dbr"
+	"gorm.io/gorm"
+)
+
+var (
+	_ core.SessionService = (*sessionSrv)(nil)
+)
+
+type sessionSrv struct {
+	db *gorm.DB
+}
+
+func newSessionService(db *gorm.DB) core.SessionService {
+	return &sessionSrv{
+		db: db,
+	}
+}
+
+func (s *sessionSrv) CreateSession(session *ms.UserSession) (*ms.UserSession, error) {
+	return session.Create(s.db)
+}
+
+func (s *sessionSrv) GetSession(id int64) (*ms.UserSession, error) {
+	return (&dbr.UserSession{Model: &dbr.Model{ID: id}}).Get(s.db)
+}
+
+func (s *sessionSrv) GetSessionByRefreshToken(token string) (*ms.UserSession, error) {
+	return (&dbr.UserSession{}).GetByRefreshToken(s.db, token)
+}
+
+func (s *sessionSrv) GetSessionByRotatedToken(token string) (*ms.UserSession, error) {
+	return (&dbr.UserSession{}).GetByRotatedToken(s.db, token)
+}
+
+// RotateSession 轮换刷新令牌，旧令牌已被使用过时返回gorm.ErrRecordNotFound
+func (s *sessionSrv) RotateSession(session *ms.UserSession, oldToken string) error {
+	affected, err := session.Rotate(s.db, oldToken)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (s *sessionSrv) TouchSession(session *ms.UserSession) error {
+	return session.Touch(s.db)
+}
+
+func (s *sessionSrv) ListSessions(userId int64) ([]*ms.UserSession, error) {
+	return (&dbr.UserSession{}).ListByUser(s.db, userId)
+}
+
+func (s *sessionSrv) RevokeSession(session *ms.UserSession) error {
+	return session.Revoke(s.db)
+}
+
+func (s *sessionSrv) RevokeAllSessions(userId int64, exceptId int64) error {
+	return (&dbr.UserSession{}).RevokeByUser(s.db, userId, exceptId)
+}
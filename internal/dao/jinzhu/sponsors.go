@@ -0,0 +1,131 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package jinzhu
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rocboss/paopao-ce/internal/conf"
+	"github.com/rocboss/paopao-ce/internal/core"
+	"github.com/rocboss/paopao-ce/internal/core/cs"
+	"github.com/rocboss/paopao-ce/internal/core/ms"
+	"github.com/rocboss/paopao-ce/internal/dao/jinzhu/dbr"
+	"gorm.io/gorm"
+)
+
+var (
+	_ core.SponsorService = (*sponsorSrv)(nil)
+)
+
+type sponsorSrv struct {
+	db *gorm.DB
+}
+
+func newSponsorService(db *gorm.DB) core.SponsorService {
+	return &sponsorSrv{
+		db: db,
+	}
+}
+
+// ChargeCreator 为创作者充电，金额从用户钱包扣除，按分成比例计入创作者钱包
+func (s *sponsorSrv) ChargeCreator(user *ms.User, creatorId int64, amount int64) (res *ms.UserSponsor, err error) {
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.transfer(tx, user, creatorId, amount, "充电支出", "充电收入"); err != nil {
+			return err
+		}
+		res, err = s.saveSponsor(tx, user.ID, creatorId, func(sponsor *dbr.UserSponsor) {
+			sponsor.ChargedAmount += amount
+		})
+		return err
+	})
+	return
+}
+
+// SubscribeCreator 订阅创作者，订阅仍在有效期内时在原到期时间上顺延
+func (s *sponsorSrv) SubscribeCreator(user *ms.User, creatorId int64, price int64, days int) (res *ms.UserSponsor, err error) {
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.transfer(tx, user, creatorId, price, "订阅支出", "订阅收入"); err != nil {
+			return err
+		}
+		res, err = s.saveSponsor(tx, user.ID, creatorId, func(sponsor *dbr.UserSponsor) {
+			startOn := max(sponsor.SubscribeExpiredOn, time.Now().Unix())
+			sponsor.SubscribeExpiredOn = startOn + int64(days)*86400
+		})
+		return err
+	})
+	return
+}
+
+func (s *sponsorSrv) GetSponsorFilter(userId int64, creatorIds ...int64) (ms.SponsorFilter, error) {
+	sponsors, err := (&dbr.UserSponsor{}).ListValid(s.db, userId, creatorIds)
+	if err != nil {
+		return nil, err
+	}
+	res := make(ms.SponsorFilter, len(sponsors))
+	for _, sponsor := range sponsors {
+		res[sponsor.CreatorID] = sponsor
+	}
+	return res, nil
+}
+
+// saveSponsor 更新用户对创作者的付费支持记录，不存在时新建
+func (s *sponsorSrv) saveSponsor(tx *gorm.DB, userId, creatorId int64, update func(*dbr.UserSponsor)) (*dbr.UserSponsor, error) {
+	sponsor, err := (&dbr.UserSponsor{UserID: userId, CreatorID: creatorId}).Get(tx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		sponsor = &dbr.UserSponsor{
+			UserID:    userId,
+			CreatorID: creatorId,
+		}
+		update(sponsor)
+		return sponsor.Create(tx)
+	} else if err != nil {
+		return nil, err
+	}
+	update(sponsor)
+	return sponsor, sponsor.Update(tx)
+}
+
+// transfer 从用户钱包扣除金额并按分成比例计入创作者钱包，同时记录双方账单，
+// 扣款以余额充足为条件在事务内原子完成，成功后 user.Balance 为扣款后的余额
+func (s *sponsorSrv) transfer(tx *gorm.DB, user *ms.User, creatorId int64, amount int64, reason, incomeReason string) error {
+	db := tx.Model(&dbr.User{}).Where("id = ? AND balance >= ?", user.ID, amount).Update("balance", gorm.Expr("balance - ?", amount))
+	if db.Error != nil {
+		return db.Error
+	}
+	if db.RowsAffected == 0 {
+		return cs.ErrInsufficientBalance
+	}
+	me, err := (&dbr.User{Model: &dbr.Model{ID: user.ID}}).Get(tx)
+	if err != nil {
+		return err
+	}
+	user.Balance = me.Balance
+	if err := tx.Create(&dbr.WalletStatement{
+		UserID:          user.ID,
+		ChangeAmount:    -amount,
+		BalanceSnapshot: me.Balance,
+		Reason:          reason,
+	}).Error; err != nil {
+		return err
+	}
+	income := int64(float64(amount) * conf.AppSetting.SponsorIncomeRate)
+	if income <= 0 {
+		return nil
+	}
+	if err = tx.Model(&dbr.User{}).Where("id = ?", creatorId).Update("balance", gorm.Expr("balance + ?", income)).Error; err != nil {
+		return err
+	}
+	creator, err := (&dbr.User{Model: &dbr.Model{ID: creatorId}}).Get(tx)
+	if err != nil {
+		return err
+	}
+	return tx.Create(&dbr.WalletStatement{
+		UserID:          creator.ID,
+		ChangeAmount:    income,
+		BalanceSnapshot: creator.Balance,
+		Reason:          incomeReason,
+	}).Error
+}
@@ -5,6 +5,8 @@
 package jinzhu
 
 import (
+	"time"
+
 	"github.com/rocboss/paopao-ce/internal/core"
 	"github.com/rocboss/paopao-ce/internal/core/cs"
 	"github.com/rocboss/paopao-ce/internal/core/ms"
@@ -33,15 +35,20 @@ type simpleIndexPostsSrv struct {
 // IndexPosts 根据userId查询广场推文列表，简单做到不同用户的主页都是不同的；
 func (s *shipIndexSrv) IndexPosts(user *ms.User, offset int, limit int) (*ms.IndexTweetList, error) {
 	predicates := dbr.Predicates{
-		"ORDER": []any{"is_top DESC, latest_replied_on DESC"},
+		"thread_root_id = ?": []any{0},
+		"publish_at = ?":     []any{0},
+		_notExpiredWhere:     []any{time.Now().Unix()},
+		"ORDER":              []any{"is_top DESC, latest_replied_on DESC"},
 	}
+	// 付费可见的推文对所有人展示，未付费的访问者只能看到预览
 	if user == nil {
-		predicates["visibility = ?"] = []any{dbr.PostVisitPublic}
+		predicates["visibility = ? OR visibility IN ?"] = []any{dbr.PostVisitPublic, dbr.PaidVisibility}
 	} else if !user.IsAdmin {
 		friendIds, _ := s.ams.BeFriendIds(user.ID)
 		friendIds = append(friendIds, user.ID)
-		args := []any{dbr.PostVisitPublic, dbr.PostVisitPrivate, user.ID, dbr.PostVisitFriend, friendIds}
-		predicates["visibility = ? OR (visibility = ? AND user_id = ?) OR (visibility = ? AND user_id IN ?)"] = args
+		// 分组可见的推文只有作者本人与被加入所选分组的好友可见
+		args := []any{dbr.PostVisitPublic, dbr.PaidVisibility, dbr.PostVisitPrivate, user.ID, dbr.PostVisitFriend, friendIds, dbr.PostVisitGroup, user.ID, user.ID}
+		predicates["visibility = ? OR visibility IN ? OR (visibility = ? AND user_id = ?) OR (visibility = ? AND user_id IN ?) OR (visibility = ? AND user_id = ?) OR "+audienceWhere()] = args
 	}
 
 	posts, err := (&dbr.Post{}).Fetch(s.db, predicates, offset, limit)
@@ -68,8 +75,11 @@ func (s *shipIndexSrv) IndexPosts(user *ms.User, offset int, limit int) (*ms.Ind
 // simpleCacheIndexGetPosts simpleCacheIndex 专属获取广场推文列表函数
 func (s *simpleIndexPostsSrv) IndexPosts(_user *ms.User, offset int, limit int) (*ms.IndexTweetList, error) {
 	predicates := dbr.Predicates{
-		"visibility = ?": []any{dbr.PostVisitPublic},
-		"ORDER":          []any{"is_top DESC, latest_replied_on DESC"},
+		"visibility = ? OR visibility IN ?": []any{dbr.PostVisitPublic, dbr.PaidVisibility},
+		"thread_root_id = ?":                []any{0},
+		"publish_at = ?":                    []any{0},
+		_notExpiredWhere:                    []any{time.Now().Unix()},
+		"ORDER":                             []any{"is_top DESC, latest_replied_on DESC"},
 	}
 
 	posts, err := (&dbr.Post{}).Fetch(s.db, predicates, offset, limit)
@@ -91,6 +91,26 @@ func (s *topicSrv) ListTags(typ cs.TagType, offset, limit int) (res cs.TagList,
 	return s.listTags(conditions, limit, offset)
 }
 
+// TagsByNames 根据话题名获取话题
+func (s *topicSrv) TagsByNames(tags []string) (res cs.TagInfoList, err error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	var items []*dbr.Tag
+	if items, err = (&dbr.Tag{}).TagsFrom(s.db, tags); err != nil {
+		return nil, err
+	}
+	for _, tag := range items {
+		res = append(res, &cs.TagInfo{
+			ID:       tag.ID,
+			UserID:   tag.UserID,
+			Tag:      tag.Tag,
+			QuoteNum: tag.QuoteNum,
+		})
+	}
+	return
+}
+
 func (s *topicSrv) GetHotTags(userId int64, limit int, offset int) (cs.TagList, error) {
 	tags, err := s.listTags(&ms.ConditionsT{
 		"ORDER": "quote_num DESC",
@@ -0,0 +1,130 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package jinzhu
+
+import (
+	"strings"
+
+	"github.com/rocboss/paopao-ce/internal/core"
+	"github.com/rocboss/paopao-ce/internal/core/ms"
+	"github.com/rocboss/paopao-ce/internal/dao/jinzhu/dbr"
+	"gorm.io/gorm"
+)
+
+var (
+	_ core.TweetTrashService = (*tweetTrashSrv)(nil)
+)
+
+type tweetTrashSrv struct {
+	cacheIndex core.CacheIndexService
+	db         *gorm.DB
+}
+
+func newTweetTrashService(db *gorm.DB, cacheIndex core.CacheIndexService) core.TweetTrashService {
+	return &tweetTrashSrv{
+		cacheIndex: cacheIndex,
+		db:         db,
+	}
+}
+
+// TrashPost 将推文移入回收站，推文及其内容、评论等只做软删除，媒体内容保留到彻底清除时
+func (s *tweetTrashSrv) TrashPost(post *ms.Post, operatorId int64) error {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		trash := &dbr.PostTrash{
+			PostID:     post.ID,
+			UserID:     post.UserID,
+			OperatorID: operatorId,
+		}
+		if _, err := trash.Create(tx); err != nil {
+			return err
+		}
+		_, err := deletePost(tx, post)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	s.cacheIndex.SendAction(core.IdxActDeletePost, post)
+	return nil
+}
+
+func (s *tweetTrashSrv) GetPostTrash(postId int64) (*ms.PostTrash, error) {
+	return (&dbr.PostTrash{}).GetByPostId(s.db, postId)
+}
+
+// ListPostTrash 获取回收站中的推文，userId为0时获取全部用户的，推文内容为随推文一起删除的内容
+func (s *tweetTrashSrv) ListPostTrash(userId int64, limit, offset int) ([]*ms.PostTrashFormated, int64, error) {
+	trashes, total, err := (&dbr.PostTrash{}).List(s.db, userId, limit, offset)
+	if err != nil || len(trashes) == 0 {
+		return nil, total, err
+	}
+	postIds := make([]int64, 0, len(trashes))
+	userIds := make([]int64, 0, len(trashes))
+	for _, trash := range trashes {
+		postIds = append(postIds, trash.PostID)
+		userIds = append(userIds, trash.UserID)
+	}
+	var contents []*dbr.PostContent
+	if err = s.db.Unscoped().Where("post_id IN ? AND is_del = 1", postIds).Order("sort ASC").Find(&contents).Error; err != nil {
+		return nil, 0, err
+	}
+	users, err := getUsersByIDs(s.db, userIds)
+	if err != nil {
+		return nil, 0, err
+	}
+	userMap := make(map[int64]*dbr.UserFormated, len(users))
+	for _, user := range users {
+		userMap[user.ID] = user.Format()
+	}
+	res := make([]*ms.PostTrashFormated, 0, len(trashes))
+	for _, trash := range trashes {
+		item := trash.Format()
+		if trash.Post != nil {
+			item.Post = trash.Post.Format()
+			item.Post.User = userMap[trash.UserID]
+			for _, c := range contents {
+				if c.PostID == trash.PostID && c.DeletedOn >= trash.CreatedOn {
+					item.Post.Contents = append(item.Post.Contents, c.Format())
+				}
+			}
+		}
+		res = append(res, item)
+	}
+	return res, total, nil
+}
+
+func (s *tweetTrashSrv) ListExpiredPostTrash(before int64, limit int) ([]*ms.PostTrash, error) {
+	return (&dbr.PostTrash{}).ListExpired(s.db, before, limit)
+}
+
+// RestorePost 从回收站恢复推文，已发布的非私密推文重新计入话题引用数
+func (s *tweetTrashSrv) RestorePost(trash *ms.PostTrash) (post *ms.Post, err error) {
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if post, err = trash.Restore(tx); err != nil {
+			return err
+		}
+		if post.Visibility != dbr.PostVisitPrivate && post.PublishAt == 0 && post.Tags != "" {
+			_, err = createTags(tx, post.UserID, strings.Split(post.Tags, ","))
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.cacheIndex.SendAction(core.IdxActUpdatePost, post)
+	return post, nil
+}
+
+// PurgePost 彻底清除回收站中的推文，返回需要从对象存储中删除的媒体内容
+func (s *tweetTrashSrv) PurgePost(trash *ms.PostTrash) (mediaContents []string, err error) {
+	err = s.db.Transaction(func(tx *gorm.DB) (err error) {
+		mediaContents, err = trash.Purge(tx)
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mediaContents, nil
+}
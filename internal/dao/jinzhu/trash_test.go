@@ -0,0 +1,74 @@
+// Copyright 2024 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package jinzhu
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rocboss/paopao-ce/internal/core"
+	"github.com/rocboss/paopao-ce/internal/core/ms"
+	"github.com/rocboss/paopao-ce/internal/dao/jinzhu/dbr"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("TweetTrashService", Ordered, func() {
+	var (
+		db *gorm.DB
+		ts core.TweetTrashService
+	)
+
+	BeforeAll(func() {
+		db = newTestDB()
+		ts = newTweetTrashService(db, noopCacheIndex{})
+	})
+
+	count := func(m any, query string, args ...any) int64 {
+		var n int64
+		Expect(db.Unscoped().Model(m).Where(query, args...).Count(&n).Error).To(Succeed())
+		return n
+	}
+
+	It("restores a trashed tweet with its comments", func() {
+		post := &ms.Post{UserID: 1}
+		Expect(db.Create(post).Error).To(Succeed())
+		comment := &dbr.Comment{PostID: post.ID, UserID: 2}
+		Expect(db.Create(comment).Error).To(Succeed())
+		Expect(db.Create(&dbr.PostContent{PostID: post.ID, UserID: 1, Content: "hello", Type: dbr.ContentTypeText}).Error).To(Succeed())
+
+		Expect(ts.TrashPost(post, 1)).To(Succeed())
+		trash, err := ts.GetPostTrash(post.ID)
+		Expect(err).To(Succeed())
+		restored, err := ts.RestorePost(trash)
+		Expect(err).To(Succeed())
+		Expect(restored.ID).To(Equal(post.ID))
+		Expect(count(&dbr.Comment{}, "post_id = ? AND is_del = 0", post.ID)).To(Equal(int64(1)))
+		Expect(count(&dbr.PostContent{}, "post_id = ? AND is_del = 0", post.ID)).To(Equal(int64(1)))
+	})
+
+	It("purges a trashed tweet with all of its records", func() {
+		post := &ms.Post{UserID: 1}
+		Expect(db.Create(post).Error).To(Succeed())
+		comment := &dbr.Comment{PostID: post.ID, UserID: 2}
+		Expect(db.Create(comment).Error).To(Succeed())
+		Expect(db.Create(&dbr.CommentContent{CommentID: comment.ID, UserID: 2, Content: "new.png", Type: dbr.ContentTypeImage}).Error).To(Succeed())
+		Expect(db.Create(&dbr.CommentRevision{CommentID: comment.ID, UserID: 2, Content: "old.png", Type: dbr.ContentTypeImage}).Error).To(Succeed())
+		Expect(db.Create(&dbr.PostAudience{PostId: post.ID, GroupId: 1}).Error).To(Succeed())
+
+		Expect(ts.TrashPost(post, 1)).To(Succeed())
+		trash, err := ts.GetPostTrash(post.ID)
+		Expect(err).To(Succeed())
+		mediaContents, err := ts.PurgePost(trash)
+		Expect(err).To(Succeed())
+		Expect(mediaContents).To(ConsistOf("new.png", "old.png"))
+		Expect(count(&dbr.Post{}, "id = ?", post.ID)).To(BeZero())
+		for _, m := range []any{&dbr.Comment{}, &dbr.PostAudience{}, &dbr.PostTrash{}} {
+			Expect(count(m, "post_id = ?", post.ID)).To(BeZero())
+		}
+		for _, m := range []any{&dbr.CommentContent{}, &dbr.CommentRevision{}} {
+			Expect(count(m, "comment_id = ?", comment.ID)).To(BeZero())
+		}
+	})
+})
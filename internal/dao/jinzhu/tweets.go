@@ -5,6 +5,7 @@
 package jinzhu
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/rocboss/paopao-ce/internal/dao/jinzhu/dbr"
 	"github.com/rocboss/paopao-ce/pkg/debug"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var (
@@ -27,6 +29,9 @@ var (
 	_ core.TweetHelpServantA   = (*tweetHelpSrvA)(nil)
 )
 
+// _notExpiredWhere 已过期的限时推文在被定时任务软删除之前也不再展示，使用时需传入当前时间
+const _notExpiredWhere = "(expires_at = 0 OR expires_at > ?)"
+
 type tweetSrv struct {
 	db *gorm.DB
 }
@@ -126,6 +131,15 @@ func (s *tweetHelpSrv) MergePosts(posts []*ms.Post) ([]*ms.PostFormated, error)
 		postFormated.Contents = contentMap[post.ID]
 		postsFormated = append(postsFormated, postFormated)
 	}
+	if err = s.mergePolls(postsFormated); err != nil {
+		return nil, err
+	}
+	if err = s.mergeReactions(postsFormated); err != nil {
+		return nil, err
+	}
+	if err = s.mergeReposts(postsFormated); err != nil {
+		return nil, err
+	}
 	return postsFormated, nil
 }
 
@@ -163,9 +177,148 @@ func (s *tweetHelpSrv) RevampPosts(posts []*ms.PostFormated) ([]*ms.PostFormated
 		post.User = userMap[post.UserID]
 		post.Contents = contentMap[post.ID]
 	}
+	if err = s.mergePolls(posts); err != nil {
+		return nil, err
+	}
+	if err = s.mergeReactions(posts); err != nil {
+		return nil, err
+	}
+	if err = s.mergeReposts(posts); err != nil {
+		return nil, err
+	}
 	return posts, nil
 }
 
+// mergeReposts 为转发动态填充原动态，原动态已删除时保持为nil
+func (s *tweetHelpSrv) mergeReposts(posts []*ms.PostFormated) error {
+	repostIds := make([]int64, 0, len(posts))
+	for _, post := range posts {
+		if post.RepostID > 0 {
+			repostIds = append(repostIds, post.RepostID)
+		}
+	}
+	if len(repostIds) == 0 {
+		return nil
+	}
+	originals, err := (&dbr.Post{}).List(s.db, dbr.ConditionsT{
+		"id IN ?": repostIds,
+	}, 0, 0)
+	if err != nil {
+		return err
+	}
+	originalIds := make([]int64, 0, len(originals))
+	userIds := make([]int64, 0, len(originals))
+	for _, original := range originals {
+		originalIds = append(originalIds, original.ID)
+		userIds = append(userIds, original.UserID)
+	}
+	postContents, err := s.getPostContentsByIDs(originalIds)
+	if err != nil {
+		return err
+	}
+	users, err := s.getUsersByIDs(userIds)
+	if err != nil {
+		return err
+	}
+	userMap := make(map[int64]*dbr.UserFormated, len(users))
+	for _, user := range users {
+		userMap[user.ID] = user.Format()
+	}
+	contentMap := make(map[int64][]*dbr.PostContentFormated, len(postContents))
+	for _, content := range postContents {
+		contentMap[content.PostID] = append(contentMap[content.PostID], content.Format())
+	}
+	originalMap := make(map[int64]*dbr.PostFormated, len(originals))
+	for _, original := range originals {
+		originalFormated := original.Format()
+		originalFormated.User = userMap[original.UserID]
+		originalFormated.Contents = contentMap[original.ID]
+		originalMap[original.ID] = originalFormated
+	}
+	originalsFormated := make([]*dbr.PostFormated, 0, len(originalMap))
+	for _, original := range originalMap {
+		originalsFormated = append(originalsFormated, original)
+	}
+	if err = s.mergePolls(originalsFormated); err != nil {
+		return err
+	}
+	if err = s.mergeReactions(originalsFormated); err != nil {
+		return err
+	}
+	for _, post := range posts {
+		if post.RepostID > 0 {
+			post.Repost = originalMap[post.RepostID]
+		}
+	}
+	return nil
+}
+
+// mergePolls 为包含投票的推文填充投票及完整计票，按访问者隐藏计票由上层处理
+func (s *tweetHelpSrv) mergePolls(posts []*ms.PostFormated) error {
+	postIds := make([]int64, 0, len(posts))
+	for _, post := range posts {
+		for _, content := range post.Contents {
+			if content.Type == dbr.ContentTypePoll {
+				postIds = append(postIds, post.ID)
+				break
+			}
+		}
+	}
+	if len(postIds) == 0 {
+		return nil
+	}
+	polls, err := (&dbr.PostPoll{}).ListByPostIds(s.db, postIds)
+	if err != nil {
+		return err
+	}
+	pollIds := make([]int64, 0, len(polls))
+	for _, poll := range polls {
+		pollIds = append(pollIds, poll.ID)
+	}
+	options, err := (&dbr.PostPollOption{}).ListByPollIds(s.db, pollIds)
+	if err != nil {
+		return err
+	}
+	optionMap := make(map[int64][]*dbr.PostPollOptionFormated, len(polls))
+	for _, option := range options {
+		optionMap[option.PollID] = append(optionMap[option.PollID], option.Format())
+	}
+	pollMap := make(map[int64]*dbr.PostPollFormated, len(polls))
+	for _, poll := range polls {
+		pollFormated := poll.Format()
+		if items, exist := optionMap[poll.ID]; exist {
+			pollFormated.Options = items
+		}
+		pollMap[poll.PostID] = pollFormated
+	}
+	for _, post := range posts {
+		post.Poll = pollMap[post.ID]
+	}
+	return nil
+}
+
+// mergeReactions 为推文填充各表情回应的汇总数，访问者自己的回应由上层处理
+func (s *tweetHelpSrv) mergeReactions(posts []*ms.PostFormated) error {
+	if len(posts) == 0 {
+		return nil
+	}
+	postIds := make([]int64, 0, len(posts))
+	for _, post := range posts {
+		postIds = append(postIds, post.ID)
+	}
+	items, err := (&dbr.PostReaction{}).CountByPostIds(s.db, postIds)
+	if err != nil {
+		return err
+	}
+	countsMap := reactionCountsFrom(items)
+	for _, post := range posts {
+		if counts, exist := countsMap[post.ID]; exist {
+			post.Reactions = counts
+		}
+	}
+	return nil
+}
+
 func (s *tweetHelpSrv) getPostContentsByIDs(ids []int64) ([]*dbr.PostContent, error) {
 	return (&dbr.PostContent{}).List(s.db, &dbr.ConditionsT{
 		"post_id IN ?": ids,
@@ -181,10 +334,11 @@ func (s *tweetHelpSrv) getUsersByIDs(ids []int64) ([]*dbr.User, error) {
 	}, 0, 0)
 }
 
-func (s *tweetManageSrv) CreatePostCollection(postID, userID int64) (*ms.PostCollection, error) {
+func (s *tweetManageSrv) CreatePostCollection(postID, userID, folderID int64) (*ms.PostCollection, error) {
 	collection := &dbr.PostCollection{
-		PostID: postID,
-		UserID: userID,
+		PostID:   postID,
+		UserID:   userID,
+		FolderID: folderID,
 	}
 
 	return collection.Create(s.db)
@@ -203,6 +357,11 @@ func (s *tweetManageSrv) CreateAttachment(obj *ms.Attachment) (int64, error) {
 	return attachment.ID, err
 }
 
+// FilterUserAttachments 过滤出由该用户上传的附件
+func (s *tweetManageSrv) FilterUserAttachments(userId int64, contents []string) ([]string, error) {
+	return (&dbr.Attachment{}).ListUserContents(s.db, userId, contents)
+}
+
 func (s *tweetManageSrv) CreatePost(post *ms.Post) (*ms.Post, error) {
 	post.LatestRepliedOn = time.Now().Unix()
 	p, err := post.Create(s.db)
@@ -213,53 +372,52 @@ func (s *tweetManageSrv) CreatePost(post *ms.Post) (*ms.Post, error) {
 	return p, nil
 }
 
-func (s *tweetManageSrv) DeletePost(post *ms.Post) ([]string, error) {
-	var mediaContents []string
+func (s *tweetManageSrv) DeletePost(post *ms.Post) (mediaContents []string, err error) {
+	err = s.db.Transaction(func(tx *gorm.DB) (err error) {
+		mediaContents, err = deletePost(tx, post)
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.cacheIndex.SendAction(core.IdxActDeletePost, post)
+	return mediaContents, nil
+}
+
+// deletePost 软删除推文及其内容、评论与回复，返回其中的媒体内容
+func deletePost(tx *gorm.DB, post *ms.Post) ([]string, error) {
 	postId := post.ID
 	postContent := &dbr.PostContent{}
-	err := s.db.Transaction(
-		func(tx *gorm.DB) error {
-			if contents, err := postContent.MediaContentsByPostId(tx, postId); err == nil {
-				mediaContents = contents
-			} else {
-				return err
-			}
-
-			// 删推文
-			if err := post.Delete(tx); err != nil {
-				return err
-			}
-
-			// 删内容
-			if err := postContent.DeleteByPostId(tx, postId); err != nil {
-				return err
-			}
-
-			// 删评论
-			if contents, err := s.deleteCommentByPostId(tx, postId); err == nil {
-				mediaContents = append(mediaContents, contents...)
-			} else {
-				return err
-			}
+	mediaContents, err := postContent.MediaContentsByPostId(tx, postId)
+	if err != nil {
+		return nil, err
+	}
 
-			if tags := strings.Split(post.Tags, ","); len(tags) > 0 {
-				// 删tag，宽松处理错误，有错误不会回滚
-				deleteTags(tx, tags)
-			}
+	// 删推文
+	if err = post.Delete(tx); err != nil {
+		return nil, err
+	}
 
-			return nil
-		},
-	)
+	// 删内容
+	if err = postContent.DeleteByPostId(tx, postId); err != nil {
+		return nil, err
+	}
 
-	if err != nil {
+	// 删评论
+	if contents, err := deleteCommentByPostId(tx, postId); err == nil {
+		mediaContents = append(mediaContents, contents...)
+	} else {
 		return nil, err
 	}
 
-	s.cacheIndex.SendAction(core.IdxActDeletePost, post)
+	if tags := strings.Split(post.Tags, ","); len(tags) > 0 {
+		// 删tag，宽松处理错误，有错误不会回滚
+		deleteTags(tx, tags)
+	}
 	return mediaContents, nil
 }
 
-func (s *tweetManageSrv) deleteCommentByPostId(db *gorm.DB, postId int64) ([]string, error) {
+func deleteCommentByPostId(db *gorm.DB, postId int64) ([]string, error) {
 	comment := &dbr.Comment{}
 	commentContent := &dbr.CommentContent{}
 
@@ -307,6 +465,38 @@ func (s *tweetManageSrv) StickPost(post *ms.Post) error {
 	return nil
 }
 
+// PinPost 切换推文在作者个人主页的置顶状态，与全站置顶相互独立，置顶数已达maxPinned时返回cs.ErrMaxPinnedPosts
+func (s *tweetManageSrv) PinPost(post *ms.Post, maxPinned int64) error {
+	pinnedOn := int64(0)
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// 锁定作者使同一用户的置顶串行执行，避免并发请求超出置顶上限
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", post.UserID).First(&dbr.User{}).Error; err != nil {
+			return err
+		}
+		var current dbr.Post
+		if err := tx.Select("pinned_on").Where("id = ?", post.ID).First(&current).Error; err != nil {
+			return err
+		}
+		if current.PinnedOn == 0 {
+			var count int64
+			if err := tx.Model(&dbr.Post{}).Where("user_id = ? AND pinned_on > 0", post.UserID).Count(&count).Error; err != nil {
+				return err
+			}
+			if count >= maxPinned {
+				return cs.ErrMaxPinnedPosts
+			}
+			pinnedOn = time.Now().Unix()
+		}
+		return tx.Model(&dbr.Post{}).Where("id = ?", post.ID).Update("pinned_on", pinnedOn).Error
+	})
+	if err != nil {
+		return err
+	}
+	post.PinnedOn = pinnedOn
+	s.cacheIndex.SendAction(core.IdxActStickPost, post)
+	return nil
+}
+
 func (s *tweetManageSrv) HighlightPost(userId int64, postId int64) (res int, err error) {
 	var post dbr.Post
 	tx := s.db.Begin()
@@ -367,6 +557,180 @@ func (s *tweetManageSrv) UpdatePost(post *ms.Post) (err error) {
 	return
 }
 
+// IncrPostShareCount 原子地增减推文的转发数，转发数不会减到0以下
+func (s *tweetManageSrv) IncrPostShareCount(post *ms.Post, delta int64) error {
+	return s.incrPostCount(post, "share_count", delta, nil)
+}
+
+// IncrPostThreadCount 原子地增减串推数，latestRepliedOn大于0时同时更新串推首条的最新回复时间
+func (s *tweetManageSrv) IncrPostThreadCount(post *ms.Post, delta int64, latestRepliedOn int64) error {
+	updates := map[string]any{}
+	if latestRepliedOn > 0 {
+		updates["latest_replied_on"] = latestRepliedOn
+	}
+	return s.incrPostCount(post, "thread_count", delta, updates)
+}
+
+func (s *tweetManageSrv) incrPostCount(post *ms.Post, column string, delta int64, updates map[string]any) error {
+	db := s.db.Model(&dbr.Post{}).Where("id = ? AND is_del = 0", post.ID)
+	if delta < 0 {
+		db = db.Where(column+" >= ?", -delta)
+	}
+	if updates == nil {
+		updates = map[string]any{}
+	}
+	updates[column] = gorm.Expr(column+" + ?", delta)
+	res := db.Updates(updates)
+	if res.Error != nil || res.RowsAffected == 0 {
+		return res.Error
+	}
+	if latest, err := post.Get(s.db); err == nil {
+		*post = *latest
+	}
+	s.cacheIndex.SendAction(core.IdxActUpdatePost, post)
+	return nil
+}
+
+// EditPost 编辑推文内容，旧内容归档为新的历史版本后再写入新内容
+func (s *tweetManageSrv) EditPost(post *ms.Post, contents []*ms.PostContent) error {
+	postContent := &dbr.PostContent{}
+	revision := &dbr.PostContentRevision{}
+	err := s.db.Transaction(
+		func(tx *gorm.DB) error {
+			oldContents, err := postContent.List(tx, &dbr.ConditionsT{
+				"post_id = ?": post.ID,
+				"ORDER":       "sort ASC",
+			}, 0, 0)
+			if err != nil {
+				return err
+			}
+			latest, err := revision.LatestRevision(tx, post.ID)
+			if err != nil {
+				return err
+			}
+			// 归档旧内容
+			for _, c := range oldContents {
+				r := &dbr.PostContentRevision{
+					PostID:   c.PostID,
+					UserID:   c.UserID,
+					Revision: latest + 1,
+					Content:  c.Content,
+					Type:     c.Type,
+					Sort:     c.Sort,
+				}
+				if _, err = r.Create(tx); err != nil {
+					return err
+				}
+			}
+			if err = postContent.DeleteByPostId(tx, post.ID); err != nil {
+				return err
+			}
+			for _, c := range contents {
+				c.PostID = post.ID
+				if _, err = c.Create(tx); err != nil {
+					return err
+				}
+			}
+			post.EditedOn = time.Now().Unix()
+			return post.Update(tx)
+		},
+	)
+	if err != nil {
+		return err
+	}
+	s.cacheIndex.SendAction(core.IdxActUpdatePost, post)
+	return nil
+}
+
+// SchedulePost 修改定时发布推文的发布时间
+func (s *tweetManageSrv) SchedulePost(post *ms.Post, publishAt int64) error {
+	// 限时推文的有效时长从发布时起算
+	if post.ExpiresAt > 0 {
+		post.ExpiresAt += publishAt - post.PublishAt
+	}
+	post.PublishAt = publishAt
+	return post.Update(s.db)
+}
+
+// PromoteThreadHead 串推首条被删除后由下一条未删除的动态接替为首条，其余动态(包括已删除的)改为挂在新首条下，
+// 没有剩余动态时不做处理，返回新的首条
+func (s *tweetManageSrv) PromoteThreadHead(head *ms.Post) (newHead *ms.Post, err error) {
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var next dbr.Post
+		if err := tx.Where("thread_root_id = ? AND is_del = 0", head.ID).Order("id ASC").First(&next).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		var count int64
+		if err := tx.Model(&dbr.Post{}).Where("thread_root_id = ? AND id <> ? AND is_del = 0", head.ID, next.ID).Count(&count).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&dbr.Post{}).Where("id = ?", next.ID).Updates(map[string]any{
+			"thread_root_id":   0,
+			"thread_parent_id": 0,
+			"thread_count":     count,
+		}).Error; err != nil {
+			return err
+		}
+		udb := tx.Unscoped().Session(&gorm.Session{})
+		if err := udb.Model(&dbr.Post{}).Where("thread_parent_id = ? AND thread_root_id = ? AND id <> ?", head.ID, head.ID, next.ID).Update("thread_parent_id", next.ID).Error; err != nil {
+			return err
+		}
+		if err := udb.Model(&dbr.Post{}).Where("thread_root_id = ? AND id <> ?", head.ID, next.ID).Update("thread_root_id", next.ID).Error; err != nil {
+			return err
+		}
+		if err := udb.Model(&dbr.Post{}).Where("id = ?", head.ID).Update("thread_count", 0).Error; err != nil {
+			return err
+		}
+		newHead = &next
+		newHead.ThreadRootID, newHead.ThreadParentID, newHead.ThreadCount = 0, 0, count
+		return nil
+	})
+	if err != nil || newHead == nil {
+		return nil, err
+	}
+	head.ThreadCount = 0
+	s.cacheIndex.SendAction(core.IdxActUpdatePost, newHead)
+	return newHead, nil
+}
+
+// PublishPost 发布到点的定时推文，发布时间即为推文的创建时间，
+// 以推文仍处于待发布状态为条件更新，多个实例同时发布时只有一个会返回 true
+func (s *tweetManageSrv) PublishPost(post *ms.Post) (bool, error) {
+	now := time.Now().Unix()
+	res := s.db.Model(&dbr.Post{}).Where("id = ? AND publish_at > 0 AND is_del = 0", post.ID).Updates(map[string]any{
+		"publish_at":        0,
+		"created_on":        now,
+		"latest_replied_on": now,
+	})
+	if res.Error != nil || res.RowsAffected == 0 {
+		return false, res.Error
+	}
+	post.PublishAt, post.CreatedOn, post.LatestRepliedOn = 0, now, now
+	s.cacheIndex.SendAction(core.IdxActUpdatePost, post)
+	return true, nil
+}
+
+// ExpirePost 软删除已过期的限时推文，内容与评论保留供作者在归档中查看
+func (s *tweetManageSrv) ExpirePost(post *ms.Post) error {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := post.Delete(tx); err != nil {
+			return err
+		}
+		// 私密推文没有计入话题引用数
+		if post.Visibility != dbr.PostVisitPrivate && post.Tags != "" {
+			deleteTags(tx, strings.Split(post.Tags, ","))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.cacheIndex.SendAction(core.IdxActDeletePost, post)
+	return nil
+}
+
 func (s *tweetManageSrv) CreatePostStar(postID, userID int64) (*ms.PostStar, error) {
 	star := &dbr.PostStar{
 		PostID: postID,
@@ -392,39 +756,44 @@ func (s *tweetSrv) GetPosts(conditions ms.ConditionsT, offset, limit int) ([]*ms
 	return (&dbr.Post{}).List(s.db, conditions, offset, limit)
 }
 
-func (s *tweetSrv) ListUserTweets(userId int64, style uint8, justEssence bool, limit, offset int) (res []*ms.Post, total int64, err error) {
-	db := s.db.Model(&dbr.Post{}).Where("user_id = ?", userId)
+func (s *tweetSrv) ListUserTweets(user *cs.VistUser, style uint8, justEssence bool, limit, offset int) (res []*ms.Post, total int64, err error) {
+	db := s.db.Model(&dbr.Post{}).Where("user_id = ?", user.UserId)
 	switch style {
 	case cs.StyleUserTweetsAdmin:
 		fallthrough
 	case cs.StyleUserTweetsSelf:
 		db = db.Where("visibility >= ?", cs.TweetVisitPrivate)
 	case cs.StyleUserTweetsFriend:
-		db = db.Where("visibility >= ?", cs.TweetVisitFriend)
+		// 分组可见的推文只有被作者加入所选分组的好友可见
+		db = db.Where("visibility >= ? OR visibility IN ? OR "+audienceWhere(), cs.TweetVisitFriend, dbr.PaidVisibility, user.VisitorId)
 	case cs.StyleUserTweetsFollowing:
-		db = db.Where("visibility >= ?", cs.TweetVisitFollowing)
+		db = db.Where("visibility >= ? OR visibility IN ?", cs.TweetVisitFollowing, dbr.PaidVisibility)
 	case cs.StyleUserTweetsGuest:
 		fallthrough
 	default:
-		db = db.Where("visibility >= ?", cs.TweetVisitPublic)
+		// 付费可见的推文对所有人展示，未付费的访问者只能看到预览
+		db = db.Where("visibility >= ? OR visibility IN ?", cs.TweetVisitPublic, dbr.PaidVisibility)
 	}
 	if justEssence {
 		db = db.Where("is_essence=1")
 	}
+	// 串推只展示首条，定时发布的推文到点前不展示
+	db = db.Where("thread_root_id = 0 AND publish_at = 0").Where(_notExpiredWhere, time.Now().Unix())
 	if err = db.Count(&total).Error; err != nil {
 		return
 	}
 	if offset >= 0 && limit > 0 {
 		db = db.Offset(offset).Limit(limit)
 	}
-	if err = db.Order("is_top DESC, latest_replied_on DESC").Find(&res).Error; err != nil {
+	// 个人主页置顶的推文优先展示，最近置顶的排在前面
+	if err = db.Order("pinned_on DESC, is_top DESC, latest_replied_on DESC").Find(&res).Error; err != nil {
 		return
 	}
 	return
 }
 
 func (s *tweetSrv) ListIndexNewestTweets(limit, offset int) (res []*ms.Post, total int64, err error) {
-	db := s.db.Table(_post_).Where("visibility >= ?", cs.TweetVisitPublic)
+	db := s.db.Table(_post_).Where("(visibility >= ? OR visibility IN ?) AND thread_root_id = 0 AND publish_at = 0", cs.TweetVisitPublic, dbr.PaidVisibility).Where(_notExpiredWhere, time.Now().Unix())
 	if err = db.Count(&total).Error; err != nil {
 		return
 	}
@@ -438,7 +807,7 @@ func (s *tweetSrv) ListIndexNewestTweets(limit, offset int) (res []*ms.Post, tot
 }
 
 func (s *tweetSrv) ListIndexHotsTweets(limit, offset int) (res []*ms.Post, total int64, err error) {
-	db := s.db.Table(_post_).Joins(fmt.Sprintf("LEFT JOIN %s metric ON %s.id=metric.post_id", _post_metric_, _post_)).Where(fmt.Sprintf("visibility >= ? AND %s.is_del=0 AND metric.is_del=0", _post_), cs.TweetVisitPublic)
+	db := s.db.Table(_post_).Joins(fmt.Sprintf("LEFT JOIN %s metric ON %s.id=metric.post_id", _post_metric_, _post_)).Where(fmt.Sprintf("(visibility >= ? OR visibility IN ?) AND %s.thread_root_id=0 AND %s.publish_at=0 AND %s.is_del=0 AND metric.is_del=0", _post_, _post_, _post_), cs.TweetVisitPublic, dbr.PaidVisibility).Where(_notExpiredWhere, time.Now().Unix())
 	if err = db.Count(&total).Error; err != nil {
 		return
 	}
@@ -452,7 +821,8 @@ func (s *tweetSrv) ListIndexHotsTweets(limit, offset int) (res []*ms.Post, total
 }
 
 func (s *tweetSrv) ListSyncSearchTweets(limit, offset int) (res []*ms.Post, total int64, err error) {
-	db := s.db.Table(_post_).Where("visibility >= ?", cs.TweetVisitFriend)
+	// 分组可见的推文也加入索引，搜索时再按访问者过滤
+	db := s.db.Table(_post_).Where("(visibility >= ? OR visibility IN ? OR visibility = ?) AND publish_at = 0", cs.TweetVisitFriend, dbr.PaidVisibility, dbr.PostVisitGroup).Where(_notExpiredWhere, time.Now().Unix())
 	if err = db.Count(&total).Error; err != nil {
 		return
 	}
@@ -472,17 +842,19 @@ func (s *tweetSrv) ListFollowingTweets(userId int64, limit, offset int) (res []*
 	}
 	beFriendCount, beFollowCount := len(beFriendIds), len(beFollowIds)
 	db := s.db.Model(&dbr.Post{})
-	//可见性: 0私密 10充电可见 20订阅可见 30保留 40保留 50好友可见 60关注可见 70保留 80保留 90公开',
+	//可见性: 0私密 10充电可见 20订阅可见 30分组可见 40保留 50好友可见 60关注可见 70保留 80保留 90公开',
+	// 付费可见的推文也展示，未付费的访问者只能看到预览；分组可见的推文只有被加入所选分组的好友可见
 	switch {
 	case beFriendCount > 0 && beFollowCount > 0:
-		db = db.Where("user_id=? OR (visibility>=50 AND user_id IN(?)) OR (visibility>=60 AND user_id IN(?))", userId, beFriendIds, beFollowIds)
+		db = db.Where("user_id=? OR ((visibility>=50 OR visibility IN(10,20)) AND user_id IN(?)) OR ((visibility>=60 OR visibility IN(10,20)) AND user_id IN(?)) OR "+audienceWhere(), userId, beFriendIds, beFollowIds, userId)
 	case beFriendCount > 0 && beFollowCount == 0:
-		db = db.Where("user_id=? OR (visibility>=50 AND user_id IN(?))", userId, beFriendIds)
+		db = db.Where("user_id=? OR ((visibility>=50 OR visibility IN(10,20)) AND user_id IN(?)) OR "+audienceWhere(), userId, beFriendIds, userId)
 	case beFriendCount == 0 && beFollowCount > 0:
-		db = db.Where("user_id=? OR (visibility>=60 AND user_id IN(?))", userId, beFollowIds)
+		db = db.Where("user_id=? OR ((visibility>=60 OR visibility IN(10,20)) AND user_id IN(?))", userId, beFollowIds)
 	case beFriendCount == 0 && beFollowCount == 0:
 		db = db.Where("user_id = ?", userId)
 	}
+	db = db.Where("thread_root_id = 0 AND publish_at = 0").Where(_notExpiredWhere, time.Now().Unix())
 	if err = db.Count(&total).Error; err != nil {
 		return
 	}
@@ -542,28 +914,32 @@ func (s *tweetSrv) ListUserStarTweets(user *cs.VistUser, limit int, offset int)
 	star := &dbr.PostStar{
 		UserID: user.UserId,
 	}
-	if total, err = star.Count(s.db, user.RelTyp, &dbr.ConditionsT{}); err != nil {
+	db := s.db.Where(_notExpiredWhere, time.Now().Unix())
+	if total, err = star.Count(db, user.RelTyp, &dbr.ConditionsT{}); err != nil {
 		return
 	}
-	res, err = star.List(s.db, &dbr.ConditionsT{
+	res, err = star.List(db, &dbr.ConditionsT{
 		"ORDER": s.db.NamingStrategy.TableName("PostStar") + ".id DESC",
 	}, user.RelTyp, limit, offset)
 	return
 }
 
 func (s *tweetSrv) getUserTweets(db *gorm.DB, user *cs.VistUser, limit int, offset int) (res []*ms.Post, total int64, err error) {
-	visibilities := []core.PostVisibleT{core.PostVisitPublic}
+	visibilities := []core.PostVisibleT{core.PostVisitPublic, core.PostVisitCharge, core.PostVisitSubscription}
 	switch user.RelTyp {
 	case cs.RelationAdmin, cs.RelationSelf:
-		visibilities = append(visibilities, core.PostVisitPrivate, core.PostVisitFriend)
+		visibilities = append(visibilities, core.PostVisitPrivate, core.PostVisitFriend, core.PostVisitGroup)
+		db = db.Where("visibility IN ?", visibilities)
 	case cs.RelationFriend:
 		visibilities = append(visibilities, core.PostVisitFriend)
+		// 分组可见的推文只有被作者加入所选分组的好友可见
+		db = db.Where("visibility IN ? OR "+audienceWhere(), visibilities, user.VisitorId)
 	case cs.RelationGuest:
 		fallthrough
 	default:
-		// nothing
+		db = db.Where("visibility IN ?", visibilities)
 	}
-	db = db.Where("visibility IN ? AND is_del=0", visibilities)
+	db = db.Where("publish_at=0 AND is_del=0").Where(_notExpiredWhere, time.Now().Unix())
 	err = db.Count(&total).Error
 	if err != nil {
 		return
@@ -658,6 +1034,51 @@ func (s *tweetSrv) GetPostContentByID(id int64) (*ms.PostContent, error) {
 	}).Get(s.db)
 }
 
+// ListThreadTweets 获取串推的全部动态，包含已删除的动态，按发布顺序排列
+func (s *tweetSrv) ListThreadTweets(rootId int64) (res []*ms.Post, err error) {
+	err = s.db.Unscoped().Where("id = ? OR thread_root_id = ?", rootId, rootId).Order("id ASC").Find(&res).Error
+	return
+}
+
+func (s *tweetSrv) ListScheduledTweets(userId int64, limit, offset int) (res []*ms.Post, total int64, err error) {
+	db := s.db.Model(&dbr.Post{}).Where("user_id = ? AND publish_at > 0", userId)
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	if offset >= 0 && limit > 0 {
+		db = db.Offset(offset).Limit(limit)
+	}
+	err = db.Order("publish_at ASC").Find(&res).Error
+	return
+}
+
+func (s *tweetSrv) ListDueScheduledTweets(now int64, limit int) (res []*ms.Post, err error) {
+	err = s.db.Model(&dbr.Post{}).Where("publish_at > 0 AND publish_at <= ?", now).Order("publish_at ASC").Limit(limit).Find(&res).Error
+	return
+}
+
+func (s *tweetSrv) ListDueExpiredTweets(now int64, limit int) (res []*ms.Post, err error) {
+	err = s.db.Model(&dbr.Post{}).Where("expires_at > 0 AND expires_at <= ?", now).Order("expires_at ASC").Limit(limit).Find(&res).Error
+	return
+}
+
+// ListArchivedTweets 获取用户已过期的限时推文，过期时被软删除的推文其删除时间不早于过期时间
+func (s *tweetSrv) ListArchivedTweets(userId int64, limit, offset int) (res []*ms.Post, total int64, err error) {
+	db := s.db.Unscoped().Model(&dbr.Post{}).Where("user_id = ? AND expires_at > 0 AND is_del = 1 AND deleted_on >= expires_at", userId)
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	if offset >= 0 && limit > 0 {
+		db = db.Offset(offset).Limit(limit)
+	}
+	err = db.Order("expires_at DESC").Find(&res).Error
+	return
+}
+
+func (s *tweetSrv) ListPostContentRevisions(postId int64) ([]*ms.PostContentRevision, error) {
+	return (&dbr.PostContentRevision{}).ListByPostId(s.db, postId)
+}
+
 func (s *tweetSrvA) TweetInfoById(id int64) (*cs.TweetInfo, error) {
 	// TODO
 	return nil, debug.ErrNotImplemented
@@ -0,0 +1,116 @@
+// Copyright 2024 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package jinzhu
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rocboss/paopao-ce/internal/core"
+	"github.com/rocboss/paopao-ce/internal/core/cs"
+	"github.com/rocboss/paopao-ce/internal/core/ms"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("TweetManageService", Ordered, func() {
+	var (
+		db *gorm.DB
+		ts core.TweetManageService
+	)
+
+	BeforeAll(func() {
+		db = newTestDB()
+		ts = newTweetManageService(db, noopCacheIndex{})
+	})
+
+	It("publishes a scheduled tweet only once", func() {
+		post := &ms.Post{UserID: 1, PublishAt: time.Now().Unix()}
+		Expect(db.Create(post).Error).To(Succeed())
+		stale := *post
+		published, err := ts.PublishPost(post)
+		Expect(err).To(Succeed())
+		Expect(published).To(BeTrue())
+		Expect(post.PublishAt).To(BeZero())
+		published, err = ts.PublishPost(&stale)
+		Expect(err).To(Succeed())
+		Expect(published).To(BeFalse())
+	})
+
+	It("does not publish a deleted tweet", func() {
+		post := &ms.Post{UserID: 1, PublishAt: time.Now().Unix()}
+		Expect(db.Create(post).Error).To(Succeed())
+		Expect(post.Delete(db)).To(Succeed())
+		published, err := ts.PublishPost(post)
+		Expect(err).To(Succeed())
+		Expect(published).To(BeFalse())
+		var count int64
+		Expect(db.Unscoped().Model(post).Where("id = ? AND is_del = 1", post.ID).Count(&count).Error).To(Succeed())
+		Expect(count).To(Equal(int64(1)))
+	})
+
+	It("promotes the next member when a thread head is deleted", func() {
+		head := &ms.Post{UserID: 1, ThreadCount: 3}
+		Expect(db.Create(head).Error).To(Succeed())
+		gone := &ms.Post{UserID: 1, ThreadRootID: head.ID, ThreadParentID: head.ID}
+		Expect(db.Create(gone).Error).To(Succeed())
+		next := &ms.Post{UserID: 1, ThreadRootID: head.ID, ThreadParentID: head.ID}
+		Expect(db.Create(next).Error).To(Succeed())
+		last := &ms.Post{UserID: 1, ThreadRootID: head.ID, ThreadParentID: next.ID}
+		Expect(db.Create(last).Error).To(Succeed())
+		Expect(gone.Delete(db)).To(Succeed())
+		Expect(head.Delete(db)).To(Succeed())
+
+		newHead, err := ts.PromoteThreadHead(head)
+		Expect(err).To(Succeed())
+		Expect(newHead.ID).To(Equal(next.ID))
+		Expect(newHead.ThreadCount).To(Equal(int64(1)))
+
+		var members []*ms.Post
+		Expect(db.Unscoped().Where("id IN ?", []int64{head.ID, gone.ID, next.ID, last.ID}).Order("id ASC").Find(&members).Error).To(Succeed())
+		Expect(members[0].ThreadCount).To(BeZero())
+		Expect(members[1].ThreadRootID).To(Equal(next.ID))
+		Expect(members[1].ThreadParentID).To(Equal(next.ID))
+		Expect(members[2].ThreadRootID).To(BeZero())
+		Expect(members[2].ThreadParentID).To(BeZero())
+		Expect(members[2].ThreadCount).To(Equal(int64(1)))
+		Expect(members[3].ThreadRootID).To(Equal(next.ID))
+		Expect(members[3].ThreadParentID).To(Equal(next.ID))
+	})
+
+	It("leaves a thread without live members alone", func() {
+		head := &ms.Post{UserID: 1, ThreadCount: 1}
+		Expect(db.Create(head).Error).To(Succeed())
+		member := &ms.Post{UserID: 1, ThreadRootID: head.ID, ThreadParentID: head.ID}
+		Expect(db.Create(member).Error).To(Succeed())
+		Expect(member.Delete(db)).To(Succeed())
+		newHead, err := ts.PromoteThreadHead(head)
+		Expect(err).To(Succeed())
+		Expect(newHead).To(BeNil())
+	})
+
+	It("pins tweets up to the limit and unpins them", func() {
+		user := &ms.User{Username: "pinner", Nickname: "pinner"}
+		Expect(db.Create(user).Error).To(Succeed())
+		posts := make([]*ms.Post, 3)
+		for i := range posts {
+			posts[i] = &ms.Post{UserID: user.ID}
+			Expect(db.Create(posts[i]).Error).To(Succeed())
+		}
+		Expect(ts.PinPost(posts[0], 2)).To(Succeed())
+		Expect(posts[0].PinnedOn).To(BeNumerically(">", 0))
+		Expect(ts.PinPost(posts[1], 2)).To(Succeed())
+		Expect(ts.PinPost(posts[2], 2)).To(MatchError(cs.ErrMaxPinnedPosts))
+		Expect(posts[2].PinnedOn).To(BeZero())
+
+		Expect(ts.PinPost(posts[0], 2)).To(Succeed())
+		Expect(posts[0].PinnedOn).To(BeZero())
+		Expect(ts.PinPost(posts[2], 2)).To(Succeed())
+		var count int64
+		Expect(db.Model(&ms.Post{}).Where("user_id = ? AND pinned_on > 0", user.ID).Count(&count).Error).To(Succeed())
+		Expect(count).To(Equal(int64(2)))
+	})
+})
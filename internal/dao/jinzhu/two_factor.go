@@ -0,0 +1,106 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package jinzhu
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rocboss/paopao-ce/internal/core"
+	"github.com/rocboss/paopao-ce/internal/core/ms"
+	"github.com/rocboss/paopao-ce/internal/dao/jinzhu/dbr"
+	"gorm.io/gorm"
+)
+
+var (
+	_ core.TwoFactorService = (*twoFactorSrv)(nil)
+)
+
+type twoFactorSrv struct {
+	db *gorm.DB
+}
+
+func newTwoFactorService(db *gorm.DB) core.TwoFactorService {
+	return &twoFactorSrv{
+		db: db,
+	}
+}
+
+func (s *twoFactorSrv) GetUserOTP(userId int64) (*ms.UserOTP, error) {
+	res, err := (&dbr.UserOTP{}).GetByUser(s.db, userId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return res, err
+}
+
+// SetupUserOTP 生成待确认的二次验证，之前未确认的一并作废
+func (s *twoFactorSrv) SetupUserOTP(userId int64, secret string) (res *ms.UserOTP, err error) {
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := (&dbr.UserOTP{}).DeleteByUser(tx, userId, true); err != nil {
+			return err
+		}
+		res, err = (&dbr.UserOTP{
+			UserId: userId,
+			Secret: secret,
+		}).Create(tx)
+		return err
+	})
+	return
+}
+
+func (s *twoFactorSrv) EnableUserOTP(otp *ms.UserOTP, counter int64) error {
+	otp.EnabledOn, otp.LastCounter = time.Now().Unix(), counter
+	return otp.Enable(s.db)
+}
+
+// UseUserOTP 验证码的周期已被使用过时返回gorm.ErrRecordNotFound
+func (s *twoFactorSrv) UseUserOTP(otp *ms.UserOTP, counter int64) error {
+	affected, err := otp.UseCounter(s.db, counter)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	otp.LastCounter = counter
+	return nil
+}
+
+func (s *twoFactorSrv) DisableUserOTP(userId int64) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := (&dbr.UserOTP{}).DeleteByUser(tx, userId, false); err != nil {
+			return err
+		}
+		return (&dbr.UserRecoveryCode{}).DeleteByUser(tx, userId)
+	})
+}
+
+// ResetRecoveryCodes 重新生成恢复码，之前的恢复码全部作废
+func (s *twoFactorSrv) ResetRecoveryCodes(userId int64, codes []string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := (&dbr.UserRecoveryCode{}).DeleteByUser(tx, userId); err != nil {
+			return err
+		}
+		for _, code := range codes {
+			if _, err := (&dbr.UserRecoveryCode{UserId: userId, Code: code}).Create(tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// UseRecoveryCode 恢复码不存在或已使用时返回gorm.ErrRecordNotFound
+func (s *twoFactorSrv) UseRecoveryCode(userId int64, code string) error {
+	affected, err := (&dbr.UserRecoveryCode{UserId: userId, Code: code}).Use(s.db)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -48,6 +48,7 @@ func newUserManageService(db *gorm.DB, ums core.UserMetricServantA) core.UserMan
 			fmt.Sprintf("%s.balance", _user_),
 			fmt.Sprintf("%s.is_admin", _user_),
 			fmt.Sprintf("%s.created_on", _user_),
+			fmt.Sprintf("%s.show_sensitive", _user_),
 			"m.tweets_count",
 		},
 	}
@@ -132,6 +133,11 @@ func (s *userManageSrv) UpdateUser(user *ms.User) error {
 	return user.Update(s.db)
 }
 
+// UpgradeUserPassword 只更新密码摘要，密码已被其他请求修改时不做处理
+func (s *userManageSrv) UpgradeUserPassword(user *ms.User, oldPassword string) error {
+	return s.db.Model(&dbr.User{}).Where("id = ? AND password = ? AND is_del = 0", user.ID, oldPassword).Update("password", user.Password).Error
+}
+
 func (s *userManageSrv) GetRegisterUserCount() (res int64, err error) {
 	err = s.db.Model(&dbr.User{}).Count(&res).Error
 	return
@@ -0,0 +1,59 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package jinzhu
+
+import (
+	"github.com/rocboss/paopao-ce/internal/core"
+	"github.com/rocboss/paopao-ce/internal/core/cs"
+	"github.com/rocboss/paopao-ce/internal/dao/jinzhu/dbr"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+var (
+	_ core.TweetViewService = (*tweetViewSrv)(nil)
+)
+
+type tweetViewSrv struct {
+	db  *gorm.DB
+	tms core.TweetMetricServantA
+}
+
+func newTweetViewService(db *gorm.DB, tms core.TweetMetricServantA) core.TweetViewService {
+	return &tweetViewSrv{
+		db:  db,
+		tms: tms,
+	}
+}
+
+// AddPostViews 累加推文浏览数并更新排序指标
+func (s *tweetViewSrv) AddPostViews(views map[int64]int64) error {
+	for postId, count := range views {
+		if count <= 0 {
+			continue
+		}
+		if err := s.db.Model(&dbr.Post{}).Where("id = ?", postId).Update("view_count", gorm.Expr("view_count + ?", count)).Error; err != nil {
+			return err
+		}
+		post, err := (&dbr.Post{Model: &dbr.Model{ID: postId}}).Get(s.db)
+		if err != nil {
+			// 推文可能已被删除，忽略即可
+			continue
+		}
+		// 浏览数变化频繁，只更新排序指标而不主动使缓存失效
+		if err = s.tms.UpdateTweetMetric(&cs.TweetMetric{
+			PostId:          post.ID,
+			CommentCount:    post.CommentCount,
+			UpvoteCount:     post.UpvoteCount,
+			CollectionCount: post.CollectionCount,
+			ShareCount:      post.ShareCount,
+			ReactionCount:   post.ReactionCount,
+			ViewCount:       post.ViewCount,
+		}); err != nil {
+			logrus.Errorf("tweetViewSrv.AddPostViews update metric of post[%d] err: %s", post.ID, err)
+		}
+	}
+	return nil
+}
@@ -5,6 +5,8 @@
 package search
 
 import (
+	"time"
+
 	"github.com/rocboss/paopao-ce/internal/core"
 	"github.com/rocboss/paopao-ce/internal/core/ms"
 	"github.com/rocboss/paopao-ce/pkg/types"
@@ -23,10 +25,12 @@ func (s *tweetSearchFilter) filterResp(user *ms.User, resp *core.QueryResp) {
 	var item *ms.PostFormated
 	items := resp.Items
 	latestIndex := len(items) - 1
+	// 已过期的限时推文在搜索文档被定时任务删除之前也不再展示
+	now := time.Now().Unix()
 	if user == nil {
 		for i := 0; i <= latestIndex; i++ {
 			item = items[i]
-			if item.Visibility != core.PostVisitPublic {
+			if item.Visibility != core.PostVisitPublic || isExpired(item, now) {
 				items[i] = items[latestIndex]
 				items = items[:latestIndex]
 				resp.Total--
@@ -35,14 +39,27 @@ func (s *tweetSearchFilter) filterResp(user *ms.User, resp *core.QueryResp) {
 			}
 		}
 	} else {
-		var cutFriend, cutPrivate bool
+		var cutFriend, cutPrivate, cutPaid, cutGroup, cutExpired bool
 		friendFilter := s.ams.BeFriendFilter(user.ID)
 		friendFilter[user.ID] = types.Empty{}
+		// 付费可见的推文只有作者与充电/订阅过的用户可以搜索到，避免通过搜索探测付费内容
+		sponsorFilter := s.ams.SponsorFilter(user.ID)
+		// 分组可见的推文只有作者与被加入所选分组的好友可以搜索到
+		var groupPostIds []int64
+		for _, post := range items {
+			if post.Visibility == core.PostVisitGroup && post.UserID != user.ID {
+				groupPostIds = append(groupPostIds, post.ID)
+			}
+		}
+		audienceFilter := s.ams.AudienceFilter(user.ID, groupPostIds)
 		for i := 0; i <= latestIndex; i++ {
 			item = items[i]
 			cutFriend = (item.Visibility == core.PostVisitFriend && !friendFilter.IsFriend(item.UserID))
 			cutPrivate = (item.Visibility == core.PostVisitPrivate && user.ID != item.UserID)
-			if cutFriend || cutPrivate {
+			cutPaid = (item.Visibility.IsPaid() && user.ID != item.UserID && !sponsorFilter.IsAllow(item.UserID, item.Visibility))
+			cutGroup = (item.Visibility == core.PostVisitGroup && user.ID != item.UserID && !audienceFilter.IsAudience(item.ID))
+			cutExpired = isExpired(item, now)
+			if cutFriend || cutPrivate || cutPaid || cutGroup || cutExpired {
 				items[i] = items[latestIndex]
 				items = items[:latestIndex]
 				resp.Total--
@@ -54,3 +71,7 @@ func (s *tweetSearchFilter) filterResp(user *ms.User, resp *core.QueryResp) {
 
 	resp.Items = items
 }
+
+func isExpired(item *ms.PostFormated, now int64) bool {
+	return item.ExpiresAt > 0 && item.ExpiresAt <= now
+}
@@ -29,6 +29,8 @@ type meiliTweetSearchServant struct {
 	publicFilter  string
 	privateFilter string
 	friendFilter  string
+	paidFilter    string
+	groupFilter   string
 }
 
 type postInfo struct {
@@ -46,6 +48,7 @@ type postInfo struct {
 	ModifiedOn      int64             `json:"modified_on"`
 	AttachmentPrice int64             `json:"attachment_price"`
 	IPLoc           string            `json:"ip_loc"`
+	ExpiresAt       int64             `json:"expires_at"`
 }
 
 func (s *meiliTweetSearchServant) Name() string {
@@ -174,7 +177,7 @@ func (s *meiliTweetSearchServant) filterList(user *ms.User) string {
 		return ""
 	}
 
-	return fmt.Sprintf("%s OR %s OR (%s%d)", s.publicFilter, s.friendFilter, s.privateFilter, user.ID)
+	return fmt.Sprintf("%s OR %s OR %s OR %s OR (%s%d)", s.publicFilter, s.friendFilter, s.paidFilter, s.groupFilter, s.privateFilter, user.ID)
 }
 
 func (s *meiliTweetSearchServant) postsFrom(resp *meilisearch.SearchResponse) (*core.QueryResp, error) {
@@ -203,6 +206,7 @@ func (s *meiliTweetSearchServant) postsFrom(resp *meilisearch.SearchResponse) (*
 			ModifiedOn:      p.ModifiedOn,
 			AttachmentPrice: p.AttachmentPrice,
 			IPLoc:           p.IPLoc,
+			ExpiresAt:       p.ExpiresAt,
 		})
 	}
 	return &core.QueryResp{
@@ -230,6 +234,7 @@ func (s *meiliTweetSearchServant) toDocs(data []core.TsDocItem) []map[string]any
 			"attachment_price":  d.Post.AttachmentPrice,
 			"created_on":        d.Post.CreatedOn,
 			"modified_on":       d.Post.ModifiedOn,
+			"expires_at":        d.Post.ExpiresAt,
 		})
 	}
 	return docs
@@ -49,6 +49,8 @@ func NewMeiliTweetSearchService(ams core.AuthorizationManageService) (core.Tweet
 		publicFilter:  fmt.Sprintf("visibility=%d", core.PostVisitPublic),
 		privateFilter: fmt.Sprintf("visibility=%d AND user_id=", core.PostVisitPrivate),
 		friendFilter:  fmt.Sprintf("visibility=%d", core.PostVisitFriend),
+		paidFilter:    fmt.Sprintf("visibility IN [%d, %d]", core.PostVisitCharge, core.PostVisitSubscription),
+		groupFilter:   fmt.Sprintf("visibility=%d", core.PostVisitGroup),
 	}
 	return mts, mts
 }
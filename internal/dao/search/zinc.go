@@ -256,6 +256,12 @@ func (s *zincTweetSearchServant) createIndex() {
 			Sortable: true,
 			Store:    true,
 		},
+		"expires_at": &zinc.ZincIndexPropertyT{
+			Type:     "numeric",
+			Index:    true,
+			Sortable: true,
+			Store:    true,
+		},
 	})
 }
 
@@ -282,6 +288,7 @@ func (s *zincTweetSearchServant) toDocs(data []core.TsDocItem) []map[string]any
 			"attachment_price":  d.Post.AttachmentPrice,
 			"created_on":        d.Post.CreatedOn,
 			"modified_on":       d.Post.ModifiedOn,
+			"expires_at":        d.Post.ExpiresAt,
 		})
 	}
 	return docs
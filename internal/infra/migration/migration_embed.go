@@ -35,6 +35,8 @@ func Run() {
 		dbDriver  database.Driver
 		srcDriver source.Driver
 		err, err2 error
+
+		normalizeTagsVersion uint = _normalizeTagsVersion
 	)
 
 	if cfg.If("MySQL") {
@@ -61,6 +63,7 @@ func Run() {
 	} else if cfg.If("PostgreSQL") || cfg.If("Postgres") {
 		srcDriver, err = iofs.New(migration.Files, "postgres")
 		dbDriver, err2 = postgres.WithInstance(db, &postgres.Config{MigrationsTable: migrationsTable})
+		normalizeTagsVersion = _normalizeTagsVersionPostgres
 	} else if cfg.If("Sqlite3") {
 		srcDriver, err = iofs.New(migration.Files, "sqlite3")
 		dbDriver, err2 = sqlite3.WithInstance(db, &sqlite3.Config{MigrationsTable: migrationsTable})
@@ -86,9 +89,18 @@ func Run() {
 		return
 	}
 
+	// 全新安装时没有版本号，视为0
+	version, _, _ := m.Version()
 	if err = m.Up(); err != nil && err != migrate.ErrNoChange {
 		logrus.Errorf("migrate up failed: %s", err)
 		return
 	}
+	// 本次迁移跨过话题规范化的版本时，规范化已有话题
+	if version < normalizeTagsVersion {
+		if err = normalizeTags(conf.MustGormDB(), conf.DatabaseSetting.TablePrefix); err != nil {
+			logrus.Errorf("normalize tags failed: %s", err)
+			return
+		}
+	}
 	logrus.Infoln("migrate up success")
 }
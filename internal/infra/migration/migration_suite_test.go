@@ -0,0 +1,38 @@
+// Copyright 2024 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package migration
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestMigration(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Migration Suite")
+}
+
+// newTestDB 以 sqlite 内存数据库加载完整表结构
+func newTestDB() *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	Expect(err).To(Succeed())
+	sqlDB, err := db.DB()
+	Expect(err).To(Succeed())
+	// 内存数据库每个连接都是独立的库，只保留一个连接
+	sqlDB.SetMaxOpenConns(1)
+	ddl, err := os.ReadFile("../../../scripts/paopao-sqlite3.sql")
+	Expect(err).To(Succeed())
+	Expect(db.Exec(string(ddl)).Error).To(Succeed())
+	return db
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package migration
+
+import (
+	"github.com/rocboss/paopao-ce/pkg/hashtag"
+	"gorm.io/gorm"
+)
+
+// 话题规范化对应的迁移版本，postgres的迁移编号比mysql/sqlite3少一
+const (
+	_normalizeTagsVersion         = 40
+	_normalizeTagsVersionPostgres = 39
+)
+
+type tagRow struct {
+	ID       int64
+	Tag      string
+	QuoteNum int64
+	IsDel    int8
+}
+
+// normalizeTags 将已有话题按hashtag.Normalize规范化(NFKC与大小写折叠)，规范化后相同的话题合并为一个：
+// 保留已规范化或id最小的话题，引用数累加未删除话题的引用数，用户关注的话题改为指向保留的话题。
+// NFKC无法在SQL中完成，因此在对应版本的SQL迁移执行后由此处完成
+func normalizeTags(db *gorm.DB, tablePrefix string) error {
+	tagTable, topicUserTable := tablePrefix+"tag", tablePrefix+"topic_user"
+	return db.Transaction(func(tx *gorm.DB) error {
+		var rows []*tagRow
+		if err := tx.Table(tagTable).Select("id, tag, quote_num, is_del").Order("id ASC").Find(&rows).Error; err != nil {
+			return err
+		}
+		groups, names := make(map[string][]*tagRow), []string{}
+		for _, row := range rows {
+			name, ok := hashtag.Normalize(row.Tag)
+			if !ok {
+				continue
+			}
+			if _, exist := groups[name]; !exist {
+				names = append(names, name)
+			}
+			groups[name] = append(groups[name], row)
+		}
+		for _, name := range names {
+			group := groups[name]
+			if len(group) == 1 && group[0].Tag == name {
+				continue
+			}
+			keeper := group[0]
+			for _, row := range group {
+				if row.Tag == name {
+					keeper = row
+					break
+				}
+			}
+			quoteNum, isDel := int64(0), int8(1)
+			for _, row := range group {
+				if row.IsDel == 0 {
+					quoteNum, isDel = quoteNum+row.QuoteNum, 0
+				}
+				if row == keeper {
+					continue
+				}
+				// 已关注保留话题的用户不再重复关注
+				if err := tx.Exec("DELETE FROM "+topicUserTable+" WHERE topic_id = ? AND user_id IN (SELECT user_id FROM (SELECT user_id FROM "+topicUserTable+" WHERE topic_id = ?) t)", row.ID, keeper.ID).Error; err != nil {
+					return err
+				}
+				if err := tx.Table(topicUserTable).Where("topic_id = ?", row.ID).Update("topic_id", keeper.ID).Error; err != nil {
+					return err
+				}
+				if err := tx.Exec("DELETE FROM "+tagTable+" WHERE id = ?", row.ID).Error; err != nil {
+					return err
+				}
+			}
+			if err := tx.Table(tagTable).Where("id = ?", keeper.ID).Updates(map[string]any{
+				"tag":       name,
+				"quote_num": quoteNum,
+				"is_del":    isDel,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
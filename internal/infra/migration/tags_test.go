@@ -0,0 +1,77 @@
+// Copyright 2024 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package migration
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"gorm.io/gorm"
+)
+
+var _ = Describe("normalizeTags", Ordered, func() {
+	var db *gorm.DB
+
+	BeforeAll(func() {
+		db = newTestDB()
+		for _, tag := range []struct {
+			id       int64
+			tag      string
+			quoteNum int64
+			isDel    int
+		}{
+			{1, "GoLang", 3, 0},
+			{2, "golang", 2, 0},
+			{3, "ＧＯＬＡＮＧ", 1, 0},
+			{4, "Golang", 5, 1},
+			{5, "Rust", 4, 0},
+			{6, "paopao", 7, 0},
+		} {
+			Expect(db.Exec("INSERT INTO p_tag (id, user_id, tag, quote_num, is_del, created_on, modified_on, deleted_on) VALUES (?, 1, ?, ?, ?, 0, 0, 0)",
+				tag.id, tag.tag, tag.quoteNum, tag.isDel).Error).To(Succeed())
+		}
+		for _, topic := range [][2]int64{{1, 10}, {2, 10}, {3, 11}, {5, 12}} {
+			Expect(db.Exec("INSERT INTO p_topic_user (topic_id, user_id, is_top, is_pin, created_on, modified_on, deleted_on, is_del) VALUES (?, ?, 0, 0, 0, 0, 0, 0)", topic[0], topic[1]).Error).To(Succeed())
+		}
+		Expect(normalizeTags(db, "p_")).To(Succeed())
+	})
+
+	It("merges tags that normalize to the same name", func() {
+		var tags []struct {
+			ID       int64
+			Tag      string
+			QuoteNum int64
+		}
+		Expect(db.Table("p_tag").Order("id ASC").Find(&tags).Error).To(Succeed())
+		Expect(tags).To(HaveLen(3))
+		Expect(tags[0].ID).To(Equal(int64(2)))
+		Expect(tags[0].Tag).To(Equal("golang"))
+		Expect(tags[0].QuoteNum).To(Equal(int64(6)))
+		Expect(tags[1].Tag).To(Equal("rust"))
+		Expect(tags[1].QuoteNum).To(Equal(int64(4)))
+		Expect(tags[2].Tag).To(Equal("paopao"))
+	})
+
+	It("moves followed topics to the kept tag without duplicates", func() {
+		var topics []struct {
+			TopicID int64
+			UserID  int64
+		}
+		Expect(db.Table("p_topic_user").Order("user_id ASC").Find(&topics).Error).To(Succeed())
+		Expect(topics).To(HaveLen(3))
+		Expect(topics[0].TopicID).To(Equal(int64(2)))
+		Expect(topics[0].UserID).To(Equal(int64(10)))
+		Expect(topics[1].TopicID).To(Equal(int64(2)))
+		Expect(topics[1].UserID).To(Equal(int64(11)))
+		Expect(topics[2].TopicID).To(Equal(int64(5)))
+	})
+
+	It("does nothing the second time", func() {
+		Expect(normalizeTags(db, "p_")).To(Succeed())
+		var count int64
+		Expect(db.Table("p_tag").Count(&count).Error).To(Succeed())
+		Expect(count).To(Equal(int64(3)))
+	})
+})
@@ -4,12 +4,25 @@
 
 package web
 
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rocboss/paopao-ce/internal/core/ms"
+	"github.com/rocboss/paopao-ce/internal/servants/base"
+)
+
 type ChangeUserStatusReq struct {
 	BaseInfo `json:"-" binding:"-"`
 	ID       int64 `json:"id" form:"id" binding:"required"`
 	Status   int   `json:"status" form:"status" binding:"required,oneof=1 2"`
 }
 
+type ChangeTweetSensitiveReq struct {
+	BaseInfo       `json:"-" binding:"-"`
+	ID             int64  `json:"id" binding:"required"`
+	IsSensitive    bool   `json:"is_sensitive"`
+	ContentWarning string `json:"content_warning"`
+}
+
 type SiteInfoReq struct {
 	SimpleInfo `json:"-" binding:"-"`
 }
@@ -20,3 +33,28 @@ type SiteInfoResp struct {
 	HistoryMaxOnline  int   `json:"history_max_online"`
 	ServerUpTime      int64 `json:"server_up_time"`
 }
+
+type CommentRevisionsReq struct {
+	SimpleInfo `form:"-" binding:"-"`
+	CommentId  int64 `form:"comment_id" binding:"required"`
+	ReplyId    int64 `form:"reply_id"`
+}
+
+// CommentRevision 评论或回复的一个历史版本
+type CommentRevision struct {
+	Revision  int64                         `json:"revision"`
+	EditorId  int64                         `json:"editor_id"`
+	CreatedOn int64                         `json:"created_on"`
+	Contents  []*ms.CommentRevisionFormated `json:"contents"`
+}
+
+type CommentRevisionsResp struct {
+	Revisions []*CommentRevision `json:"revisions"`
+}
+
+type ListTrashTweetsReq BasePageReq
+type ListTrashTweetsResp base.PageResp
+
+func (r *ListTrashTweetsReq) Bind(c *gin.Context) error {
+	return (*BasePageReq)(r).Bind(c)
+}
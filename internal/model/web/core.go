@@ -7,6 +7,7 @@ package web
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/rocboss/paopao-ce/internal/core/cs"
+	"github.com/rocboss/paopao-ce/internal/core/ms"
 	"github.com/rocboss/paopao-ce/internal/model/joint"
 	"github.com/rocboss/paopao-ce/internal/servants/base"
 	"github.com/rocboss/paopao-ce/pkg/convert"
@@ -30,18 +31,20 @@ type UserInfoReq struct {
 }
 
 type UserInfoResp struct {
-	Id          int64  `json:"id"`
-	Nickname    string `json:"nickname"`
-	Username    string `json:"username"`
-	Status      int    `json:"status"`
-	Avatar      string `json:"avatar"`
-	Balance     int64  `json:"balance"`
-	Phone       string `json:"phone"`
-	IsAdmin     bool   `json:"is_admin"`
-	CreatedOn   int64  `json:"created_on"`
-	Follows     int64  `json:"follows"`
-	Followings  int64  `json:"followings"`
-	TweetsCount int    `json:"tweets_count"`
+	Id            int64  `json:"id"`
+	Nickname      string `json:"nickname"`
+	Username      string `json:"username"`
+	Status        int    `json:"status"`
+	Avatar        string `json:"avatar"`
+	Balance       int64  `json:"balance"`
+	Phone         string `json:"phone"`
+	IsAdmin       bool   `json:"is_admin"`
+	CreatedOn     int64  `json:"created_on"`
+	Follows       int64  `json:"follows"`
+	Followings    int64  `json:"followings"`
+	TweetsCount   int    `json:"tweets_count"`
+	ShowSensitive bool   `json:"show_sensitive"`
+	TwoFactor     bool   `json:"two_factor"`
 }
 
 type GetMessagesReq struct {
@@ -75,6 +78,9 @@ type GetCollectionsResp base.PageResp
 type GetStarsReq BasePageReq
 type GetStarsResp base.PageResp
 
+type GetMentionsReq BasePageReq
+type GetMentionsResp base.PageResp
+
 type UserPhoneBindReq struct {
 	BaseInfo `json:"-" binding:"-"`
 	Phone    string `json:"phone" form:"phone" binding:"required"`
@@ -87,11 +93,86 @@ type ChangePasswordReq struct {
 	OldPassword string `json:"old_password" form:"old_password" binding:"required"`
 }
 
+type ListSessionsReq struct {
+	SimpleInfo  `form:"-" binding:"-"`
+	SessionInfo `form:"-" binding:"-"`
+}
+
+type ListSessionsResp struct {
+	List []*ms.UserSessionFormated `json:"list"`
+}
+
+type RevokeSessionReq struct {
+	SimpleInfo `json:"-" binding:"-"`
+	ID         int64 `json:"id" binding:"required"`
+}
+
+type RevokeAllSessionsReq struct {
+	SimpleInfo  `json:"-" binding:"-"`
+	SessionInfo `json:"-" binding:"-"`
+	KeepCurrent bool `json:"keep_current"`
+}
+
+type SetupTwoFactorReq struct {
+	BaseInfo `json:"-" binding:"-"`
+}
+
+type SetupTwoFactorResp TwoFactorSetupResp
+
+type EnableTwoFactorReq struct {
+	BaseInfo `json:"-" binding:"-"`
+	Code     string `json:"code" binding:"required"`
+}
+
+type EnableTwoFactorResp struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type DisableTwoFactorReq struct {
+	BaseInfo `json:"-" binding:"-"`
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+type ResetRecoveryCodesReq struct {
+	BaseInfo `json:"-" binding:"-"`
+	Code     string `json:"code" binding:"required"`
+}
+
+type ResetRecoveryCodesResp EnableTwoFactorResp
+
 type ChangeNicknameReq struct {
 	BaseInfo `json:"-" binding:"-"`
 	Nickname string `json:"nickname" form:"nickname" binding:"required"`
 }
 
+type ChangeShowSensitiveReq struct {
+	BaseInfo `json:"-" binding:"-"`
+	Show     bool `json:"show_sensitive" form:"show_sensitive"`
+}
+
+type ChargeUserReq struct {
+	BaseInfo `json:"-" binding:"-"`
+	UserId   int64 `json:"user_id" binding:"required"`
+	Amount   int64 `json:"amount" binding:"required"`
+}
+
+type SubscribeUserReq struct {
+	BaseInfo `json:"-" binding:"-"`
+	UserId   int64 `json:"user_id" binding:"required"`
+}
+
+// SponsorInfo 充电或订阅后的状态
+type SponsorInfo struct {
+	CreatorId          int64 `json:"creator_id"`
+	ChargedAmount      int64 `json:"charged_amount"`
+	SubscribeExpiredOn int64 `json:"subscribe_expired_on"`
+	Balance            int64 `json:"balance"`
+}
+
+type ChargeUserResp SponsorInfo
+type SubscribeUserResp SponsorInfo
+
 type SuggestUsersReq struct {
 	Keyword string
 }
@@ -143,6 +224,10 @@ func (r *GetStarsReq) Bind(c *gin.Context) error {
 	return (*BasePageReq)(r).Bind(c)
 }
 
+func (r *GetMentionsReq) Bind(c *gin.Context) error {
+	return (*BasePageReq)(r).Bind(c)
+}
+
 func (r *SuggestTagsReq) Bind(c *gin.Context) error {
 	r.Keyword = c.Query("k")
 	return nil
@@ -5,6 +5,7 @@
 package web
 
 import (
+	"github.com/rocboss/paopao-ce/internal/core/ms"
 	"github.com/rocboss/paopao-ce/internal/servants/base"
 )
 
@@ -40,3 +41,36 @@ type GetContactsResp base.PageResp
 func (r *GetContactsReq) SetPageInfo(page int, pageSize int) {
 	r.Page, r.PageSize = page, pageSize
 }
+
+type ListContactGroupsReq struct {
+	BaseInfo `form:"-" binding:"-"`
+}
+
+type ListContactGroupsResp struct {
+	List []*ms.ContactGroupFormated `json:"list"`
+}
+
+type CreateContactGroupReq struct {
+	BaseInfo `json:"-" binding:"-"`
+	Name     string `json:"name" binding:"required"`
+}
+
+type CreateContactGroupResp ms.ContactGroupFormated
+
+type RenameContactGroupReq struct {
+	BaseInfo `json:"-" binding:"-"`
+	ID       int64  `json:"id" binding:"required"`
+	Name     string `json:"name" binding:"required"`
+}
+
+type DeleteContactGroupReq struct {
+	BaseInfo `json:"-" binding:"-"`
+	ID       int64 `json:"id" binding:"required"`
+}
+
+// SetContactGroupReq GroupId为0时把好友移出分组
+type SetContactGroupReq struct {
+	BaseInfo `json:"-" binding:"-"`
+	UserId   int64 `json:"user_id" binding:"required"`
+	GroupId  int64 `json:"group_id"`
+}
@@ -39,10 +39,13 @@ type TagType = cs.TagType
 
 type CommentStyleType string
 
+type ReplyStyleType string
+
 type TweetCommentsReq struct {
 	SimpleInfo `form:"-" binding:"-"`
 	TweetId    int64            `form:"id" binding:"required"`
 	Style      CommentStyleType `form:"style"`
+	ReplyStyle ReplyStyleType   `form:"reply_style"`
 	Page       int              `form:"-" binding:"-"`
 	PageSize   int              `form:"-" binding:"-"`
 }
@@ -51,6 +54,20 @@ type TweetCommentsResp struct {
 	joint.CachePageResp
 }
 
+type CommentRepliesReq struct {
+	SimpleInfo `form:"-" binding:"-"`
+	CommentId  int64          `form:"id" binding:"required"`
+	Style      ReplyStyleType `form:"style"`
+	Cursor     string         `form:"cursor"`
+	PageSize   int            `form:"page_size"`
+}
+
+type CommentRepliesResp struct {
+	List       []*ms.CommentReplyFormated `json:"list"`
+	NextCursor string                     `json:"next_cursor"`
+	HasMore    bool                       `json:"has_more"`
+}
+
 type TimelineReq struct {
 	BaseInfo   `form:"-"  binding:"-"`
 	Query      string              `form:"query"`
@@ -77,6 +94,24 @@ type GetUserTweetsResp struct {
 	joint.CachePageResp
 }
 
+type CollectionFoldersReq struct {
+	BaseInfo `form:"-" binding:"-"`
+	Username string `form:"username" binding:"required"`
+}
+
+type CollectionFoldersResp struct {
+	Folders []*ms.PostCollectionFolderFormated `json:"folders"`
+}
+
+type FolderTweetsReq struct {
+	BaseInfo `form:"-" binding:"-"`
+	FolderID int64 `form:"id" binding:"required"`
+	Page     int   `form:"-" binding:"-"`
+	PageSize int   `form:"-" binding:"-"`
+}
+
+type FolderTweetsResp base.PageResp
+
 type GetUserProfileReq struct {
 	BaseInfo `form:"-" binding:"-"`
 	Username string `form:"username" binding:"required"`
@@ -113,19 +148,73 @@ type TopicListResp struct {
 
 type TweetDetailReq struct {
 	BaseInfo `form:"-"  binding:"-"`
-	TweetId  int64 `form:"id"`
+	TweetId  int64  `form:"id"`
+	ClientIP string `form:"-" binding:"-"`
 }
 
 type TweetDetailResp ms.PostFormated
 
+// TweetViewsReq 客户端上报的时间线曝光
+type TweetViewsReq struct {
+	BaseInfo `json:"-" binding:"-"`
+	IDs      []int64 `json:"ids" binding:"required"`
+	ClientIP string  `json:"-" binding:"-"`
+}
+
+type TweetRevisionsReq struct {
+	BaseInfo `form:"-"  binding:"-"`
+	TweetId  int64 `form:"id"`
+}
+
+// TweetRevision 动态的一个历史版本
+type TweetRevision struct {
+	Revision  int64                             `json:"revision"`
+	CreatedOn int64                             `json:"created_on"`
+	Contents  []*ms.PostContentRevisionFormated `json:"contents"`
+}
+
+type TweetRevisionsResp struct {
+	Revisions []*TweetRevision `json:"revisions"`
+}
+
+type TweetThreadReq struct {
+	BaseInfo `form:"-"  binding:"-"`
+	TweetId  int64 `form:"id"`
+}
+
+// TweetThreadItem 串推中的一项，IsGap为true时表示此处的动态已删除或不可见
+type TweetThreadItem struct {
+	IsGap bool             `json:"is_gap"`
+	Tweet *ms.PostFormated `json:"tweet"`
+}
+
+type TweetThreadResp struct {
+	RootId int64              `json:"root_id"`
+	Items  []*TweetThreadItem `json:"items"`
+}
+
 func (r *GetUserTweetsReq) SetPageInfo(page int, pageSize int) {
 	r.Page, r.PageSize = page, pageSize
 }
 
+func (r *FolderTweetsReq) SetPageInfo(page int, pageSize int) {
+	r.Page, r.PageSize = page, pageSize
+}
+
 func (r *TweetCommentsReq) SetPageInfo(page int, pageSize int) {
 	r.Page, r.PageSize = page, pageSize
 }
 
+func (r *TweetDetailReq) Bind(c *gin.Context) error {
+	r.ClientIP = c.ClientIP()
+	return bindAny(c, r)
+}
+
+func (r *TweetViewsReq) Bind(c *gin.Context) error {
+	r.ClientIP = c.ClientIP()
+	return bindAny(c, r)
+}
+
 func (r *TimelineReq) Bind(c *gin.Context) error {
 	user, _ := base.UserFrom(c)
 	r.BaseInfo = BaseInfo{
@@ -150,6 +239,20 @@ func (s CommentStyleType) ToInnerValue() (res cs.StyleCommentType) {
 	return
 }
 
+func (s ReplyStyleType) ToInnerValue() (res cs.StyleReplyType) {
+	switch s {
+	case "hots":
+		res = cs.StyleReplyHots
+	case "newest":
+		res = cs.StyleReplyNewest
+	case "default":
+		fallthrough
+	default:
+		res = cs.StyleReplyDefault
+	}
+	return
+}
+
 func (s CommentStyleType) String() (res string) {
 	switch s {
 	case "default":
@@ -5,10 +5,13 @@
 package web
 
 import (
+	"encoding/json"
 	"fmt"
 	"mime/multipart"
 	"net/http"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rocboss/paopao-ce/internal/core"
@@ -26,6 +29,9 @@ const (
 	TweetVisitPrivate
 	TweetVisitFriend
 	TweetVisitFollowing
+	TweetVisitCharge
+	TweetVisitSubscription
+	TweetVisitGroup
 	TweetVisitInvalid
 )
 
@@ -45,23 +51,189 @@ type TweetReplyThumbsReq struct {
 }
 
 type PostContentItem struct {
-	Content string          `json:"content"  binding:"required"`
-	Type    ms.PostContentT `json:"type"  binding:"required"`
-	Sort    int64           `json:"sort"  binding:"required"`
+	Content     string          `json:"content"  binding:"required"`
+	Type        ms.PostContentT `json:"type"  binding:"required"`
+	Sort        int64           `json:"sort"  binding:"required"`
+	IsSensitive bool            `json:"is_sensitive"`
+}
+
+// PollSpec 投票内容项的内容，以JSON格式保存
+type PollSpec struct {
+	Options   []string `json:"options"`
+	Multiple  bool     `json:"multiple"`
+	ExpiresAt int64    `json:"expires_at"`
 }
 
 type CreateTweetReq struct {
 	BaseInfo        `json:"-" binding:"-"`
-	Contents        []*PostContentItem `json:"contents" binding:"required"`
-	Tags            []string           `json:"tags" binding:"required"`
-	Users           []string           `json:"users" binding:"required"`
-	AttachmentPrice int64              `json:"attachment_price"`
-	Visibility      TweetVisibleType   `json:"visibility"`
-	ClientIP        string             `json:"-" binding:"-"`
+	Contents        []*PostContentItem  `json:"contents" binding:"required"`
+	Tags            []string            `json:"tags" binding:"required"`
+	Users           []string            `json:"users" binding:"required"`
+	AttachmentPrice int64               `json:"attachment_price"`
+	Visibility      TweetVisibleType    `json:"visibility"`
+	GroupIds        []int64             `json:"group_ids"`
+	RepostID        int64               `json:"repost_id"`
+	ThreadParentID  int64               `json:"thread_parent_id"`
+	PublishAt       int64               `json:"publish_at"`
+	IsSensitive     bool                `json:"is_sensitive"`
+	ContentWarning  string              `json:"content_warning"`
+	IsStory         bool                `json:"is_story"`
+	ExpiresIn       int64               `json:"expires_in"`
+	ReplyPolicy     ms.PostReplyPolicyT `json:"reply_policy"`
+	ClientIP        string              `json:"-" binding:"-"`
 }
 
 type CreateTweetResp ms.PostFormated
 
+type EditTweetReq struct {
+	BaseInfo       `json:"-" binding:"-"`
+	ID             int64                `json:"id" binding:"required"`
+	Contents       []*PostContentItem   `json:"contents" binding:"required"`
+	Tags           []string             `json:"tags"`
+	Users          []string             `json:"users" binding:"required"`
+	IsSensitive    *bool                `json:"is_sensitive"`
+	ContentWarning *string              `json:"content_warning"`
+	ReplyPolicy    *ms.PostReplyPolicyT `json:"reply_policy"`
+}
+
+type EditTweetResp ms.PostFormated
+
+type ScheduledTweetsReq BasePageReq
+type ScheduledTweetsResp base.PageResp
+
+type ArchivedTweetsReq BasePageReq
+type ArchivedTweetsResp base.PageResp
+
+type RescheduleTweetReq struct {
+	BaseInfo  `json:"-" binding:"-"`
+	ID        int64 `json:"id" binding:"required"`
+	PublishAt int64 `json:"publish_at" binding:"required"`
+}
+
+type RescheduleTweetResp struct {
+	PublishAt int64 `json:"publish_at"`
+}
+
+type CancelScheduledTweetReq struct {
+	BaseInfo `json:"-" binding:"-"`
+	ID       int64 `json:"id" binding:"required"`
+}
+
+// TweetDraft 推文草稿
+type TweetDraft struct {
+	ID              int64               `json:"id"`
+	Contents        []*PostContentItem  `json:"contents"`
+	Tags            []string            `json:"tags"`
+	Users           []string            `json:"users"`
+	AttachmentPrice int64               `json:"attachment_price"`
+	Visibility      TweetVisibleType    `json:"visibility"`
+	GroupIds        []int64             `json:"group_ids"`
+	IsSensitive     bool                `json:"is_sensitive"`
+	ContentWarning  string              `json:"content_warning"`
+	IsStory         bool                `json:"is_story"`
+	ExpiresIn       int64               `json:"expires_in"`
+	ReplyPolicy     ms.PostReplyPolicyT `json:"reply_policy"`
+	CreatedOn       int64               `json:"created_on"`
+	ModifiedOn      int64               `json:"modified_on"`
+}
+
+type DraftsReq BasePageReq
+type DraftsResp base.PageResp
+
+type TrashTweetsReq BasePageReq
+type TrashTweetsResp base.PageResp
+
+type RestoreTweetReq struct {
+	BaseInfo `json:"-" binding:"-"`
+	ID       int64 `json:"id" binding:"required"`
+}
+
+type RestoreTweetResp ms.PostFormated
+
+type CreateDraftReq struct {
+	BaseInfo        `json:"-" binding:"-"`
+	Contents        []*PostContentItem  `json:"contents" binding:"required"`
+	Tags            []string            `json:"tags"`
+	Users           []string            `json:"users"`
+	AttachmentPrice int64               `json:"attachment_price"`
+	Visibility      TweetVisibleType    `json:"visibility"`
+	GroupIds        []int64             `json:"group_ids"`
+	IsSensitive     bool                `json:"is_sensitive"`
+	ContentWarning  string              `json:"content_warning"`
+	IsStory         bool                `json:"is_story"`
+	ExpiresIn       int64               `json:"expires_in"`
+	ReplyPolicy     ms.PostReplyPolicyT `json:"reply_policy"`
+}
+
+type CreateDraftResp TweetDraft
+
+type UpdateDraftReq struct {
+	BaseInfo        `json:"-" binding:"-"`
+	ID              int64               `json:"id" binding:"required"`
+	Contents        []*PostContentItem  `json:"contents" binding:"required"`
+	Tags            []string            `json:"tags"`
+	Users           []string            `json:"users"`
+	AttachmentPrice int64               `json:"attachment_price"`
+	Visibility      TweetVisibleType    `json:"visibility"`
+	GroupIds        []int64             `json:"group_ids"`
+	IsSensitive     bool                `json:"is_sensitive"`
+	ContentWarning  string              `json:"content_warning"`
+	IsStory         bool                `json:"is_story"`
+	ExpiresIn       int64               `json:"expires_in"`
+	ReplyPolicy     ms.PostReplyPolicyT `json:"reply_policy"`
+}
+
+type UpdateDraftResp TweetDraft
+
+type DeleteDraftReq struct {
+	BaseInfo `json:"-" binding:"-"`
+	ID       int64 `json:"id" binding:"required"`
+}
+
+type PublishDraftReq struct {
+	BaseInfo `json:"-" binding:"-"`
+	ID       int64  `json:"id" binding:"required"`
+	ClientIP string `json:"-" binding:"-"`
+}
+
+type PublishDraftResp ms.PostFormated
+
+type VotePollReq struct {
+	BaseInfo  `json:"-" binding:"-"`
+	PollID    int64   `json:"poll_id" binding:"required"`
+	OptionIDs []int64 `json:"option_ids" binding:"required"`
+}
+
+type VotePollResp ms.PostPollFormated
+
+type ReactionInfo struct {
+	Reactions   []*ms.ReactionCount `json:"reactions"`
+	MyReactions []string            `json:"my_reactions"`
+}
+
+type TweetReactionReq struct {
+	BaseInfo `json:"-" binding:"-"`
+	ID       int64  `json:"id" binding:"required"`
+	Reaction string `json:"reaction" binding:"required"`
+}
+
+type CommentReactionReq struct {
+	BaseInfo `json:"-" binding:"-"`
+	ID       int64  `json:"id" binding:"required"`
+	Reaction string `json:"reaction" binding:"required"`
+}
+
+type (
+	CreateTweetReactionReq    TweetReactionReq
+	DeleteTweetReactionReq    TweetReactionReq
+	CreateTweetReactionResp   ReactionInfo
+	DeleteTweetReactionResp   ReactionInfo
+	CreateCommentReactionReq  CommentReactionReq
+	DeleteCommentReactionReq  CommentReactionReq
+	CreateCommentReactionResp ReactionInfo
+	DeleteCommentReactionResp ReactionInfo
+)
+
 type DeleteTweetReq struct {
 	BaseInfo `json:"-" binding:"-"`
 	ID       int64 `json:"id" binding:"required"`
@@ -79,6 +251,35 @@ type StarTweetResp struct {
 type CollectionTweetReq struct {
 	SimpleInfo `json:"-" binding:"-"`
 	ID         int64 `json:"id" binding:"required"`
+	FolderID   int64 `json:"folder_id"`
+}
+
+type MoveCollectionTweetReq struct {
+	SimpleInfo `json:"-" binding:"-"`
+	ID         int64 `json:"id" binding:"required"`
+	FolderID   int64 `json:"folder_id" binding:"required"`
+}
+
+type CreateCollectionFolderReq struct {
+	SimpleInfo `json:"-" binding:"-"`
+	Name       string `json:"name" binding:"required"`
+	IsPublic   bool   `json:"is_public"`
+}
+
+type CreateCollectionFolderResp ms.PostCollectionFolderFormated
+
+type UpdateCollectionFolderReq struct {
+	SimpleInfo `json:"-" binding:"-"`
+	ID         int64  `json:"id" binding:"required"`
+	Name       string `json:"name" binding:"required"`
+	IsPublic   bool   `json:"is_public"`
+}
+
+type UpdateCollectionFolderResp ms.PostCollectionFolderFormated
+
+type DeleteCollectionFolderReq struct {
+	SimpleInfo `json:"-" binding:"-"`
+	ID         int64 `json:"id" binding:"required"`
 }
 
 type CollectionTweetResp struct {
@@ -99,6 +300,11 @@ type StickTweetReq struct {
 	ID       int64 `json:"id" binding:"required"`
 }
 
+type PinTweetReq struct {
+	BaseInfo `json:"-" binding:"-"`
+	ID       int64 `json:"id" binding:"required"`
+}
+
 type HighlightTweetReq struct {
 	BaseInfo `json:"-" binding:"-"`
 	ID       int64 `json:"id" binding:"required"`
@@ -108,6 +314,10 @@ type StickTweetResp struct {
 	StickStatus int `json:"top_status"`
 }
 
+type PinTweetResp struct {
+	PinStatus int `json:"pin_status"`
+}
+
 type HighlightTweetResp struct {
 	HighlightStatus int `json:"highlight_status"`
 }
@@ -116,6 +326,7 @@ type VisibleTweetReq struct {
 	BaseInfo   `json:"-" binding:"-"`
 	ID         int64            `json:"id"`
 	Visibility TweetVisibleType `json:"visibility"`
+	GroupIds   []int64          `json:"group_ids"`
 }
 
 type VisibleTweetResp struct {
@@ -142,6 +353,23 @@ type CreateCommentReplyReq struct {
 
 type CreateCommentReplyResp ms.CommentReply
 
+type EditCommentReq struct {
+	BaseInfo `json:"-" binding:"-"`
+	ID       int64              `json:"id" binding:"required"`
+	Contents []*PostContentItem `json:"contents" binding:"required"`
+	Users    []string           `json:"users" binding:"required"`
+}
+
+type EditCommentResp ms.CommentFormated
+
+type EditCommentReplyReq struct {
+	BaseInfo `json:"-" binding:"-"`
+	ID       int64  `json:"id" binding:"required"`
+	Content  string `json:"content" binding:"required"`
+}
+
+type EditCommentReplyResp ms.CommentReply
+
 type DeleteCommentReq struct {
 	BaseInfo `json:"-" binding:"-"`
 	ID       int64 `json:"id" binding:"required"`
@@ -233,6 +461,12 @@ func (p *PostContentItem) Check(acs core.AttachmentCheckService) error {
 			return err
 		}
 	}
+	// 检查投票是否合法
+	if p.Type == ms.ContentTypePoll {
+		if _, err := p.ParsePoll(); err != nil {
+			return err
+		}
+	}
 	// 检查链接是否合法
 	if p.Type == ms.ContentTypeLink {
 		if strings.Index(p.Content, "http://") != 0 && strings.Index(p.Content, "https://") != 0 {
@@ -242,6 +476,32 @@ func (p *PostContentItem) Check(acs core.AttachmentCheckService) error {
 	return nil
 }
 
+// ParsePoll 解析并校验投票内容项，选项2~6个且不能重复
+func (p *PostContentItem) ParsePoll() (*PollSpec, error) {
+	spec := &PollSpec{}
+	if err := json.Unmarshal([]byte(p.Content), spec); err != nil {
+		return nil, fmt.Errorf("投票内容不合法")
+	}
+	if len(spec.Options) < 2 || len(spec.Options) > 6 {
+		return nil, fmt.Errorf("投票选项数量不合法")
+	}
+	options := make(map[string]struct{}, len(spec.Options))
+	for i, option := range spec.Options {
+		option = strings.TrimSpace(option)
+		if size := utf8.RuneCountInString(option); size == 0 || size > 64 {
+			return nil, fmt.Errorf("投票选项长度不合法")
+		}
+		if _, exist := options[option]; exist {
+			return nil, fmt.Errorf("投票选项不能重复")
+		}
+		options[option], spec.Options[i] = struct{}{}, option
+	}
+	if spec.ExpiresAt != 0 && spec.ExpiresAt <= time.Now().Unix() {
+		return nil, fmt.Errorf("投票截止时间不合法")
+	}
+	return spec, nil
+}
+
 func (r *UploadAttachmentReq) Bind(c *gin.Context) (xerr error) {
 	userId, exist := base.UserIdFrom(c)
 	if !exist {
@@ -299,6 +559,27 @@ func (r *DownloadAttachmentReq) Bind(c *gin.Context) error {
 	return nil
 }
 
+func (r *ScheduledTweetsReq) Bind(c *gin.Context) error {
+	return (*BasePageReq)(r).Bind(c)
+}
+
+func (r *ArchivedTweetsReq) Bind(c *gin.Context) error {
+	return (*BasePageReq)(r).Bind(c)
+}
+
+func (r *DraftsReq) Bind(c *gin.Context) error {
+	return (*BasePageReq)(r).Bind(c)
+}
+
+func (r *TrashTweetsReq) Bind(c *gin.Context) error {
+	return (*BasePageReq)(r).Bind(c)
+}
+
+func (r *PublishDraftReq) Bind(c *gin.Context) error {
+	r.ClientIP = c.ClientIP()
+	return bindAny(c, r)
+}
+
 func (r *CreateTweetReq) Bind(c *gin.Context) error {
 	r.ClientIP = c.ClientIP()
 	return bindAny(c, r)
@@ -327,9 +608,35 @@ func (r *CreateTweetResp) Render(c *gin.Context) {
 	})
 }
 
+func (r *EditTweetResp) Render(c *gin.Context) {
+	c.JSON(http.StatusOK, &joint.JsonResp{
+		Code: 0,
+		Msg:  "success",
+		Data: r,
+	})
+	// 设置审核元信息，用于接下来的审核逻辑
+	c.Set(AuditHookCtxKey, &AuditMetaInfo{
+		Style: AuditStyleUserTweet,
+		Id:    r.ID,
+	})
+}
+
+func (r *PublishDraftResp) Render(c *gin.Context) {
+	c.JSON(http.StatusOK, &joint.JsonResp{
+		Code: 0,
+		Msg:  "success",
+		Data: r,
+	})
+	// 设置审核元信息，用于接下来的审核逻辑
+	c.Set(AuditHookCtxKey, &AuditMetaInfo{
+		Style: AuditStyleUserTweet,
+		Id:    r.ID,
+	})
+}
+
 func (t TweetVisibleType) ToVisibleValue() (res cs.TweetVisibleType) {
-	// 原来的可见性: 0公开 1私密 2好友可见 3关注可见
-	//  现在的可见性: 0私密 10充电可见 20订阅可见 30保留 40保留 50好友可见 60关注可见 70保留 80保留 90公开
+	// 原来的可见性: 0公开 1私密 2好友可见 3关注可见 4充电可见 5订阅可见
+	//  现在的可见性: 0私密 10充电可见 20订阅可见 30分组可见 40保留 50好友可见 60关注可见 70保留 80保留 90公开
 	switch t {
 	case TweetVisitPublic:
 		res = cs.TweetVisitPublic
@@ -339,6 +646,12 @@ func (t TweetVisibleType) ToVisibleValue() (res cs.TweetVisibleType) {
 		res = cs.TweetVisitFriend
 	case TweetVisitFollowing:
 		res = cs.TweetVisitFollowing
+	case TweetVisitCharge:
+		res = cs.TweetVisitCharge
+	case TweetVisitSubscription:
+		res = cs.TweetVisitSubscription
+	case TweetVisitGroup:
+		res = cs.TweetVisitGroup
 	default:
 		// TODO: 默认私密
 		res = cs.TweetVisitPrivate
@@ -4,6 +4,10 @@
 
 package web
 
+import (
+	"github.com/gin-gonic/gin"
+)
+
 type GetCaptchaResp struct {
 	Id      string `json:"id"`
 	Content string `json:"b64s"`
@@ -16,12 +20,69 @@ type SendCaptchaReq struct {
 }
 
 type LoginReq struct {
-	Username string `json:"username" form:"username" binding:"required"`
-	Password string `json:"password" form:"password" binding:"required"`
+	Username  string `json:"username" form:"username" binding:"required"`
+	Password  string `json:"password" form:"password" binding:"required"`
+	Device    string `json:"device" form:"device"`
+	ClientIP  string `json:"-" binding:"-"`
+	UserAgent string `json:"-" binding:"-"`
 }
 
+// 登录时需要的二次验证，verify为校验验证码，enroll为必须先开启二次验证
+const (
+	TwoFactorVerify = "verify"
+	TwoFactorEnroll = "enroll"
+)
+
 type LoginResp struct {
-	Token string `json:"token"`
+	Token          string `json:"token,omitempty"`
+	RefreshToken   string `json:"refresh_token,omitempty"`
+	ExpiresIn      int64  `json:"expires_in,omitempty"`
+	TwoFactor      string `json:"two_factor,omitempty"`
+	ChallengeToken string `json:"challenge_token,omitempty"`
+}
+
+type RefreshTokenReq struct {
+	RefreshToken string `json:"refresh_token" form:"refresh_token" binding:"required"`
+	ClientIP     string `json:"-" binding:"-"`
+}
+
+type RefreshTokenResp LoginResp
+
+type TwoFactorSetupReq struct {
+	ChallengeToken string `json:"challenge_token" form:"challenge_token" binding:"required"`
+}
+
+type TwoFactorSetupResp struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type TwoFactorLoginReq struct {
+	ChallengeToken string `json:"challenge_token" form:"challenge_token" binding:"required"`
+	Code           string `json:"code" form:"code" binding:"required"`
+	Device         string `json:"device" form:"device"`
+	ClientIP       string `json:"-" binding:"-"`
+	UserAgent      string `json:"-" binding:"-"`
+}
+
+type TwoFactorLoginResp struct {
+	LoginResp
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+}
+
+type ForgotPasswordReq struct {
+	Username     string `json:"username" form:"username" binding:"required"`
+	ImgCaptcha   string `json:"img_captcha" form:"img_captcha" binding:"required"`
+	ImgCaptchaID string `json:"img_captcha_id" form:"img_captcha_id" binding:"required"`
+}
+
+// ForgotPasswordResp 无论账户是否存在都返回相同的结果，不包含手机号信息
+type ForgotPasswordResp struct{}
+
+type ResetPasswordReq struct {
+	Username string `json:"username" form:"username" binding:"required"`
+	Captcha  string `json:"captcha" form:"captcha" binding:"required"`
+	Password string `json:"password" form:"password" binding:"required"`
 }
 
 type RegisterReq struct {
@@ -33,3 +94,18 @@ type RegisterResp struct {
 	UserId   int64  `json:"id"`
 	Username string `json:"username"`
 }
+
+func (r *LoginReq) Bind(c *gin.Context) error {
+	r.ClientIP, r.UserAgent = c.ClientIP(), c.GetHeader("User-Agent")
+	return bindAny(c, r)
+}
+
+func (r *TwoFactorLoginReq) Bind(c *gin.Context) error {
+	r.ClientIP, r.UserAgent = c.ClientIP(), c.GetHeader("User-Agent")
+	return bindAny(c, r)
+}
+
+func (r *RefreshTokenReq) Bind(c *gin.Context) error {
+	r.ClientIP = c.ClientIP()
+	return bindAny(c, r)
+}
@@ -25,6 +25,11 @@ type SimpleInfo struct {
 	Uid int64
 }
 
+// SessionInfo 当前访问令牌所属的登录会话
+type SessionInfo struct {
+	SessionId int64
+}
+
 type BasePageReq struct {
 	UserId   int64
 	Page     int
@@ -39,6 +44,10 @@ func (s *SimpleInfo) SetUserId(id int64) {
 	s.Uid = id
 }
 
+func (s *SessionInfo) SetSessionId(id int64) {
+	s.SessionId = id
+}
+
 func BasePageReqFrom(c *gin.Context) (*BasePageReq, mir.Error) {
 	uid, ok := base.UserIdFrom(c)
 	if !ok {
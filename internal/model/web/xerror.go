@@ -33,6 +33,20 @@ var (
 	ErrNoExistUsername         = xerror.NewError(20021, "用户不存在")
 	ErrNoAdminPermission       = xerror.NewError(20022, "无管理权限")
 	ErrDisallowUserRegister    = xerror.NewError(20023, "系统不允许注册用户")
+	ErrChangeSensitiveFailed   = xerror.NewError(20024, "敏感内容展示设置失败")
+	ErrInsufficientBalance     = xerror.NewError(20025, "账户余额不足")
+	ErrInvalidChargeAmount     = xerror.NewError(20026, "充电金额低于最低限额")
+	ErrSponsorSelf             = xerror.NewError(20027, "不能为自己充电或订阅")
+	ErrSponsorFailed           = xerror.NewError(20028, "充电或订阅失败")
+	ErrGetSessionsFailed       = xerror.NewError(20029, "获取登录设备列表失败")
+	ErrNoExistSession          = xerror.NewError(20030, "登录会话不存在")
+	ErrRevokeSessionFailed     = xerror.NewError(20031, "下线登录设备失败")
+	ErrDisallowResetPassword   = xerror.NewError(20032, "系统未开启短信验证，无法找回密码")
+	ErrResetPasswordFailed     = xerror.NewError(20033, "重置密码失败")
+	ErrTwoFactorRequired       = xerror.NewError(20034, "管理员账户必须开启二次验证")
+	ErrTwoFactorChallenge      = xerror.NewError(20035, "二次验证已失效，请重新登录")
+	ErrTwoFactorFailed         = xerror.NewError(20036, "二次验证设置失败")
+	ErrTooManyResetPwdError    = xerror.NewError(20037, "重置密码失败次数过多，请稍后再试")
 
 	ErrGetPostsFailed          = xerror.NewError(30001, "获取动态列表失败")
 	ErrCreatePostFailed        = xerror.NewError(30002, "动态发布失败")
@@ -49,6 +63,40 @@ var (
 	ErrHighlightPostFailed     = xerror.NewError(30013, "动态设为亮点失败")
 	ErrGetPostsUnknowStyle     = xerror.NewError(30014, "使用未知样式参数获取动态列表")
 	ErrGetPostsNilUser         = xerror.NewError(30015, "使用游客账户获取动态详情失败")
+	ErrEditPostFailed          = xerror.NewError(30016, "动态编辑失败")
+	ErrGetPostRevisionsFailed  = xerror.NewError(30017, "获取动态编辑历史失败")
+	ErrInvalidThreadParent     = xerror.NewError(30018, "只能在自己的动态下续写串推")
+	ErrGetPostThreadFailed     = xerror.NewError(30019, "获取串推失败")
+	ErrInvalidPublishAt        = xerror.NewError(30020, "定时发布时间不合法")
+	ErrNotScheduledPost        = xerror.NewError(30021, "该动态不是待发布的定时动态")
+	ErrGetScheduledPostsFailed = xerror.NewError(30022, "获取定时动态列表失败")
+	ErrScheduleTweetFailed     = xerror.NewError(30023, "修改定时动态失败")
+	ErrCreateDraftFailed       = xerror.NewError(30024, "保存草稿失败")
+	ErrGetDraftsFailed         = xerror.NewError(30025, "获取草稿列表失败")
+	ErrGetDraftFailed          = xerror.NewError(30026, "草稿不存在")
+	ErrUpdateDraftFailed       = xerror.NewError(30027, "更新草稿失败")
+	ErrDeleteDraftFailed       = xerror.NewError(30028, "删除草稿失败")
+	ErrPublishDraftFailed      = xerror.NewError(30029, "发布草稿失败")
+	ErrInvalidPoll             = xerror.NewError(30030, "投票内容不合法")
+	ErrGetPollFailed           = xerror.NewError(30031, "投票不存在")
+	ErrPollClosed              = xerror.NewError(30032, "投票已结束")
+	ErrPollAlreadyVoted        = xerror.NewError(30033, "您已经投过票了")
+	ErrInvalidPollOptions      = xerror.NewError(30034, "投票选项不合法")
+	ErrVotePollFailed          = xerror.NewError(30035, "投票失败")
+	ErrInvalidReaction         = xerror.NewError(30036, "不支持的表情回应")
+	ErrReactionFailed          = xerror.NewError(30037, "表情回应失败")
+	ErrPinPostFailed           = xerror.NewError(30038, "动态主页置顶失败")
+	ErrMaxPinnedPosts          = xerror.NewError(30039, "主页置顶动态数已达上限")
+	ErrInvalidContentWarning   = xerror.NewError(30040, "内容警告不能超过255个字符")
+	ErrSensitivePostFailed     = xerror.NewError(30041, "设置动态敏感标记失败")
+	ErrNotInTrash              = xerror.NewError(30042, "动态不在回收站中")
+	ErrRestorePostFailed       = xerror.NewError(30043, "恢复动态失败")
+	ErrGetTrashFailed          = xerror.NewError(30044, "获取回收站动态失败")
+	ErrNotSponsor              = xerror.NewError(30045, "为作者充电或订阅后才能查看完整内容")
+	ErrInvalidAudienceGroups   = xerror.NewError(30046, "分组可见需要选择自己创建的分组")
+	ErrInvalidExpiresIn        = xerror.NewError(30047, "限时动态的有效时长不合法")
+	ErrGetArchivedPostsFailed  = xerror.NewError(30048, "获取限时动态归档失败")
+	ErrInvalidReplyPolicy      = xerror.NewError(30049, "动态的回复权限设置不合法")
 
 	ErrGetCommentsFailed      = xerror.NewError(40001, "获取评论列表失败")
 	ErrCreateCommentFailed    = xerror.NewError(40002, "评论发布失败")
@@ -59,15 +107,33 @@ var (
 	ErrMaxCommentCount        = xerror.NewError(40007, "评论数已达最大限制")
 	ErrGetCommentThumbs       = xerror.NewError(40008, "获取评论点赞信息失败")
 	ErrHighlightCommentFailed = xerror.NewError(40009, "设置精选评论失败")
+	ErrGetRepliesFailed       = xerror.NewError(40010, "获取评论回复失败")
+	ErrEditCommentFailed      = xerror.NewError(40011, "评论编辑失败")
+	ErrEditReplyFailed        = xerror.NewError(40012, "回复编辑失败")
+	ErrCommentEditExpired     = xerror.NewError(40013, "已超过可编辑的时限")
+	ErrGetRevisionsFailed     = xerror.NewError(40014, "获取评论编辑历史失败")
+	ErrReplyNotAllowed        = xerror.NewError(40015, "作者限制了可以回复此动态的用户")
+	ErrEmptyCommentContent    = xerror.NewError(40016, "评论内容不能为空")
+	ErrCommentContentTooLong  = xerror.NewError(40017, "评论内容不能超过300个字符")
 
 	ErrGetMessagesFailed = xerror.NewError(50001, "获取消息列表失败")
 	ErrReadMessageFailed = xerror.NewError(50002, "标记消息已读失败")
 	ErrSendWhisperFailed = xerror.NewError(50003, "私信发送失败")
 	ErrNoWhisperToSelf   = xerror.NewError(50004, "不允许给自己发送私信")
 	ErrTooManyWhisperNum = xerror.NewError(50005, "今日私信次数已达上限")
+	ErrGetMentionsFailed = xerror.NewError(50006, "获取提及列表失败")
 
 	ErrGetCollectionsFailed = xerror.NewError(60001, "获取收藏列表失败")
 	ErrGetStarsFailed       = xerror.NewError(60002, "获取点赞列表失败")
+	ErrGetFoldersFailed     = xerror.NewError(60003, "获取收藏夹列表失败")
+	ErrGetFolderFailed      = xerror.NewError(60004, "收藏夹不存在")
+	ErrInvalidFolderName    = xerror.NewError(60005, "收藏夹名称不合法")
+	ErrCreateFolderFailed   = xerror.NewError(60006, "创建收藏夹失败")
+	ErrUpdateFolderFailed   = xerror.NewError(60007, "更新收藏夹失败")
+	ErrDeleteFolderFailed   = xerror.NewError(60008, "删除收藏夹失败")
+	ErrDeleteDefaultFolder  = xerror.NewError(60009, "默认收藏夹不能删除")
+	ErrMoveCollectionFailed = xerror.NewError(60010, "移动收藏失败")
+	ErrFolderTweetsFailed   = xerror.NewError(60011, "获取收藏夹动态失败")
 
 	ErrRechargeReqFail       = xerror.NewError(70001, "充值请求失败")
 	ErrRechargeNotifyError   = xerror.NewError(70002, "充值回调失败")
@@ -82,6 +148,14 @@ var (
 	ErrDeleteFriendFailed         = xerror.NewError(80006, "删除好友失败")
 	ErrGetContactsFailed          = xerror.NewError(80007, "获取联系人列表失败")
 	ErrNoActionToSelf             = xerror.NewError(80008, "不允许对自己操作")
+	ErrGetContactGroupsFailed     = xerror.NewError(80009, "获取联系人分组失败")
+	ErrCreateContactGroupFailed   = xerror.NewError(80010, "创建联系人分组失败")
+	ErrContactGroupsExceed        = xerror.NewError(80011, "联系人分组数已达上限")
+	ErrInvalidContactGroupName    = xerror.NewError(80012, "分组名称不合法")
+	ErrNotExistContactGroup       = xerror.NewError(80013, "联系人分组不存在")
+	ErrUpdateContactGroupFailed   = xerror.NewError(80014, "修改联系人分组失败")
+	ErrDeleteContactGroupFailed   = xerror.NewError(80015, "删除联系人分组失败")
+	ErrSetContactGroupFailed      = xerror.NewError(80016, "设置好友分组失败")
 	ErrFolloUserFailed            = xerror.NewError(80100, "关注失败")
 	ErrUnfollowUserFailed         = xerror.NewError(80101, "取消关注失败")
 	ErrListFollowsFailed          = xerror.NewError(80102, "获取关注列表失败")
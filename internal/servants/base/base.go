@@ -38,6 +38,7 @@ type DaoServant struct {
 
 	Dsa   core.WebDataServantA
 	Ds    core.DataService
+	Ams   core.AuthorizationManageService
 	Ts    core.TweetSearchService
 	Redis core.RedisCache
 }
@@ -54,6 +55,10 @@ type UserIdSetter interface {
 	SetUserId(int64)
 }
 
+type SessionIdSetter interface {
+	SetSessionId(int64)
+}
+
 type PageInfoSetter interface {
 	SetPageInfo(page, pageSize int)
 }
@@ -82,6 +87,15 @@ func UserNameFrom(c *gin.Context) (string, bool) {
 	return "", false
 }
 
+// SessionIdFrom 当前访问令牌所属的登录会话，旧令牌没有会话信息
+func SessionIdFrom(c *gin.Context) (int64, bool) {
+	if sid, exists := c.Get("SID"); exists {
+		v, ok := sid.(int64)
+		return v, ok
+	}
+	return 0, false
+}
+
 func bindAny(c *gin.Context, obj any) error {
 	var errs xerror.ValidErrors
 	err := c.ShouldBind(obj)
@@ -98,6 +112,11 @@ func bindAny(c *gin.Context, obj any) error {
 		uid, _ := UserIdFrom(c)
 		setter.SetUserId(uid)
 	}
+	// setup SessionId if needed
+	if setter, ok := obj.(SessionIdSetter); ok {
+		sid, _ := SessionIdFrom(c)
+		setter.SetSessionId(sid)
+	}
 	// setup PageInfo if needed
 	if setter, ok := obj.(PageInfoSetter); ok {
 		page, pageSize := app.GetPageInfo(c)
@@ -131,6 +150,11 @@ func bindAnySentry(c *gin.Context, obj any) error {
 		uid, _ := UserIdFrom(c)
 		setter.SetUserId(uid)
 	}
+	// setup SessionId if needed
+	if setter, ok := obj.(SessionIdSetter); ok {
+		sid, _ := SessionIdFrom(c)
+		setter.SetSessionId(sid)
+	}
 	// setup PageInfo if needed
 	if setter, ok := obj.(PageInfoSetter); ok {
 		page, pageSize := app.GetPageInfo(c)
@@ -234,6 +258,26 @@ func (s *DaoServant) PrepareMessages(userId int64, messages []*ms.MessageFormate
 }
 
 func (s *DaoServant) PrepareTweet(user *ms.User, tweet *ms.PostFormated) error {
+	userId, isAdmin := int64(-1), false
+	if user != nil {
+		userId, isAdmin = user.ID, user.IsAdmin
+	}
+	if err := s.prepareLocks(userId, isAdmin, []*ms.PostFormated{tweet}); err != nil {
+		return err
+	}
+	if err := s.prepareReposts(userId, isAdmin, []*ms.PostFormated{tweet}); err != nil {
+		return err
+	}
+	if err := s.PreparePolls(userId, []*ms.PostFormated{tweet}); err != nil {
+		return err
+	}
+	if err := s.prepareReactions(userId, []*ms.PostFormated{tweet}); err != nil {
+		return err
+	}
+	s.prepareSensitive(user, []*ms.PostFormated{tweet})
+	if err := s.prepareLinkPreviews([]*ms.PostFormated{tweet}); err != nil {
+		return err
+	}
 	// guest用户
 	if user == nil {
 		return nil
@@ -253,6 +297,30 @@ func (s *DaoServant) PrepareTweet(user *ms.User, tweet *ms.PostFormated) error {
 }
 
 func (s *DaoServant) PrepareTweets(userId int64, tweets []*ms.PostFormated) error {
+	var user *ms.User
+	if userId > 0 {
+		var err error
+		if user, err = s.Ds.GetUserByID(userId); err != nil {
+			return err
+		}
+	}
+	isAdmin := user != nil && user.IsAdmin
+	if err := s.prepareLocks(userId, isAdmin, tweets); err != nil {
+		return err
+	}
+	if err := s.prepareReposts(userId, isAdmin, tweets); err != nil {
+		return err
+	}
+	if err := s.PreparePolls(userId, tweets); err != nil {
+		return err
+	}
+	if err := s.prepareReactions(userId, tweets); err != nil {
+		return err
+	}
+	s.prepareSensitive(user, tweets)
+	if err := s.prepareLinkPreviews(tweets); err != nil {
+		return err
+	}
 	userIdSet := make(map[int64]types.Empty, len(tweets))
 	for _, tweet := range tweets {
 		userIdSet[tweet.UserID] = types.Empty{}
@@ -282,6 +350,228 @@ func (s *DaoServant) PrepareTweets(userId int64, tweets []*ms.PostFormated) erro
 	return nil
 }
 
+// PreparePolls 按访问者处理推文中的投票，未投票且投票未结束时隐藏计票，guest用户的userId<0
+func (s *DaoServant) PreparePolls(userId int64, tweets []*ms.PostFormated) error {
+	var polls []*ms.PostPollFormated
+	for _, tweet := range tweets {
+		if tweet.Poll != nil {
+			polls = append(polls, tweet.Poll)
+		}
+		if tweet.Repost != nil && tweet.Repost.Poll != nil {
+			polls = append(polls, tweet.Repost.Poll)
+		}
+	}
+	if len(polls) == 0 {
+		return nil
+	}
+	votedMap := make(map[int64][]int64, len(polls))
+	if userId > 0 {
+		pollIds := make([]int64, 0, len(polls))
+		for _, poll := range polls {
+			pollIds = append(pollIds, poll.ID)
+		}
+		votes, err := s.Ds.ListUserPollVotes(userId, pollIds)
+		if err != nil {
+			return err
+		}
+		for _, vote := range votes {
+			votedMap[vote.PollID] = append(votedMap[vote.PollID], vote.OptionID)
+		}
+	}
+	for _, poll := range polls {
+		if optionIds, exist := votedMap[poll.ID]; exist {
+			poll.Voted, poll.VotedOptions = true, optionIds
+		} else if !poll.IsClosed {
+			poll.HideResults()
+		}
+	}
+	return nil
+}
+
+// prepareReactions 填充访问者自己对推文的表情回应，guest用户的userId<0
+func (s *DaoServant) prepareReactions(userId int64, tweets []*ms.PostFormated) error {
+	if userId < 0 || len(tweets) == 0 {
+		return nil
+	}
+	tweetMap := make(map[int64][]*ms.PostFormated, len(tweets))
+	for _, tweet := range tweets {
+		tweetMap[tweet.ID] = append(tweetMap[tweet.ID], tweet)
+		if tweet.Repost != nil {
+			tweetMap[tweet.Repost.ID] = append(tweetMap[tweet.Repost.ID], tweet.Repost)
+		}
+	}
+	postIds := make([]int64, 0, len(tweetMap))
+	for id := range tweetMap {
+		postIds = append(postIds, id)
+	}
+	reactions, err := s.Ds.ListUserPostReactions(userId, postIds)
+	if err != nil {
+		return err
+	}
+	for _, reaction := range reactions {
+		for _, tweet := range tweetMap[reaction.PostID] {
+			tweet.MyReactions = append(tweet.MyReactions, reaction.Reaction)
+		}
+	}
+	return nil
+}
+
+// prepareLocks 付费可见的推文对未充电或订阅作者的访问者只展示预览，guest用户的userId<0
+func (s *DaoServant) prepareLocks(userId int64, isAdmin bool, tweets []*ms.PostFormated) error {
+	if isAdmin {
+		return nil
+	}
+	var locks []*ms.PostFormated
+	var creatorIds []int64
+	collect := func(tweet *ms.PostFormated) {
+		if tweet.Visibility.IsPaid() && tweet.UserID != userId {
+			locks = append(locks, tweet)
+			creatorIds = append(creatorIds, tweet.UserID)
+		}
+	}
+	for _, tweet := range tweets {
+		collect(tweet)
+		if tweet.Repost != nil {
+			collect(tweet.Repost)
+		}
+	}
+	if len(locks) == 0 {
+		return nil
+	}
+	filter := ms.SponsorFilter{}
+	if userId > 0 {
+		var err error
+		if filter, err = s.Ds.GetSponsorFilter(userId, creatorIds...); err != nil {
+			return err
+		}
+	}
+	for _, tweet := range locks {
+		if !filter.IsAllow(tweet.UserID, tweet.Visibility) {
+			tweet.Lock(conf.AppSetting.TeaserLength)
+		}
+	}
+	return nil
+}
+
+// prepareSensitive 按访问者的敏感内容偏好折叠敏感推文，guest用户默认折叠，作者本人不折叠
+func (s *DaoServant) prepareSensitive(user *ms.User, tweets []*ms.PostFormated) {
+	if user != nil && user.ShowSensitive {
+		return
+	}
+	collapse := func(tweet *ms.PostFormated) {
+		if user != nil && user.ID == tweet.UserID {
+			return
+		}
+		tweet.Collapsed = tweet.IsSensitive
+		for _, content := range tweet.Contents {
+			tweet.Collapsed = tweet.Collapsed || content.IsSensitive
+		}
+	}
+	for _, tweet := range tweets {
+		collapse(tweet)
+		if tweet.Repost != nil {
+			collapse(tweet.Repost)
+		}
+	}
+}
+
+// prepareLinkPreviews 为推文中的链接内容填充已抓取的链接预览
+func (s *DaoServant) prepareLinkPreviews(tweets []*ms.PostFormated) error {
+	var links []*ms.PostContentFormated
+	urlSet := make(map[string]types.Empty)
+	collect := func(tweet *ms.PostFormated) {
+		for _, content := range tweet.Contents {
+			if content.Type == ms.ContentTypeLink {
+				links = append(links, content)
+				urlSet[content.Content] = types.Empty{}
+			}
+		}
+	}
+	for _, tweet := range tweets {
+		collect(tweet)
+		if tweet.Repost != nil {
+			collect(tweet.Repost)
+		}
+	}
+	if len(links) == 0 {
+		return nil
+	}
+	urls := make([]string, 0, len(urlSet))
+	for url := range urlSet {
+		urls = append(urls, url)
+	}
+	previews, err := s.Ds.GetLinkPreviews(urls)
+	if err != nil {
+		return err
+	}
+	for _, link := range links {
+		if preview, exist := previews[link.Content]; exist {
+			link.Preview = preview.Format()
+		}
+	}
+	return nil
+}
+
+// prepareReposts 按访问者检查转发动态中原动态的可见性，不可见时隐藏原动态，guest用户的userId<0
+func (s *DaoServant) prepareReposts(userId int64, isAdmin bool, tweets []*ms.PostFormated) error {
+	var friendIds, followIds []int64
+	audienceMap := map[int64]bool{}
+	for _, tweet := range tweets {
+		if original := tweet.Repost; original != nil && userId > 0 && original.UserID != userId {
+			switch original.Visibility {
+			case core.PostVisitFriend:
+				friendIds = append(friendIds, original.UserID)
+			case core.PostVisitFollowing:
+				followIds = append(followIds, original.UserID)
+			case core.PostVisitGroup:
+				audienceMap[original.ID] = s.Ds.IsPostAudience(original.ID, userId)
+			}
+		}
+	}
+	friendMap, followMap := map[int64]bool{}, map[int64]bool{}
+	var err error
+	if len(friendIds) > 0 {
+		if friendMap, err = s.Ds.IsMyFriend(userId, friendIds...); err != nil {
+			return err
+		}
+	}
+	if len(followIds) > 0 {
+		if followMap, err = s.Ds.IsMyFollow(userId, followIds...); err != nil {
+			return err
+		}
+	}
+	for _, tweet := range tweets {
+		original := tweet.Repost
+		if original == nil {
+			continue
+		}
+		visible := false
+		switch {
+		case isAdmin, original.Visibility == core.PostVisitPublic:
+			visible = true
+		case original.Visibility.IsPaid():
+			// 付费可见的原动态已按访问者处理为预览
+			visible = true
+		case userId < 0:
+			visible = false
+		case original.UserID == userId:
+			visible = true
+		case original.Visibility == core.PostVisitFriend:
+			visible = friendMap[original.UserID]
+		case original.Visibility == core.PostVisitFollowing:
+			visible = followMap[original.UserID]
+		case original.Visibility == core.PostVisitGroup:
+			visible = audienceMap[original.ID]
+		}
+		if !visible {
+			tweet.Repost = nil
+			continue
+		}
+		original.Visibility = ms.PostVisibleT(original.Visibility.ToOutValue())
+	}
+	return nil
+}
+
 func (s *DaoServant) GetTweetBy(id int64) (*ms.PostFormated, error) {
 	post, err := s.Ds.GetPostByID(id)
 	if err != nil {
@@ -356,6 +646,10 @@ func (s *DaoServant) pushAllPostToSearch() error {
 }
 
 func (s *DaoServant) PushPostToSearch(post *ms.Post) {
+	// 未发布的定时推文不进入搜索索引
+	if post.PublishAt > 0 {
+		return
+	}
 	events.OnEvent(&pushPostToSearchEvent{
 		fn:   s.pushPostToSearch,
 		post: post,
@@ -395,7 +689,7 @@ func (s *DaoServant) RelationTypFrom(me *ms.User, username string) (res *cs.Vist
 	}
 	// visit by self
 	if me != nil && me.Username == username {
-		res.UserId = me.ID
+		res.UserId, res.VisitorId = me.ID, me.ID
 		return
 	}
 	he, xerr := s.Ds.GetUserByUsername(username)
@@ -408,6 +702,7 @@ func (s *DaoServant) RelationTypFrom(me *ms.User, username string) (res *cs.Vist
 		res.RelTyp = cs.RelationGuest
 		return
 	}
+	res.VisitorId = me.ID
 	// visit by admin/friend/other
 	if me.IsAdmin {
 		res.RelTyp = cs.RelationAdmin
@@ -446,6 +741,7 @@ func NewDaoServant() *DaoServant {
 		Redis:       cache.NewRedisCache(),
 		Dsa:         dao.WebDataServantA(),
 		Ds:          dao.DataService(),
+		Ams:         dao.AuthorizationManageService(),
 		Ts:          dao.TweetSearchService(),
 	}
 }
@@ -14,14 +14,21 @@ import (
 
 var (
 	_ums     core.UserManageService
+	_ss      core.SessionService
 	_ac      core.AppCache
 	_onceUms sync.Once
 )
 
 func userManageService() core.UserManageService {
 	_onceUms.Do(func() {
-		_ums = dao.DataService()
+		ds := dao.DataService()
+		_ums, _ss = ds, ds
 		_ac = cache.NewAppCache()
 	})
 	return _ums
 }
+
+func sessionService() core.SessionService {
+	userManageService()
+	return _ss
+}
@@ -6,8 +6,11 @@ package chain
 
 import (
 	"github.com/alimy/tryst/event"
+	"github.com/rocboss/paopao-ce/internal/core"
+	"github.com/rocboss/paopao-ce/internal/core/ms"
 	"github.com/rocboss/paopao-ce/internal/infra/events"
 	"github.com/rocboss/paopao-ce/internal/model/web"
+	"github.com/rocboss/paopao-ce/pkg/utils"
 	"github.com/sirupsen/logrus"
 )
 
@@ -26,6 +29,35 @@ func (e *AuditHookEvent) Action() error {
 	return nil
 }
 
+type TouchSessionEvent struct {
+	event.UnimplementedEvent
+	ss      core.SessionService
+	session *ms.UserSession
+}
+
+func (e *TouchSessionEvent) Name() string {
+	return "TouchSessionEvent"
+}
+
+func (e *TouchSessionEvent) Action() error {
+	if e.session.IPLoc == "" {
+		e.session.IPLoc = utils.GetIPLoc(e.session.IP)
+	}
+	if err := e.ss.TouchSession(e.session); err != nil {
+		logrus.Errorf("TouchSessionEvent action session[%d] err: %s", e.session.ID, err)
+		return err
+	}
+	return nil
+}
+
+// OnTouchSessionEvent 异步更新登录会话的最近访问时间与IP
+func OnTouchSessionEvent(ss core.SessionService, session *ms.UserSession) {
+	events.OnEvent(&TouchSessionEvent{
+		ss:      ss,
+		session: session,
+	})
+}
+
 func OnAudiotHookEvent(ami *web.AuditMetaInfo) {
 	if ami != nil {
 		events.OnEvent(&AuditHookEvent{
@@ -7,15 +7,20 @@ package chain
 import (
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/rocboss/paopao-ce/internal/core"
 	"github.com/rocboss/paopao-ce/pkg/app"
 	"github.com/rocboss/paopao-ce/pkg/xerror"
 )
 
+// _sessionTouchInterval 登录会话最近访问时间的最小更新间隔，单位秒
+const _sessionTouchInterval = 300
+
 func JWT() gin.HandlerFunc {
-	ums := userManageService()
+	ums, ss := userManageService(), sessionService()
 	return func(c *gin.Context) {
 		var (
 			token string
@@ -39,8 +44,8 @@ func JWT() gin.HandlerFunc {
 			if claims, err := app.ParseToken(token); err == nil {
 				// 加载用户信息
 				if user, err := ums.GetUserByID(claims.UID); err == nil {
-					// 强制下线机制
-					if app.IssuerFrom(user.Salt) == claims.Issuer {
+					// 强制下线机制，已撤销的登录会话同样拒绝访问
+					if app.IssuerFrom(user.Salt) == claims.Issuer && validSession(c, ss, claims) {
 						c.Set("USER", user)
 						c.Set("UID", claims.UID)
 						c.Set("USERNAME", claims.Username)
@@ -71,6 +76,7 @@ func JWT() gin.HandlerFunc {
 }
 
 func JwtSurely() gin.HandlerFunc {
+	ss := sessionService()
 	return func(c *gin.Context) {
 		var (
 			token string
@@ -92,8 +98,13 @@ func JwtSurely() gin.HandlerFunc {
 		}
 		if token != "" {
 			if claims, err := app.ParseToken(token); err == nil {
-				c.Set("UID", claims.UID)
-				c.Set("USERNAME", claims.Username)
+				// 已撤销的登录会话同样拒绝访问
+				if validSession(c, ss, claims) {
+					c.Set("UID", claims.UID)
+					c.Set("USERNAME", claims.Username)
+				} else {
+					ecode = xerror.UnauthorizedTokenTimeout
+				}
 			} else {
 				if errors.Is(err, jwt.ErrTokenExpired) {
 					ecode = xerror.UnauthorizedTokenTimeout
@@ -115,7 +126,7 @@ func JwtSurely() gin.HandlerFunc {
 }
 
 func JwtLoose() gin.HandlerFunc {
-	ums := userManageService()
+	ums, ss := userManageService(), sessionService()
 	return func(c *gin.Context) {
 		token, exist := c.GetQuery("token")
 		if !exist {
@@ -132,7 +143,7 @@ func JwtLoose() gin.HandlerFunc {
 			if claims, err := app.ParseToken(token); err == nil {
 				// 加载用户信息
 				user, err := ums.GetUserByID(claims.UID)
-				if err == nil && app.IssuerFrom(user.Salt) == claims.Issuer {
+				if err == nil && app.IssuerFrom(user.Salt) == claims.Issuer && validSession(c, ss, claims) {
 					c.Set("UID", claims.UID)
 					c.Set("USERNAME", claims.Username)
 					c.Set("USER", user)
@@ -142,3 +153,25 @@ func JwtLoose() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// validSession 检查令牌所属的登录会话是否有效，未携带会话的旧令牌无法按设备撤销，不再接受
+func validSession(c *gin.Context, ss core.SessionService, claims *app.Claims) bool {
+	if claims.SID == 0 {
+		return false
+	}
+	session, err := ss.GetSession(claims.SID)
+	if err != nil || session.UserId != claims.UID {
+		return false
+	}
+	c.Set("SID", session.ID)
+	// 限制更新频率，避免每次请求都写库
+	now, ip := time.Now().Unix(), c.ClientIP()
+	if now-session.LastSeenOn >= _sessionTouchInterval || ip != session.IP {
+		if ip != session.IP {
+			session.IP, session.IPLoc = ip, ""
+		}
+		session.LastSeenOn = now
+		OnTouchSessionEvent(ss, session)
+	}
+	return true
+}
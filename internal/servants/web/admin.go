@@ -11,6 +11,8 @@ import (
 	api "github.com/rocboss/paopao-ce/auto/api/v1"
 	"github.com/rocboss/paopao-ce/internal/conf"
 	"github.com/rocboss/paopao-ce/internal/core"
+	"github.com/rocboss/paopao-ce/internal/core/ms"
+	"github.com/rocboss/paopao-ce/internal/dao/cache"
 	"github.com/rocboss/paopao-ce/internal/model/web"
 	"github.com/rocboss/paopao-ce/internal/servants/base"
 	"github.com/rocboss/paopao-ce/internal/servants/chain"
@@ -46,6 +48,55 @@ func (s *adminSrv) ChangeUserStatus(req *web.ChangeUserStatusReq) error {
 	return nil
 }
 
+func (s *adminSrv) ChangeTweetSensitive(req *web.ChangeTweetSensitiveReq) error {
+	post, err := s.Ds.GetPostByID(req.ID)
+	if err != nil {
+		logrus.Errorf("Ds.GetPostByID err: %s", err)
+		return web.ErrGetPostFailed
+	}
+	_, warning, ok := sensitiveFrom(req.IsSensitive, req.ContentWarning)
+	if !ok {
+		return web.ErrInvalidContentWarning
+	}
+	post.IsSensitive, post.ContentWarning = ms.PostSensitiveNone, ""
+	if req.IsSensitive {
+		post.IsSensitive, post.ContentWarning = ms.PostSensitiveByAdmin, warning
+	}
+	if err = s.Ds.UpdatePost(post); err != nil {
+		logrus.Errorf("Ds.UpdatePost err: %s", err)
+		return web.ErrSensitivePostFailed
+	}
+	// 推送Search
+	s.PushPostToSearch(post)
+	cache.OnExpireIndexTweetEvent(post.UserID)
+	return nil
+}
+
+func (s *adminSrv) CommentRevisions(req *web.CommentRevisionsReq) (*web.CommentRevisionsResp, error) {
+	revisions, err := s.Ds.ListCommentRevisions(req.CommentId, req.ReplyId)
+	if err != nil {
+		logrus.Errorf("Ds.ListCommentRevisions err: %s", err)
+		return nil, web.ErrGetRevisionsFailed
+	}
+	// 按版本号聚合，结果已按版本号倒序排列
+	resp := &web.CommentRevisionsResp{
+		Revisions: []*web.CommentRevision{},
+	}
+	var item *web.CommentRevision
+	for _, r := range revisions {
+		if item == nil || item.Revision != r.Revision {
+			item = &web.CommentRevision{
+				Revision:  r.Revision,
+				EditorId:  r.EditorID,
+				CreatedOn: r.CreatedOn,
+			}
+			resp.Revisions = append(resp.Revisions, item)
+		}
+		item.Contents = append(item.Contents, r.Format())
+	}
+	return resp, nil
+}
+
 func (s *adminSrv) SiteInfo(req *web.SiteInfoReq) (*web.SiteInfoResp, error) {
 	res, err := &web.SiteInfoResp{ServerUpTime: s.serverUpTime}, error(nil)
 	res.RegisterUserCount, err = s.Ds.GetRegisterUserCount()
@@ -72,3 +123,12 @@ func newAdminSrv(s *base.DaoServant, wc core.WebCache) api.Admin {
 		serverUpTime: time.Now().Unix(),
 	}
 }
+
+func (s *adminSrv) ListTrashTweets(req *web.ListTrashTweetsReq) (*web.ListTrashTweetsResp, error) {
+	items, total, err := trashTweetsFrom(s.Ds, 0, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	resp := base.PageRespFrom(items, req.Page, req.PageSize, total)
+	return (*web.ListTrashTweetsResp)(resp), nil
+}
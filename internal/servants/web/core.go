@@ -6,16 +6,18 @@ package web
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
-	"time"
 	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
 	api "github.com/rocboss/paopao-ce/auto/api/v1"
 	"github.com/rocboss/paopao-ce/internal/conf"
 	"github.com/rocboss/paopao-ce/internal/core"
+	"github.com/rocboss/paopao-ce/internal/core/cs"
 	"github.com/rocboss/paopao-ce/internal/core/ms"
+	"github.com/rocboss/paopao-ce/internal/dao/cache"
 	"github.com/rocboss/paopao-ce/internal/model/joint"
 	"github.com/rocboss/paopao-ce/internal/model/web"
 	"github.com/rocboss/paopao-ce/internal/servants/base"
@@ -67,17 +69,19 @@ func (s *coreSrv) GetUserInfo(req *web.UserInfoReq) (*web.UserInfoResp, error) {
 		return nil, web.ErrGetFollowCountFailed
 	}
 	resp := &web.UserInfoResp{
-		Id:          user.ID,
-		Nickname:    user.Nickname,
-		Username:    user.Username,
-		Status:      user.Status,
-		Avatar:      user.Avatar,
-		Balance:     user.Balance,
-		IsAdmin:     user.IsAdmin,
-		CreatedOn:   user.CreatedOn,
-		Follows:     follows,
-		Followings:  followings,
-		TweetsCount: user.TweetsCount,
+		Id:            user.ID,
+		Nickname:      user.Nickname,
+		Username:      user.Username,
+		Status:        user.Status,
+		Avatar:        user.Avatar,
+		Balance:       user.Balance,
+		IsAdmin:       user.IsAdmin,
+		CreatedOn:     user.CreatedOn,
+		Follows:       follows,
+		Followings:    followings,
+		TweetsCount:   user.TweetsCount,
+		ShowSensitive: user.ShowSensitive,
+		TwoFactor:     twoFactorEnabled(s.Ds, user.ID),
 	}
 	if user.Phone != "" && len(user.Phone) == 11 {
 		resp.Phone = user.Phone[0:3] + "****" + user.Phone[7:]
@@ -256,21 +260,9 @@ func (s *coreSrv) UserPhoneBind(req *web.UserPhoneBindReq) error {
 
 	// 如果禁止phone verify 则允许通过任意验证码
 	if _enablePhoneVerify {
-		c, err := s.Ds.GetLatestPhoneCaptcha(req.Phone)
-		if err != nil {
-			return web.ErrErrorPhoneCaptcha
-		}
-		if c.Captcha != req.Captcha {
-			return web.ErrErrorPhoneCaptcha
-		}
-		if c.ExpiredOn < time.Now().Unix() {
-			return web.ErrErrorPhoneCaptcha
-		}
-		if c.UseTimes >= _maxCaptchaTimes {
-			return web.ErrMaxPhoneCaptchaUseTimes
+		if err := checkPhoneCaptcha(s.Ds, req.Phone, req.Captcha, _maxCaptchaTimes); err != nil {
+			return err
 		}
-		// 更新检测次数
-		s.Ds.UsePhoneCaptcha(c)
 	}
 
 	// 执行绑定
@@ -308,6 +300,94 @@ func (s *coreSrv) GetStars(req *web.GetStarsReq) (*web.GetStarsResp, error) {
 	return (*web.GetStarsResp)(resp), nil
 }
 
+func (s *coreSrv) GetMentions(req *web.GetMentionsReq) (*web.GetMentionsResp, error) {
+	user, err := s.Ds.GetUserByID(req.UserId)
+	if err != nil {
+		logrus.Errorf("Ds.GetUserByID err: %s", err)
+		return nil, web.ErrGetMentionsFailed
+	}
+	// 动态的可见范围可能在@之后发生变化，不可见的不再展示，总数也只计可见的
+	mentions, totalRows, err := s.Ds.ListUserMentions(user, req.PageSize, (req.Page-1)*req.PageSize)
+	if err != nil {
+		logrus.Errorf("Ds.ListUserMentions err: %s", err)
+		return nil, web.ErrGetMentionsFailed
+	}
+	visible := make([]*ms.Mention, 0, len(mentions))
+	for _, m := range mentions {
+		if m.Post != nil {
+			visible = append(visible, m)
+		}
+	}
+	var (
+		posts                []*ms.Post
+		authorIds            []int64
+		commentIds, replyIds []int64
+	)
+	for _, m := range visible {
+		posts = append(posts, m.Post)
+		authorIds = append(authorIds, m.AuthorID)
+		if m.CommentID > 0 {
+			commentIds = append(commentIds, m.CommentID)
+		}
+		if m.ReplyID > 0 {
+			replyIds = append(replyIds, m.ReplyID)
+		}
+	}
+	postsFormated, err := s.Ds.MergePosts(posts)
+	if err != nil {
+		logrus.Errorf("Ds.MergePosts err: %s", err)
+		return nil, web.ErrGetMentionsFailed
+	}
+	if err = s.PrepareTweets(req.UserId, postsFormated); err != nil {
+		logrus.Errorf("get mentions prepare tweets err: %s", err)
+		return nil, web.ErrGetMentionsFailed
+	}
+	postMap := make(map[int64]*ms.PostFormated, len(postsFormated))
+	for _, post := range postsFormated {
+		postMap[post.ID] = post
+	}
+	authors, err := s.Ds.GetUsersByIDs(authorIds)
+	if err != nil {
+		logrus.Errorf("Ds.GetUsersByIDs err: %s", err)
+		return nil, web.ErrGetMentionsFailed
+	}
+	authorMap := make(map[int64]*ms.UserFormated, len(authors))
+	for _, author := range authors {
+		authorMap[author.ID] = author.Format()
+	}
+	commentMap := make(map[int64]*ms.Comment, len(commentIds))
+	if len(commentIds) > 0 {
+		comments, err := s.Ds.ListCommentsByIDs(commentIds)
+		if err != nil {
+			logrus.Errorf("Ds.ListCommentsByIDs err: %s", err)
+			return nil, web.ErrGetMentionsFailed
+		}
+		for _, comment := range comments {
+			commentMap[comment.ID] = comment
+		}
+	}
+	replyMap := make(map[int64]*ms.CommentReply, len(replyIds))
+	if len(replyIds) > 0 {
+		replies, err := s.Ds.ListCommentRepliesByIDs(replyIds)
+		if err != nil {
+			logrus.Errorf("Ds.ListCommentRepliesByIDs err: %s", err)
+			return nil, web.ErrGetMentionsFailed
+		}
+		for _, reply := range replies {
+			replyMap[reply.ID] = reply
+		}
+	}
+	items := make([]*ms.MentionFormated, 0, len(visible))
+	for _, m := range visible {
+		item := m.Format()
+		item.Post, item.Author = postMap[m.PostID], authorMap[m.AuthorID]
+		item.Comment, item.Reply = commentMap[m.CommentID], replyMap[m.ReplyID]
+		items = append(items, item)
+	}
+	resp := base.PageRespFrom(items, req.Page, req.PageSize, totalRows)
+	return (*web.GetMentionsResp)(resp), nil
+}
+
 func (s *coreSrv) ChangePassword(req *web.ChangePasswordReq) error {
 	// 密码检查
 	if err := checkPassword(req.Password); err != nil {
@@ -319,11 +399,117 @@ func (s *coreSrv) ChangePassword(req *web.ChangePasswordReq) error {
 		return web.ErrErrorOldPassword
 	}
 	// 更新入库
-	user.Password, user.Salt = encryptPasswordAndSalt(req.Password)
+	password, salt, err := encryptPasswordAndSalt(req.Password)
+	if err != nil {
+		logrus.Errorf("encryptPasswordAndSalt err: %s", err)
+		return xerror.ServerError
+	}
+	user.Password, user.Salt = password, salt
 	if err := s.Ds.UpdateUser(user); err != nil {
 		logrus.Errorf("Ds.UpdateUser err: %s", err)
 		return xerror.ServerError
 	}
+	// 修改密码后全部设备需要重新登录
+	if err := s.Ds.RevokeAllSessions(user.ID, 0); err != nil {
+		logrus.Errorf("Ds.RevokeAllSessions err: %s", err)
+	}
+	return nil
+}
+
+func (s *coreSrv) SetupTwoFactor(req *web.SetupTwoFactorReq) (*web.SetupTwoFactorResp, error) {
+	if twoFactorEnabled(s.Ds, req.User.ID) {
+		return nil, web.ErrUserHasBindOTP
+	}
+	resp, err := setupTwoFactor(s.Ds, req.User)
+	return (*web.SetupTwoFactorResp)(resp), err
+}
+
+func (s *coreSrv) EnableTwoFactor(req *web.EnableTwoFactorReq) (*web.EnableTwoFactorResp, error) {
+	var codes []string
+	if err := limitTwoFactor(s.Redis, req.User.ID, func() (err error) {
+		codes, err = enableTwoFactor(s.Ds, req.User.ID, req.Code)
+		return
+	}); err != nil {
+		return nil, err
+	}
+	return &web.EnableTwoFactorResp{
+		RecoveryCodes: codes,
+	}, nil
+}
+
+func (s *coreSrv) DisableTwoFactor(req *web.DisableTwoFactorReq) error {
+	user := req.User
+	if twoFactorRequired(user) {
+		return web.ErrTwoFactorRequired
+	}
+	if err := limitTwoFactor(s.Redis, user.ID, func() error {
+		if !validPassword(user.Password, req.Password, user.Salt) {
+			return web.ErrErrorOldPassword
+		}
+		return checkTwoFactor(s.Ds, user.ID, req.Code)
+	}); err != nil {
+		return err
+	}
+	if err := s.Ds.DisableUserOTP(user.ID); err != nil {
+		logrus.Errorf("Ds.DisableUserOTP err: %s", err)
+		return web.ErrTwoFactorFailed
+	}
+	return nil
+}
+
+func (s *coreSrv) ResetRecoveryCodes(req *web.ResetRecoveryCodesReq) (*web.ResetRecoveryCodesResp, error) {
+	if err := limitTwoFactor(s.Redis, req.User.ID, func() error {
+		return checkTwoFactor(s.Ds, req.User.ID, req.Code)
+	}); err != nil {
+		return nil, err
+	}
+	codes, err := resetRecoveryCodes(s.Ds, req.User.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &web.ResetRecoveryCodesResp{
+		RecoveryCodes: codes,
+	}, nil
+}
+
+func (s *coreSrv) ListSessions(req *web.ListSessionsReq) (*web.ListSessionsResp, error) {
+	sessions, err := s.Ds.ListSessions(req.Uid)
+	if err != nil {
+		logrus.Errorf("Ds.ListSessions err: %s", err)
+		return nil, web.ErrGetSessionsFailed
+	}
+	list := make([]*ms.UserSessionFormated, 0, len(sessions))
+	for _, session := range sessions {
+		item := session.Format()
+		item.IsCurrent = session.ID == req.SessionId
+		list = append(list, item)
+	}
+	return &web.ListSessionsResp{
+		List: list,
+	}, nil
+}
+
+func (s *coreSrv) RevokeSession(req *web.RevokeSessionReq) error {
+	session, err := s.Ds.GetSession(req.ID)
+	if err != nil || session.UserId != req.Uid {
+		return web.ErrNoExistSession
+	}
+	if err = s.Ds.RevokeSession(session); err != nil {
+		logrus.Errorf("Ds.RevokeSession err: %s", err)
+		return web.ErrRevokeSessionFailed
+	}
+	return nil
+}
+
+func (s *coreSrv) RevokeAllSessions(req *web.RevokeAllSessionsReq) error {
+	exceptId := int64(0)
+	if req.KeepCurrent {
+		exceptId = req.SessionId
+	}
+	if err := s.Ds.RevokeAllSessions(req.Uid, exceptId); err != nil {
+		logrus.Errorf("Ds.RevokeAllSessions err: %s", err)
+		return web.ErrRevokeSessionFailed
+	}
 	return nil
 }
 
@@ -368,6 +554,70 @@ func (s *coreSrv) ChangeNickname(req *web.ChangeNicknameReq) error {
 	return nil
 }
 
+func (s *coreSrv) ChangeShowSensitive(req *web.ChangeShowSensitiveReq) error {
+	user := req.User
+	user.ShowSensitive = req.Show
+	if err := s.Ds.UpdateUser(user); err != nil {
+		logrus.Errorf("Ds.UpdateUser err: %s", err)
+		return web.ErrChangeSensitiveFailed
+	}
+	// 缓存处理，推文列表的折叠状态是按访问者缓存的
+	onChangeUsernameEvent(user.ID, user.Username)
+	cache.OnExpireViewerTweetsEvent(user.Username)
+	return nil
+}
+
+func (s *coreSrv) ChargeUser(req *web.ChargeUserReq) (*web.ChargeUserResp, error) {
+	if req.Amount < conf.AppSetting.MinChargeAmount {
+		return nil, web.ErrInvalidChargeAmount
+	}
+	info, err := s.sponsorUser(req.User, req.UserId, req.Amount, func(user *ms.User) (*ms.UserSponsor, error) {
+		return s.Ds.ChargeCreator(user, req.UserId, req.Amount)
+	})
+	return (*web.ChargeUserResp)(info), err
+}
+
+func (s *coreSrv) SubscribeUser(req *web.SubscribeUserReq) (*web.SubscribeUserResp, error) {
+	price, days := conf.AppSetting.SubscriptionPrice, conf.AppSetting.SubscriptionDays
+	info, err := s.sponsorUser(req.User, req.UserId, price, func(user *ms.User) (*ms.UserSponsor, error) {
+		return s.Ds.SubscribeCreator(user, req.UserId, price, days)
+	})
+	return (*web.SubscribeUserResp)(info), err
+}
+
+// sponsorUser 执行充电或订阅（余额在事务内校验），并过期双方的用户信息与访问者看到的推文列表缓存
+func (s *coreSrv) sponsorUser(me *ms.User, creatorId int64, amount int64, fn func(*ms.User) (*ms.UserSponsor, error)) (*web.SponsorInfo, error) {
+	if me.ID == creatorId {
+		return nil, web.ErrSponsorSelf
+	}
+	creator, err := s.Ds.GetUserByID(creatorId)
+	if err != nil || creator.Model == nil || creator.ID <= 0 {
+		return nil, web.ErrNoExistUsername
+	}
+	// 缓存中的用户信息余额可能不是最新的
+	user, err := s.Ds.GetUserByID(me.ID)
+	if err != nil {
+		logrus.Errorf("Ds.GetUserByID err: %s", err)
+		return nil, web.ErrSponsorFailed
+	}
+	sponsor, err := fn(user)
+	if errors.Is(err, cs.ErrInsufficientBalance) {
+		return nil, web.ErrInsufficientBalance
+	} else if err != nil {
+		logrus.Errorf("sponsor user err: %s", err)
+		return nil, web.ErrSponsorFailed
+	}
+	onChangeUsernameEvent(user.ID, user.Username)
+	onChangeUsernameEvent(creator.ID, creator.Username)
+	cache.OnExpireViewerTweetsEvent(user.Username)
+	return &web.SponsorInfo{
+		CreatorId:          creator.ID,
+		ChargedAmount:      sponsor.ChargedAmount,
+		SubscribeExpiredOn: sponsor.SubscribeExpiredOn,
+		Balance:            user.Balance,
+	}, nil
+}
+
 func (s *coreSrv) ChangeAvatar(req *web.ChangeAvatarReq) (xerr error) {
 	defer func() {
 		if xerr != nil {
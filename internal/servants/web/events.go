@@ -35,6 +35,8 @@ const (
 	_commentActionReplyThumbsUp
 	_commentActionReplyThumbsDown
 	_commentActionHighlight
+	_commentActionReaction
+	_commentActionEdit
 )
 
 const (
@@ -42,6 +44,7 @@ const (
 	_messageActionRead
 	_messageActionFollow
 	_messageActionSendWhisper
+	_messageActionDelete
 )
 
 const (
@@ -225,7 +228,10 @@ func (e *commentActionEvent) Action() (err error) {
 	case _commentActionThumbsUp, _commentActionThumbsDown:
 		err = e.updateCommentMetric()
 		e.expireHotsComments()
-	case _commentActionHighlight:
+	case _commentActionHighlight, _commentActionReaction, _commentActionEdit:
+		e.expireAllStyleComments()
+	case _commentActionReplyThumbsUp, _commentActionReplyThumbsDown:
+		// 评论附带的回复可按点赞排序
 		e.expireAllStyleComments()
 	default:
 		// nothing
@@ -264,7 +270,8 @@ func (e *messageActionEvent) Action() (err error) {
 	for _, userId := range e.userId {
 		switch e.action {
 		case _messageActionRead,
-			_messageActionSendWhisper:
+			_messageActionSendWhisper,
+			_messageActionDelete:
 			// 清除未读消息数缓存，不需要处理错误
 			e.wc.DelUnreadMsgCountResp(userId)
 		case _messageActionCreate,
@@ -7,6 +7,9 @@ package web
 import (
 	"github.com/gin-gonic/gin"
 	api "github.com/rocboss/paopao-ce/auto/api/v1"
+	"github.com/rocboss/paopao-ce/internal/conf"
+	"github.com/rocboss/paopao-ce/internal/core/ms"
+	"github.com/rocboss/paopao-ce/internal/dao/cache"
 	"github.com/rocboss/paopao-ce/internal/model/web"
 	"github.com/rocboss/paopao-ce/internal/servants/base"
 	"github.com/rocboss/paopao-ce/internal/servants/chain"
@@ -114,6 +117,104 @@ func (s *friendshipSrv) RequestingFriend(req *web.RequestingFriendReq) error {
 	return nil
 }
 
+func (s *friendshipSrv) ListContactGroups(req *web.ListContactGroupsReq) (*web.ListContactGroupsResp, error) {
+	if req.User == nil {
+		return nil, xerror.ServerError
+	}
+	groups, err := s.Ds.ListContactGroups(req.User.ID)
+	if err != nil {
+		logrus.Errorf("Ds.ListContactGroups err: %s", err)
+		return nil, web.ErrGetContactGroupsFailed
+	}
+	return &web.ListContactGroupsResp{
+		List: groups,
+	}, nil
+}
+
+func (s *friendshipSrv) CreateContactGroup(req *web.CreateContactGroupReq) (*web.CreateContactGroupResp, error) {
+	if req.User == nil {
+		return nil, xerror.ServerError
+	}
+	name, ok := contactGroupNameFrom(req.Name)
+	if !ok {
+		return nil, web.ErrInvalidContactGroupName
+	}
+	count, err := s.Ds.CountContactGroups(req.User.ID)
+	if err != nil {
+		logrus.Errorf("Ds.CountContactGroups err: %s", err)
+		return nil, web.ErrCreateContactGroupFailed
+	}
+	if count >= conf.AppSetting.MaxContactGroups {
+		return nil, web.ErrContactGroupsExceed
+	}
+	group, err := s.Ds.CreateContactGroup(req.User.ID, name)
+	if err != nil {
+		logrus.Errorf("Ds.CreateContactGroup err: %s", err)
+		return nil, web.ErrCreateContactGroupFailed
+	}
+	return (*web.CreateContactGroupResp)(group.Format()), nil
+}
+
+func (s *friendshipSrv) RenameContactGroup(req *web.RenameContactGroupReq) error {
+	group, err := s.contactGroupFrom(req.User, req.ID)
+	if err != nil {
+		return err
+	}
+	name, ok := contactGroupNameFrom(req.Name)
+	if !ok {
+		return web.ErrInvalidContactGroupName
+	}
+	if err = s.Ds.RenameContactGroup(group, name); err != nil {
+		logrus.Errorf("Ds.RenameContactGroup err: %s", err)
+		return web.ErrUpdateContactGroupFailed
+	}
+	return nil
+}
+
+func (s *friendshipSrv) DeleteContactGroup(req *web.DeleteContactGroupReq) error {
+	group, err := s.contactGroupFrom(req.User, req.ID)
+	if err != nil {
+		return err
+	}
+	if err = s.Ds.DeleteContactGroup(group); err != nil {
+		logrus.Errorf("Ds.DeleteContactGroup err: %s", err)
+		return web.ErrDeleteContactGroupFailed
+	}
+	// 仅该分组可见的推文不再对分组内的好友可见
+	cache.OnExpireIndexTweetEvent(req.User.ID)
+	return nil
+}
+
+func (s *friendshipSrv) SetContactGroup(req *web.SetContactGroupReq) error {
+	if req.User == nil {
+		return xerror.ServerError
+	}
+	if req.GroupId > 0 {
+		if _, err := s.contactGroupFrom(req.User, req.GroupId); err != nil {
+			return err
+		}
+	}
+	if err := s.Ds.SetContactGroup(req.User.ID, req.UserId, req.GroupId); err != nil {
+		logrus.Errorf("Ds.SetContactGroup err: %s", err)
+		return web.ErrSetContactGroupFailed
+	}
+	// 好友能看到的分组可见推文变了
+	cache.OnExpireIndexTweetEvent(req.User.ID)
+	return nil
+}
+
+// contactGroupFrom 获取当前用户的联系人分组
+func (s *friendshipSrv) contactGroupFrom(user *ms.User, id int64) (*ms.ContactGroup, error) {
+	if user == nil {
+		return nil, xerror.ServerError
+	}
+	group, err := s.Ds.GetContactGroup(id)
+	if err != nil || group.UserId != user.ID {
+		return nil, web.ErrNotExistContactGroup
+	}
+	return group, nil
+}
+
 func newFriendshipSrv(s *base.DaoServant) api.Friendship {
 	return &friendshipSrv{
 		DaoServant: s,
@@ -5,10 +5,16 @@
 package web
 
 import (
+	"context"
+	"time"
+
 	"github.com/alimy/tryst/cfg"
 	"github.com/robfig/cron/v3"
 	"github.com/rocboss/paopao-ce/internal/conf"
+	"github.com/rocboss/paopao-ce/internal/core/ms"
+	"github.com/rocboss/paopao-ce/internal/dao/cache"
 	"github.com/rocboss/paopao-ce/internal/infra/events"
+	"github.com/rocboss/paopao-ce/internal/servants/base"
 	"github.com/sirupsen/logrus"
 )
 
@@ -30,10 +36,186 @@ func onMaxOnlineJob() {
 	})
 }
 
-func scheduleJobs() {
+// onPublishScheduledJob 发布到点的定时推文
+func onPublishScheduledJob(ds *base.DaoServant) {
+	spec := conf.JobManagerSetting.PublishScheduledInterval
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		panic(err)
+	}
+	events.OnTask(schedule, func() {
+		posts, err := ds.Ds.ListDueScheduledTweets(time.Now().Unix(), 100)
+		if err != nil {
+			logrus.Warnf("onPublishScheduledJob[1] occurs error: %s", err)
+			return
+		}
+		for _, post := range posts {
+			user, err := ds.Ds.GetUserByID(post.UserID)
+			if err != nil {
+				logrus.Warnf("onPublishScheduledJob[2] occurs error: %s", err)
+				continue
+			}
+			contents, err := ds.Ds.GetPostContentsByIDs([]int64{post.ID})
+			if err != nil {
+				logrus.Warnf("onPublishScheduledJob[3] occurs error: %s", err)
+				continue
+			}
+			// 已被其他实例发布或已删除的推文不再重复触发发布后的处理
+			if published, err := ds.Ds.PublishPost(post); err != nil {
+				logrus.Warnf("onPublishScheduledJob[4] occurs error: %s", err)
+				continue
+			} else if !published {
+				continue
+			}
+			onPublishTweet(ds, user, post, mentionedUsernames(contents))
+		}
+	})
+}
+
+// onCleanupDraftsJob 清理长期未修改的草稿及其媒体资源
+func onCleanupDraftsJob(ds *base.DaoServant) {
+	spec := conf.JobManagerSetting.CleanupDraftsInterval
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		panic(err)
+	}
+	events.OnTask(schedule, func() {
+		before := time.Now().AddDate(0, 0, -conf.AppSetting.DraftExpireDays).Unix()
+		drafts, err := ds.Ds.ListExpiredDrafts(before, 100)
+		if err != nil {
+			logrus.Warnf("onCleanupDraftsJob[1] occurs error: %s", err)
+			return
+		}
+		for _, draft := range drafts {
+			if err = ds.Ds.DeleteDraft(draft); err != nil {
+				logrus.Warnf("onCleanupDraftsJob[2] occurs error: %s", err)
+				continue
+			}
+			deleteUserOssObjects(ds.Ds, _oss, draft.UserID, mediaContentsFrom(draftContentsFrom(draft)))
+		}
+	})
+}
+
+// onPurgeTrashJob 彻底清除回收站中过期的推文及其媒体资源
+func onPurgeTrashJob(ds *base.DaoServant) {
+	spec := conf.JobManagerSetting.PurgeTrashInterval
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		panic(err)
+	}
+	events.OnTask(schedule, func() {
+		before := time.Now().AddDate(0, 0, -conf.AppSetting.TrashRetentionDays).Unix()
+		trashes, err := ds.Ds.ListExpiredPostTrash(before, 100)
+		if err != nil {
+			logrus.Warnf("onPurgeTrashJob[1] occurs error: %s", err)
+			return
+		}
+		for _, trash := range trashes {
+			mediaContents, err := ds.Ds.PurgePost(trash)
+			if err != nil {
+				logrus.Warnf("onPurgeTrashJob[2] occurs error: %s", err)
+				continue
+			}
+			deleteOssObjects(_oss, mediaContents)
+		}
+	})
+}
+
+// onExpireTweetsJob 下线已过期的限时推文，作者仍可在归档中查看
+func onExpireTweetsJob(ds *base.DaoServant) {
+	spec := conf.JobManagerSetting.ExpireTweetsInterval
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		panic(err)
+	}
+	events.OnTask(schedule, func() {
+		posts, err := ds.Ds.ListDueExpiredTweets(time.Now().Unix(), 100)
+		if err != nil {
+			logrus.Warnf("onExpireTweetsJob[1] occurs error: %s", err)
+			return
+		}
+		for _, post := range posts {
+			if err = ds.Ds.ExpirePost(post); err != nil {
+				logrus.Warnf("onExpireTweetsJob[2] occurs error: %s", err)
+				continue
+			}
+			ds.DeleteSearchPost(post)
+			cache.OnExpireIndexTweetEvent(post.UserID)
+		}
+	})
+}
+
+// onClosePollsJob 结束到期的投票并通知发起人
+func onClosePollsJob(ds *base.DaoServant) {
+	spec := conf.JobManagerSetting.ClosePollsInterval
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		panic(err)
+	}
+	events.OnTask(schedule, func() {
+		polls, err := ds.Ds.ListDueClosedPolls(time.Now().Unix(), 100)
+		if err != nil {
+			logrus.Warnf("onClosePollsJob[1] occurs error: %s", err)
+			return
+		}
+		for _, poll := range polls {
+			if err = ds.Ds.ClosePostPoll(poll); err != nil {
+				logrus.Warnf("onClosePollsJob[2] occurs error: %s", err)
+				continue
+			}
+			// 推文已删除时不再通知
+			if _, err = ds.Ds.GetPostByID(poll.PostID); err != nil {
+				continue
+			}
+			onCreateMessageEvent(&ms.Message{
+				SenderUserID:   poll.UserID,
+				ReceiverUserID: poll.UserID,
+				Type:           ms.MsgTypeSystem,
+				Brief:          "你发起的投票已结束",
+				PostID:         poll.PostID,
+			})
+		}
+	})
+}
+
+// onFlushTweetViewsJob 将缓冲的推文浏览数写入数据库
+func onFlushTweetViewsJob(ds *base.DaoServant) {
+	spec := conf.JobManagerSetting.FlushTweetViewsInterval
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		panic(err)
+	}
+	events.OnTask(schedule, func() {
+		ctx := context.Background()
+		views, err := ds.Redis.GetTweetViews(ctx)
+		if err != nil {
+			logrus.Warnf("onFlushTweetViewsJob[1] occurs error: %s", err)
+			return
+		}
+		if len(views) == 0 {
+			return
+		}
+		// 写入数据库失败时计数保留在缓冲中，下次再写入
+		if err = ds.Ds.AddPostViews(views); err != nil {
+			logrus.Warnf("onFlushTweetViewsJob[2] occurs error: %s", err)
+			return
+		}
+		if err = ds.Redis.DecrTweetViews(ctx, views); err != nil {
+			logrus.Warnf("onFlushTweetViewsJob[3] occurs error: %s", err)
+		}
+	})
+}
+
+func scheduleJobs(ds *base.DaoServant) {
 	cfg.Not("DisableJobManager", func() {
 		lazyInitial()
 		onMaxOnlineJob()
+		onPublishScheduledJob(ds)
+		onCleanupDraftsJob(ds)
+		onPurgeTrashJob(ds)
+		onExpireTweetsJob(ds)
+		onClosePollsJob(ds)
+		onFlushTweetViewsJob(ds)
 		logrus.Debug("schedule inner jobs complete")
 	})
 }
@@ -5,6 +5,8 @@
 package web
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
 	"github.com/gin-gonic/gin"
@@ -18,6 +20,7 @@ import (
 	"github.com/rocboss/paopao-ce/internal/model/web"
 	"github.com/rocboss/paopao-ce/internal/servants/base"
 	"github.com/rocboss/paopao-ce/internal/servants/chain"
+	"github.com/rocboss/paopao-ce/pkg/xerror"
 	"github.com/sirupsen/logrus"
 )
 
@@ -25,6 +28,9 @@ var (
 	_ api.Loose = (*looseSrv)(nil)
 )
 
+// _maxTweetViewsBatch 单次上报曝光的最大推文数
+const _maxTweetViewsBatch = 50
+
 type looseSrv struct {
 	api.UnimplementedLooseServant
 	*base.DaoServant
@@ -159,7 +165,8 @@ func (s *looseSrv) indexTweetsFromCache(req *web.TimelineReq, limit int, offset
 }
 
 func (s *looseSrv) tweetCommentsFromCache(req *web.TweetCommentsReq, limit int, offset int) (res *web.TweetCommentsResp, key string, ok bool) {
-	key = fmt.Sprintf("%s%d:%s:%d:%d", s.prefixTweetComment, req.TweetId, req.Style, limit, offset)
+	// 评论列表包含访问者的点赞与表情回应状态，需按访问者区分缓存
+	key = fmt.Sprintf("%s%d:%s:%d:%d:%d:%s", s.prefixTweetComment, req.TweetId, req.Style, limit, offset, req.Uid, req.ReplyStyle)
 	if data, err := s.ac.Get(key); err == nil {
 		ok, res = true, &web.TweetCommentsResp{
 			CachePageResp: joint.CachePageResp{
@@ -202,16 +209,12 @@ func (s *looseSrv) GetUserTweets(req *web.GetUserTweetsReq) (res *web.GetUserTwe
 }
 
 func (s *looseSrv) userTweetsFromCache(req *web.GetUserTweetsReq, user *cs.VistUser) (res *web.GetUserTweetsResp, key string, ok bool) {
-	switch req.Style {
-	case web.UserPostsStylePost, web.UserPostsStyleHighlight, web.UserPostsStyleMedia:
-		key = fmt.Sprintf("%s%d:%s:%s:%d:%d", s.prefixUserTweets, user.UserId, req.Style, user.RelTyp, req.Page, req.PageSize)
-	default:
-		meName := "_"
-		if user.RelTyp != cs.RelationGuest {
-			meName = req.User.Username
-		}
-		key = fmt.Sprintf("%s%d:%s:%s:%d:%d", s.prefixUserTweets, user.UserId, req.Style, meName, req.Page, req.PageSize)
+	// 转发动态中原动态的可见性与访问者相关，因此缓存需要区分访问者
+	meName := "_"
+	if user.RelTyp != cs.RelationGuest {
+		meName = req.User.Username
 	}
+	key = fmt.Sprintf("%s%d:%s:%s:%d:%d", s.prefixUserTweets, user.UserId, req.Style, meName, req.Page, req.PageSize)
 	if data, err := s.ac.Get(key); err == nil {
 		ok, res = true, &web.GetUserTweetsResp{
 			CachePageResp: joint.CachePageResp{
@@ -310,7 +313,7 @@ func (s *looseSrv) getUserPostTweets(req *web.GetUserTweetsReq, user *cs.VistUse
 	default:
 		// nothing
 	}
-	posts, total, err := s.Ds.ListUserTweets(user.UserId, style, isHighlight, req.PageSize, (req.Page-1)*req.PageSize)
+	posts, total, err := s.Ds.ListUserTweets(user, style, isHighlight, req.PageSize, (req.Page-1)*req.PageSize)
 	if err != nil {
 		logrus.Errorf("s.GetTweetList error[1]: %s", err)
 		return nil, web.ErrGetPostsFailed
@@ -410,6 +413,15 @@ func (s *looseSrv) TopicList(req *web.TopicListReq) (*web.TopicListResp, error)
 
 func (s *looseSrv) TweetComments(req *web.TweetCommentsReq) (res *web.TweetCommentsResp, err error) {
 	limit, offset := req.PageSize, (req.Page-1)*req.PageSize
+	// 付费可见的推文的评论只对充电或订阅过作者的用户展示
+	post, xerr := s.Ds.GetPostByID(req.TweetId)
+	if xerr != nil {
+		logrus.Errorf("Ds.GetPostByID err: %s", xerr)
+		return nil, web.ErrGetPostFailed
+	}
+	if xerr = checkPaidPostPermission(req.Uid, post, s.DaoServant); xerr != nil {
+		return nil, xerr
+	}
 	// 尝试直接从缓存中获取数据
 	key, ok := "", false
 	if res, key, ok = s.tweetCommentsFromCache(req, limit, offset); ok {
@@ -442,7 +454,7 @@ func (s *looseSrv) TweetComments(req *web.TweetCommentsReq) (res *web.TweetComme
 		return nil, web.ErrGetCommentsFailed
 	}
 
-	replies, xerr := s.Ds.GetCommentRepliesByID(commentIDs)
+	replies, xerr := s.Ds.GetCommentRepliesByID(commentIDs, req.ReplyStyle.ToInnerValue(), conf.AppSetting.PreviewRepliesSize)
 	if xerr != nil {
 		logrus.Errorf("looseSrv.TweetComments occurs error[4]: %s", xerr)
 		return nil, web.ErrGetCommentsFailed
@@ -457,6 +469,23 @@ func (s *looseSrv) TweetComments(req *web.TweetCommentsReq) (res *web.TweetComme
 		}
 	}
 
+	reactionCounts, xerr := s.Ds.GetCommentReactionCounts(commentIDs)
+	if xerr != nil {
+		logrus.Errorf("looseSrv.TweetComments occurs error[6]: %s", xerr)
+		return nil, web.ErrGetCommentsFailed
+	}
+	myReactions := make(map[int64][]string)
+	if req.Uid > 0 {
+		reactions, xerr := s.Ds.ListUserCommentReactions(req.Uid, commentIDs)
+		if xerr != nil {
+			logrus.Errorf("looseSrv.TweetComments occurs error[7]: %s", xerr)
+			return nil, web.ErrGetCommentsFailed
+		}
+		for _, reaction := range reactions {
+			myReactions[reaction.CommentID] = append(myReactions[reaction.CommentID], reaction.Reaction)
+		}
+	}
+
 	replyMap := make(map[int64][]*dbr.CommentReplyFormated)
 	if len(replyThumbs) > 0 {
 		for _, reply := range replies {
@@ -485,6 +514,12 @@ func (s *looseSrv) TweetComments(req *web.TweetCommentsReq) (res *web.TweetComme
 		if replySlice, exist := replyMap[commentFormated.ID]; exist {
 			commentFormated.Replies = replySlice
 		}
+		if counts, exist := reactionCounts[comment.ID]; exist {
+			commentFormated.Reactions = counts
+		}
+		if reactions, exist := myReactions[comment.ID]; exist {
+			commentFormated.MyReactions = reactions
+		}
 		for _, user := range users {
 			if user.ID == comment.UserID {
 				commentFormated.User = user.Format()
@@ -502,6 +537,58 @@ func (s *looseSrv) TweetComments(req *web.TweetCommentsReq) (res *web.TweetComme
 	}, nil
 }
 
+func (s *looseSrv) CommentReplies(req *web.CommentRepliesReq) (*web.CommentRepliesResp, error) {
+	cursor, ok := replyCursorFrom(req.Cursor)
+	if !ok {
+		return nil, xerror.InvalidParams
+	}
+	comment, err := s.Ds.GetCommentByID(req.CommentId)
+	if err != nil {
+		return nil, web.ErrGetCommentFailed
+	}
+	// 与评论列表一致，付费可见的推文的回复只对充电或订阅过作者的用户展示
+	post, err := s.Ds.GetPostByID(comment.PostID)
+	if err != nil {
+		logrus.Errorf("Ds.GetPostByID err: %s", err)
+		return nil, web.ErrGetPostFailed
+	}
+	if err = checkPaidPostPermission(req.Uid, post, s.DaoServant); err != nil {
+		return nil, err
+	}
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = conf.AppSetting.DefaultPageSize
+	} else if pageSize > conf.AppSetting.MaxPageSize {
+		pageSize = conf.AppSetting.MaxPageSize
+	}
+	// 多取一条用于判断是否还有更多回复
+	replies, err := s.Ds.GetCommentReplies(comment.ID, req.Style.ToInnerValue(), cursor, pageSize+1)
+	if err != nil {
+		logrus.Errorf("Ds.GetCommentReplies err: %s", err)
+		return nil, web.ErrGetRepliesFailed
+	}
+	resp := &web.CommentRepliesResp{
+		List: replies,
+	}
+	if len(replies) > pageSize {
+		resp.List, resp.HasMore = replies[:pageSize], true
+		resp.NextCursor = replyCursorString(resp.List[pageSize-1])
+	}
+	if req.Uid > 0 && len(resp.List) > 0 {
+		_, replyThumbs, err := s.Ds.GetCommentThumbsMap(req.Uid, comment.PostID)
+		if err != nil {
+			logrus.Errorf("Ds.GetCommentThumbsMap err: %s", err)
+			return nil, web.ErrGetRepliesFailed
+		}
+		for _, reply := range resp.List {
+			if thumbs, exist := replyThumbs[reply.ID]; exist {
+				reply.IsThumbsUp, reply.IsThumbsDown = thumbs.IsThumbsUp, thumbs.IsThumbsDown
+			}
+		}
+	}
+	return resp, nil
+}
+
 func (s *looseSrv) TweetDetail(req *web.TweetDetailReq) (*web.TweetDetailResp, error) {
 	post, err := s.Ds.GetPostByID(req.TweetId)
 	if err != nil {
@@ -525,6 +612,12 @@ func (s *looseSrv) TweetDetail(req *web.TweetDetailReq) (*web.TweetDetailResp, e
 			postFormated.Contents = append(postFormated.Contents, content.Format())
 		}
 	}
+	// 转发动态需要带上原动态
+	if post.RepostID > 0 {
+		if _, err = s.Ds.RevampPosts([]*ms.PostFormated{postFormated}); err != nil {
+			return nil, web.ErrGetPostFailed
+		}
+	}
 	if err = s.PrepareTweet(req.User, postFormated); err != nil {
 		return nil, web.ErrGetPostFailed
 	}
@@ -534,18 +627,223 @@ func (s *looseSrv) TweetDetail(req *web.TweetDetailReq) (*web.TweetDetailResp, e
 	case req.User != nil && (req.User.ID == postFormated.User.ID || req.User.IsAdmin):
 		// read by self of super admin
 		break
+	case post.PublishAt > 0 || post.IsExpired():
+		// 未发布的定时推文与已过期的限时推文仅作者可见
+		return nil, web.ErrNoPermission
 	case post.Visibility == core.PostVisitPublic:
 		break
 	case post.Visibility == core.PostVisitFriend && postFormated.User.IsFriend:
 		break
 	case post.Visibility == core.PostVisitFollowing && postFormated.User.IsFollowing:
 		break
+	case post.Visibility == core.PostVisitGroup && req.User != nil && s.Ds.IsPostAudience(post.ID, req.User.ID):
+		break
+	case post.Visibility.IsPaid():
+		// 未充电或订阅的访问者看到的是预览
+		break
 	default:
 		return nil, web.ErrNoPermission
 	}
+	s.recordTweetViews(req.User, req.ClientIP, post.ID)
 	return (*web.TweetDetailResp)(postFormated), nil
 }
 
+func (s *looseSrv) CollectionFolders(req *web.CollectionFoldersReq) (*web.CollectionFoldersResp, error) {
+	user, err := s.Ds.GetUserByUsername(req.Username)
+	if err != nil {
+		return nil, web.ErrNoExistUsername
+	}
+	// 非本人只能看到公开的收藏夹
+	isSelf := req.User != nil && req.User.ID == user.ID
+	if isSelf {
+		if _, err = s.Ds.GetDefaultCollectionFolder(user.ID); err != nil {
+			logrus.Errorf("Ds.GetDefaultCollectionFolder err: %s", err)
+			return nil, web.ErrGetFoldersFailed
+		}
+	}
+	folders, err := s.Ds.ListUserCollectionFolders(user.ID, !isSelf)
+	if err != nil {
+		logrus.Errorf("Ds.ListUserCollectionFolders err: %s", err)
+		return nil, web.ErrGetFoldersFailed
+	}
+	return &web.CollectionFoldersResp{
+		Folders: folders,
+	}, nil
+}
+
+func (s *looseSrv) FolderTweets(req *web.FolderTweetsReq) (*web.FolderTweetsResp, error) {
+	folder, err := s.Ds.GetCollectionFolderByID(req.FolderID)
+	if err != nil {
+		return nil, web.ErrGetFolderFailed
+	}
+	userId := int64(-1)
+	if req.User != nil {
+		userId = req.User.ID
+	}
+	isSelf := userId == folder.UserID
+	if !isSelf && folder.IsPublic == 0 {
+		return nil, web.ErrNoPermission
+	}
+	// 公开收藏夹对他人只展示公开的动态
+	collections, total, err := s.Ds.ListFolderCollections(folder.ID, !isSelf, req.PageSize, (req.Page-1)*req.PageSize)
+	if err != nil {
+		logrus.Errorf("Ds.ListFolderCollections err: %s", err)
+		return nil, web.ErrFolderTweetsFailed
+	}
+	tweets := make([]*ms.PostFormated, 0, len(collections))
+	for _, collection := range collections {
+		if collection.Post == nil {
+			continue
+		}
+		if tweet := collection.Post.Format(); tweet != nil {
+			tweets = append(tweets, tweet)
+		}
+	}
+	if tweets, err = s.Ds.RevampPosts(tweets); err != nil {
+		logrus.Errorf("Ds.RevampPosts err: %s", err)
+		return nil, web.ErrFolderTweetsFailed
+	}
+	if err = s.PrepareTweets(userId, tweets); err != nil {
+		logrus.Errorf("s.PrepareTweets err: %s", err)
+		return nil, web.ErrFolderTweetsFailed
+	}
+	resp := base.PageRespFrom(tweets, req.Page, req.PageSize, total)
+	return (*web.FolderTweetsResp)(resp), nil
+}
+
+func (s *looseSrv) TweetViews(req *web.TweetViewsReq) error {
+	ids := req.IDs
+	if len(ids) > _maxTweetViewsBatch {
+		ids = ids[:_maxTweetViewsBatch]
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	posts, err := s.Ds.GetPosts(ms.ConditionsT{"id IN ?": ids}, 0, 0)
+	if err != nil {
+		logrus.Errorf("Ds.GetPosts err: %s", err)
+		return web.ErrGetPostFailed
+	}
+	// 只记录存在且访问者可见的推文，付费推文与推文详情一样按预览计入
+	visibleIds := make([]int64, 0, len(posts))
+	for _, post := range posts {
+		if post.Visibility.IsPaid() && post.PublishAt == 0 && !post.IsExpired() {
+			visibleIds = append(visibleIds, post.ID)
+		} else if checkPostViewPermission(req.User, post, s.DaoServant) == nil {
+			visibleIds = append(visibleIds, post.ID)
+		}
+	}
+	s.recordTweetViews(req.User, req.ClientIP, visibleIds...)
+	return nil
+}
+
+// recordTweetViews 记录推文浏览，登录用户按用户去重，游客按IP去重
+func (s *looseSrv) recordTweetViews(user *ms.User, clientIP string, tweetIds ...int64) {
+	viewer := "ip:" + clientIP
+	if user != nil {
+		viewer = fmt.Sprintf("uid:%d", user.ID)
+	}
+	ctx := context.Background()
+	for _, id := range tweetIds {
+		if err := s.Redis.IncrTweetView(ctx, id, viewer, conf.AppSetting.TweetViewWindow); err != nil {
+			logrus.Warnf("looseSrv.recordTweetViews occurs error: %s", err)
+		}
+	}
+}
+
+func (s *looseSrv) TweetRevisions(req *web.TweetRevisionsReq) (*web.TweetRevisionsResp, error) {
+	post, err := s.Ds.GetPostByID(req.TweetId)
+	if err != nil {
+		return nil, web.ErrGetPostFailed
+	}
+	if err = checkPostViewPermission(req.User, post, s.DaoServant); err != nil {
+		return nil, err
+	}
+	revisions, err := s.Ds.ListPostContentRevisions(post.ID)
+	if err != nil {
+		logrus.Errorf("Ds.ListPostContentRevisions err: %s", err)
+		return nil, web.ErrGetPostRevisionsFailed
+	}
+	// 按版本号聚合，结果已按版本号倒序排列
+	resp := &web.TweetRevisionsResp{
+		Revisions: []*web.TweetRevision{},
+	}
+	var item *web.TweetRevision
+	for _, r := range revisions {
+		if item == nil || item.Revision != r.Revision {
+			item = &web.TweetRevision{
+				Revision:  r.Revision,
+				CreatedOn: r.CreatedOn,
+			}
+			resp.Revisions = append(resp.Revisions, item)
+		}
+		item.Contents = append(item.Contents, r.Format())
+	}
+	return resp, nil
+}
+
+func (s *looseSrv) TweetThread(req *web.TweetThreadReq) (*web.TweetThreadResp, error) {
+	post, err := s.Ds.GetPostByID(req.TweetId)
+	if err != nil {
+		return nil, web.ErrGetPostFailed
+	}
+	rootId := post.ID
+	if post.ThreadRootID > 0 {
+		rootId = post.ThreadRootID
+	}
+	posts, err := s.Ds.ListThreadTweets(rootId)
+	if err != nil {
+		logrus.Errorf("Ds.ListThreadTweets err: %s", err)
+		return nil, web.ErrGetPostThreadFailed
+	}
+	// 已删除或者无权查看的动态作为空缺，付费可见的动态与推文详情一样展示预览
+	visiblePosts := make([]*ms.Post, 0, len(posts))
+	for _, p := range posts {
+		if p.IsDel != 0 {
+			continue
+		}
+		if err := checkPostViewPermission(req.User, p, s.DaoServant); err == nil || errors.Is(err, web.ErrNotSponsor) {
+			visiblePosts = append(visiblePosts, p)
+		}
+	}
+	postsFormated, err := s.Ds.MergePosts(visiblePosts)
+	if err != nil {
+		logrus.Errorf("Ds.MergePosts err: %s", err)
+		return nil, web.ErrGetPostThreadFailed
+	}
+	userId := int64(-1)
+	if req.User != nil {
+		userId = req.User.ID
+	}
+	if err = s.PrepareTweets(userId, postsFormated); err != nil {
+		logrus.Errorf("s.PrepareTweets err: %s", err)
+		return nil, web.ErrGetPostThreadFailed
+	}
+	tweetMap := make(map[int64]*ms.PostFormated, len(postsFormated))
+	for _, tweet := range postsFormated {
+		tweetMap[tweet.ID] = tweet
+	}
+	resp := &web.TweetThreadResp{
+		RootId: rootId,
+		Items:  []*web.TweetThreadItem{},
+	}
+	gap := false
+	for _, p := range posts {
+		tweet, exist := tweetMap[p.ID]
+		if !exist {
+			gap = true
+			continue
+		}
+		// 连续的空缺合并为一个，末尾的空缺不展示
+		if gap {
+			resp.Items = append(resp.Items, &web.TweetThreadItem{IsGap: true})
+			gap = false
+		}
+		resp.Items = append(resp.Items, &web.TweetThreadItem{Tweet: tweet})
+	}
+	return resp, nil
+}
+
 func newLooseSrv(s *base.DaoServant, ac core.AppCache) api.Loose {
 	cs := conf.CacheSetting
 	return &looseSrv{
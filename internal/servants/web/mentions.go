@@ -0,0 +1,51 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package web
+
+import (
+	"github.com/rocboss/paopao-ce/internal/core"
+	"github.com/rocboss/paopao-ce/internal/core/ms"
+	"github.com/sirupsen/logrus"
+)
+
+// saveMentions 保存来源中@的用户，返回新增的被@用户，用于发送消息提醒
+func saveMentions(ds core.DataService, src *ms.Mention, usernames []string) []*ms.User {
+	users := make(map[int64]*ms.User, len(usernames))
+	userIds := make([]int64, 0, len(usernames))
+	for _, username := range usernames {
+		user, err := ds.GetUserByUsername(username)
+		if err != nil || user.ID == src.AuthorID || users[user.ID] != nil {
+			continue
+		}
+		users[user.ID] = user
+		userIds = append(userIds, user.ID)
+	}
+	added, removed, err := ds.SaveMentions(src, userIds)
+	if err != nil {
+		logrus.Errorf("Ds.SaveMentions err: %s", err)
+		return nil
+	}
+	// 不再被@的用户的消息提醒已删除，需要清除其消息缓存
+	if len(removed) > 0 {
+		onMessageActionEvent(_messageActionDelete, removed...)
+	}
+	res := make([]*ms.User, 0, len(added))
+	for _, id := range added {
+		res = append(res, users[id])
+	}
+	return res
+}
+
+// deleteMentions 删除来源及其下级来源中的@与对应的消息提醒
+func deleteMentions(ds core.DataService, src *ms.Mention) {
+	userIds, err := ds.DeleteMentions(src)
+	if err != nil {
+		logrus.Errorf("Ds.DeleteMentions err: %s", err)
+		return
+	}
+	if len(userIds) > 0 {
+		onMessageActionEvent(_messageActionDelete, userIds...)
+	}
+}
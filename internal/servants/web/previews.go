@@ -0,0 +1,149 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package web
+
+import (
+	"bytes"
+	"context"
+	"mime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alimy/tryst/event"
+	"github.com/rocboss/paopao-ce/internal/conf"
+	"github.com/rocboss/paopao-ce/internal/core"
+	"github.com/rocboss/paopao-ce/internal/core/ms"
+	"github.com/rocboss/paopao-ce/internal/dao/cache"
+	"github.com/rocboss/paopao-ce/internal/infra/events"
+	"github.com/rocboss/paopao-ce/internal/model/web"
+	"github.com/rocboss/paopao-ce/pkg/unfurl"
+	"github.com/rocboss/paopao-ce/pkg/utils"
+	"github.com/sirupsen/logrus"
+)
+
+const _maxLinkPreviewURLSize = 2000
+
+var (
+	_unfurler     *unfurl.Unfurler
+	_onceUnfurler sync.Once
+
+	// _previewImageExts 允许转存的预览图类型
+	_previewImageExts = map[string]string{
+		"image/jpeg": ".jpg",
+		"image/png":  ".png",
+		"image/gif":  ".gif",
+		"image/webp": ".webp",
+	}
+)
+
+type linkPreviewEvent struct {
+	event.UnimplementedEvent
+	ds     core.DataService
+	oss    core.ObjectStorageService
+	userId int64
+	urls   []string
+}
+
+// onLinkPreviewEvent 异步抓取推文中链接内容的预览信息
+func onLinkPreviewEvent(userId int64, contents []*web.PostContentItem) {
+	if !conf.LinkPreviewSetting.Enable {
+		return
+	}
+	var urls []string
+	for _, item := range contents {
+		if item.Type == ms.ContentTypeLink && len(item.Content) <= _maxLinkPreviewURLSize &&
+			(strings.HasPrefix(item.Content, "http://") || strings.HasPrefix(item.Content, "https://")) {
+			urls = append(urls, item.Content)
+		}
+	}
+	if len(urls) == 0 {
+		return
+	}
+	events.OnEvent(&linkPreviewEvent{
+		ds:     _ds,
+		oss:    _oss,
+		userId: userId,
+		urls:   urls,
+	})
+}
+
+func linkUnfurler() *unfurl.Unfurler {
+	_onceUnfurler.Do(func() {
+		_unfurler = unfurl.New(conf.LinkPreviewSetting.Timeout, conf.LinkPreviewSetting.MaxBodySize)
+	})
+	return _unfurler
+}
+
+func (e *linkPreviewEvent) Name() string {
+	return "linkPreviewEvent"
+}
+
+func (e *linkPreviewEvent) Action() error {
+	fetched := false
+	for _, url := range e.urls {
+		// 已抓取成功的链接不再重复抓取，失败的链接间隔一段时间后重试
+		if old, err := e.ds.GetLinkPreview(url); err == nil {
+			if old.Status == ms.LinkPreviewFetched || time.Now().Unix()-old.ModifiedOn < conf.LinkPreviewSetting.RetryInterval {
+				continue
+			}
+		}
+		preview := e.unfurl(url)
+		if err := e.ds.SaveLinkPreview(preview); err != nil {
+			logrus.Errorf("linkPreviewEvent save preview of %s err: %s", url, err)
+			continue
+		}
+		fetched = fetched || preview.Status == ms.LinkPreviewFetched
+	}
+	// 推文列表缓存中没有预览信息，需要让其过期
+	if fetched {
+		cache.OnExpireIndexTweetEvent(e.userId)
+	}
+	return nil
+}
+
+func (e *linkPreviewEvent) unfurl(url string) *ms.LinkPreview {
+	preview := &ms.LinkPreview{
+		URL:    url,
+		Status: ms.LinkPreviewFailed,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), conf.LinkPreviewSetting.Timeout)
+	defer cancel()
+	res, err := linkUnfurler().Unfurl(ctx, url)
+	if err != nil {
+		logrus.Debugf("linkPreviewEvent unfurl %s err: %s", url, err)
+		return preview
+	}
+	preview.Title, preview.Description, preview.SiteName = res.Title, res.Description, res.SiteName
+	preview.Image, preview.Status = res.Image, ms.LinkPreviewFetched
+	if conf.LinkPreviewSetting.RehostImage && preview.Image != "" {
+		preview.Image = e.rehostImage(preview.Image)
+	}
+	return preview
+}
+
+// rehostImage 将预览图转存到对象存储，失败时不展示预览图
+func (e *linkPreviewEvent) rehostImage(imageUrl string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), conf.LinkPreviewSetting.Timeout)
+	defer cancel()
+	data, contentType, err := linkUnfurler().FetchImage(ctx, imageUrl, conf.LinkPreviewSetting.MaxImageSize)
+	if err != nil {
+		logrus.Debugf("linkPreviewEvent fetch image %s err: %s", imageUrl, err)
+		return ""
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	ext, exist := _previewImageExts[mediaType]
+	if !exist {
+		return ""
+	}
+	hash := utils.EncodeMD5(imageUrl)
+	objectKey := "public/image/preview/" + generatePath(hash[:8]) + "/" + hash[8:] + ext
+	objectUrl, err := e.oss.PutObject(objectKey, bytes.NewReader(data), int64(len(data)), mediaType, true)
+	if err != nil {
+		logrus.Errorf("linkPreviewEvent put preview image err: %s", err)
+		return ""
+	}
+	return objectUrl
+}
@@ -5,6 +5,8 @@
 package web
 
 import (
+	"encoding/json"
+	"errors"
 	"image"
 	"io"
 	"strings"
@@ -57,6 +59,20 @@ func (s *privChain) ChainCreateTweet() (res gin.HandlersChain) {
 	return
 }
 
+func (s *privChain) ChainEditTweet() (res gin.HandlersChain) {
+	if cfg.If("UseAuditHook") {
+		res = gin.HandlersChain{chain.AuditHook()}
+	}
+	return
+}
+
+func (s *privChain) ChainPublishDraft() (res gin.HandlersChain) {
+	if cfg.If("UseAuditHook") {
+		res = gin.HandlersChain{chain.AuditHook()}
+	}
+	return
+}
+
 func (s *privSrv) Chain() gin.HandlersChain {
 	return gin.HandlersChain{chain.JWT(), chain.Priv()}
 }
@@ -66,6 +82,8 @@ func (s *privSrv) ThumbsDownTweetReply(req *web.TweetReplyThumbsReq) error {
 		logrus.Errorf("thumbs down tweet reply error: %s req:%v", err, req)
 		return web.ErrThumbsDownTweetReply
 	}
+	// 缓存处理
+	onCommentActionEvent(req.TweetId, req.CommentId, _commentActionReplyThumbsDown)
 	return nil
 }
 
@@ -74,6 +92,8 @@ func (s *privSrv) ThumbsUpTweetReply(req *web.TweetReplyThumbsReq) error {
 		logrus.Errorf("thumbs up tweet reply error: %s req:%v", err, req)
 		return web.ErrThumbsUpTweetReply
 	}
+	// 缓存处理
+	onCommentActionEvent(req.TweetId, req.CommentId, _commentActionReplyThumbsUp)
 	return nil
 }
 
@@ -256,12 +276,47 @@ func (s *privSrv) CreateTweet(req *web.CreateTweetReq) (_ *web.CreateTweetResp,
 		}
 	}()
 
+	// 转发动态需要先确认原动态存在且可见
+	var original *ms.Post
+	if req.RepostID > 0 {
+		var err error
+		if original, err = s.repostOriginalFrom(req.User, req.RepostID); err != nil {
+			return nil, err
+		}
+	}
+	// 续写串推只能接在自己的动态之后
+	var threadHead *ms.Post
+	if req.ThreadParentID > 0 {
+		var err error
+		if threadHead, err = s.threadHeadFrom(req.User, req.ThreadParentID); err != nil {
+			return nil, err
+		}
+	}
+	poll, err := pollSpecFrom(req.Contents, req.PublishAt)
+	if err != nil {
+		return nil, err
+	}
+	sensitive, warning, ok := sensitiveFrom(req.IsSensitive, req.ContentWarning)
+	if !ok {
+		return nil, web.ErrInvalidContentWarning
+	}
+	groupIds, err := audienceGroupsFrom(s.Ds, req.User.ID, req.Visibility, req.GroupIds)
+	if err != nil {
+		return nil, err
+	}
+	expiresIn, ok := expiresInFrom(req.IsStory, req.ExpiresIn)
+	if !ok {
+		return nil, web.ErrInvalidExpiresIn
+	}
+	if !req.ReplyPolicy.IsValid() {
+		return nil, web.ErrInvalidReplyPolicy
+	}
 	contents, err := persistMediaContents(s.oss, req.Contents)
 	if err != nil {
 		return nil, web.ErrCreatePostFailed
 	}
 	mediaContents = contents
-	tags := tagsFrom(req.Tags)
+	tags := tagsFrom(req.Tags, textsFrom(req.Contents)...)
 	post := &ms.Post{
 		UserID:          req.User.ID,
 		Tags:            strings.Join(tags, ","),
@@ -269,29 +324,61 @@ func (s *privSrv) CreateTweet(req *web.CreateTweetReq) (_ *web.CreateTweetResp,
 		IPLoc:           utils.GetIPLoc(req.ClientIP),
 		AttachmentPrice: req.AttachmentPrice,
 		Visibility:      ms.PostVisibleT(req.Visibility.ToVisibleValue()),
+		IsSensitive:     sensitive,
+		ContentWarning:  warning,
+		ReplyPolicy:     req.ReplyPolicy,
+	}
+	if original != nil {
+		post.RepostID = original.ID
+	}
+	if threadHead != nil {
+		post.ThreadRootID, post.ThreadParentID = threadHead.ID, req.ThreadParentID
+	}
+	now := time.Now().Unix()
+	if req.PublishAt > now {
+		post.PublishAt = req.PublishAt
+	}
+	// 限时推文从发布时起算有效时长
+	if expiresIn > 0 {
+		post.ExpiresAt = max(now, post.PublishAt) + expiresIn
 	}
 	post, err = s.Ds.CreatePost(post)
 	if err != nil {
 		logrus.Errorf("Ds.CreatePost err: %s", err)
 		return nil, web.ErrCreatePostFailed
 	}
+	if len(groupIds) > 0 {
+		if err = s.Ds.SetPostAudience(post.ID, groupIds); err != nil {
+			logrus.Errorf("Ds.SetPostAudience err: %s", err)
+			return nil, web.ErrCreatePostFailed
+		}
+	}
 
 	// 创建推文内容
+	hasPoll := false
 	for _, item := range req.Contents {
 		if err := item.Check(s.Ds); err != nil {
 			// 属性非法
 			logrus.Infof("contents check err: %s", err)
 			continue
 		}
+		// 每条推文最多一个投票
+		if item.Type == ms.ContentTypePoll {
+			if hasPoll {
+				continue
+			}
+			hasPoll = true
+		}
 		if item.Type == ms.ContentTypeAttachment && req.AttachmentPrice > 0 {
 			item.Type = ms.ContentTypeChargeAttachment
 		}
 		postContent := &ms.PostContent{
-			PostID:  post.ID,
-			UserID:  req.User.ID,
-			Content: item.Content,
-			Type:    item.Type,
-			Sort:    item.Sort,
+			PostID:      post.ID,
+			UserID:      req.User.ID,
+			Content:     item.Content,
+			Type:        item.Type,
+			Sort:        item.Sort,
+			IsSensitive: boolToInt8(item.IsSensitive),
 		}
 		if _, err = s.Ds.CreatePostContent(postContent); err != nil {
 			logrus.Infof("Ds.CreatePostContent err: %s", err)
@@ -299,40 +386,773 @@ func (s *privSrv) CreateTweet(req *web.CreateTweetReq) (_ *web.CreateTweetResp,
 		}
 	}
 
-	// 私密推文不创建标签与用户提醒
-	if post.Visibility != core.PostVisitPrivate {
-		// 创建标签
-		s.Ds.UpsertTags(req.User.ID, tags)
+	if poll != nil {
+		multiple := int8(0)
+		if poll.Multiple {
+			multiple = 1
+		}
+		if _, err = s.Ds.CreatePostPoll(&ms.PostPoll{
+			PostID:    post.ID,
+			UserID:    req.User.ID,
+			Multiple:  multiple,
+			ExpiresAt: poll.ExpiresAt,
+		}, poll.Options); err != nil {
+			logrus.Errorf("Ds.CreatePostPoll err: %s", err)
+			return nil, web.ErrCreatePostFailed
+		}
+	}
 
-		// 创建用户消息提醒
-		for _, u := range req.Users {
-			user, err := s.Ds.GetUserByUsername(u)
-			if err != nil || user.ID == req.User.ID {
-				continue
-			}
+	// 定时发布的推文由定时任务到点后再发布
+	if post.PublishAt == 0 {
+		onPublishTweet(s.DaoServant, req.User, post, mentionsFrom(req.Users, textsFrom(req.Contents)...))
+	}
+	onLinkPreviewEvent(req.User.ID, req.Contents)
+	formatedPosts, err := s.Ds.RevampPosts([]*ms.PostFormated{post.Format()})
+	if err != nil {
+		logrus.Infof("Ds.RevampPosts err: %s", err)
+		return nil, web.ErrCreatePostFailed
+	}
+	if err = s.PreparePolls(req.User.ID, formatedPosts); err != nil {
+		logrus.Infof("s.PreparePolls err: %s", err)
+		return nil, web.ErrCreatePostFailed
+	}
+	return (*web.CreateTweetResp)(formatedPosts[0]), nil
+}
 
-			// 创建消息提醒
+func (s *privSrv) EditTweet(req *web.EditTweetReq) (_ *web.EditTweetResp, xerr error) {
+	if req.User == nil {
+		return nil, web.ErrNoPermission
+	}
+	post, err := s.Ds.GetPostByID(req.ID)
+	if err != nil {
+		logrus.Errorf("Ds.GetPostByID err: %s", err)
+		return nil, web.ErrGetPostFailed
+	}
+	// 只有作者本人可以编辑动态
+	if post.UserID != req.User.ID {
+		return nil, web.ErrNoPermission
+	}
+	// 锁定的动态与评论一样不允许编辑，管理员除外
+	if post.IsLock == 1 && !req.User.IsAdmin {
+		return nil, web.ErrNoPermission
+	}
+	sensitive, warning, ok := editSensitiveFrom(post, req.IsSensitive, req.ContentWarning)
+	if !ok {
+		return nil, web.ErrInvalidContentWarning
+	}
+	// 未传回复权限时保持不变
+	if req.ReplyPolicy != nil {
+		if !req.ReplyPolicy.IsValid() {
+			return nil, web.ErrInvalidReplyPolicy
+		}
+		post.ReplyPolicy = *req.ReplyPolicy
+	}
+	post.IsSensitive, post.ContentWarning = sensitive, warning
+	// 编辑前的内容，用于判断哪些用户是新@的
+	oldContents, err := s.Ds.GetPostContentsByIDs([]int64{post.ID})
+	if err != nil {
+		logrus.Errorf("Ds.GetPostContentsByIDs err: %s", err)
+		return nil, web.ErrEditPostFailed
+	}
+
+	var mediaContents []string
+	defer func() {
+		if xerr != nil {
+			deleteOssObjects(s.oss, mediaContents)
+		}
+	}()
+	if mediaContents, err = persistMediaContents(s.oss, req.Contents); err != nil {
+		return nil, web.ErrEditPostFailed
+	}
+	contents := make([]*ms.PostContent, 0, len(req.Contents))
+	for _, item := range req.Contents {
+		// 投票发起后不允许修改，下面沿用原投票内容
+		if item.Type == ms.ContentTypePoll {
+			continue
+		}
+		if err := item.Check(s.Ds); err != nil {
+			// 属性非法
+			logrus.Infof("contents check err: %s", err)
+			continue
+		}
+		if item.Type == ms.ContentTypeAttachment && post.AttachmentPrice > 0 {
+			item.Type = ms.ContentTypeChargeAttachment
+		}
+		contents = append(contents, &ms.PostContent{
+			PostID:      post.ID,
+			UserID:      req.User.ID,
+			Content:     item.Content,
+			Type:        item.Type,
+			Sort:        item.Sort,
+			IsSensitive: boolToInt8(item.IsSensitive),
+		})
+	}
+	for _, c := range oldContents {
+		if c.Type == ms.ContentTypePoll {
+			contents = append(contents, &ms.PostContent{
+				PostID:      post.ID,
+				UserID:      req.User.ID,
+				Content:     c.Content,
+				Type:        c.Type,
+				Sort:        c.Sort,
+				IsSensitive: c.IsSensitive,
+			})
+		}
+	}
+	oldTags, newTags := splitNonEmpty(post.Tags), tagsFrom(req.Tags, textsFrom(req.Contents)...)
+	post.Tags = strings.Join(newTags, ",")
+	// 旧的媒体内容仍被历史版本引用，这里不删除
+	if err = s.Ds.EditPost(post, contents); err != nil {
+		logrus.Errorf("Ds.EditPost err: %s", err)
+		return nil, web.ErrEditPostFailed
+	}
+	// 定时推文与私密推文没有计入话题引用数
+	if post.PublishAt == 0 && post.Visibility != core.PostVisitPrivate {
+		s.updatePostTags(req.User.ID, oldTags, newTags)
+	}
+
+	// 定时推文到点发布时才保存@的用户；私密推文不保存@，已有的@及提醒一并移除
+	if post.PublishAt == 0 {
+		var atUsers []string
+		if post.Visibility != core.PostVisitPrivate {
+			atUsers = mentionsFrom(req.Users, textsFrom(req.Contents)...)
+		}
+		// 只提醒本次编辑新增@的用户，编辑前已经@过的用户不再重复提醒
+		for _, user := range saveMentions(s.Ds, &ms.Mention{AuthorID: req.User.ID, PostID: post.ID}, atUsers) {
 			onCreateMessageEvent(&ms.Message{
 				SenderUserID:   req.User.ID,
 				ReceiverUserID: user.ID,
 				Type:           ms.MsgTypePost,
-				Brief:          "在新发布的泡泡动态中@了你",
+				Brief:          "在编辑后的泡泡动态中@了你",
 				PostID:         post.ID,
 			})
 		}
 	}
 	// 推送Search
 	s.PushPostToSearch(post)
+	onLinkPreviewEvent(req.User.ID, req.Contents)
 	formatedPosts, err := s.Ds.RevampPosts([]*ms.PostFormated{post.Format()})
 	if err != nil {
 		logrus.Infof("Ds.RevampPosts err: %s", err)
-		return nil, web.ErrCreatePostFailed
+		return nil, web.ErrEditPostFailed
+	}
+	if err = s.PreparePolls(req.User.ID, formatedPosts); err != nil {
+		logrus.Infof("s.PreparePolls err: %s", err)
+		return nil, web.ErrEditPostFailed
+	}
+	return (*web.EditTweetResp)(formatedPosts[0]), nil
+}
+
+// updatePostTags 更新编辑后推文的话题引用数，宽松处理错误
+func (s *privSrv) updatePostTags(userId int64, oldTags, newTags []string) {
+	added, removed := diffTags(oldTags, newTags)
+	if len(added) > 0 {
+		if _, err := s.Ds.UpsertTags(userId, added); err != nil {
+			logrus.Errorf("Ds.UpsertTags err: %s", err)
+		}
+	}
+	if len(removed) == 0 {
+		return
+	}
+	tags, err := s.Ds.TagsByNames(removed)
+	if err != nil {
+		logrus.Errorf("Ds.TagsByNames err: %s", err)
+		return
 	}
+	ids := make([]int64, 0, len(tags))
+	for _, tag := range tags {
+		ids = append(ids, tag.ID)
+	}
+	if err = s.Ds.DecrTagsById(ids); err != nil {
+		logrus.Errorf("Ds.DecrTagsById err: %s", err)
+	}
+}
+
+// threadHeadFrom 获取续写串推时的串推首条动态
+func (s *privSrv) threadHeadFrom(user *ms.User, parentId int64) (*ms.Post, error) {
+	parent, err := s.Ds.GetPostByID(parentId)
+	if err != nil {
+		logrus.Errorf("Ds.GetPostByID err: %s", err)
+		return nil, web.ErrGetPostFailed
+	}
+	if parent.UserID != user.ID {
+		return nil, web.ErrInvalidThreadParent
+	}
+	if parent.ThreadRootID == 0 {
+		return parent, nil
+	}
+	head, err := s.Ds.GetPostByID(parent.ThreadRootID)
+	if err != nil {
+		logrus.Errorf("Ds.GetPostByID err: %s", err)
+		return nil, web.ErrGetPostFailed
+	}
+	return head, nil
+}
+
+// repostOriginalFrom 获取被转发的原动态，转发一条纯转发动态时指向其原动态
+func (s *privSrv) repostOriginalFrom(user *ms.User, repostId int64) (*ms.Post, error) {
+	original, err := s.Ds.GetPostByID(repostId)
+	if err != nil {
+		logrus.Errorf("Ds.GetPostByID err: %s", err)
+		return nil, web.ErrGetPostFailed
+	}
+	if original.RepostID > 0 {
+		contents, err := s.Ds.GetPostContentsByIDs([]int64{original.ID})
+		if err != nil {
+			logrus.Errorf("Ds.GetPostContentsByIDs err: %s", err)
+			return nil, web.ErrGetPostFailed
+		}
+		if len(contents) == 0 {
+			if original, err = s.Ds.GetPostByID(original.RepostID); err != nil {
+				logrus.Errorf("Ds.GetPostByID err: %s", err)
+				return nil, web.ErrGetPostFailed
+			}
+		}
+	}
+	if err = checkPostViewPermission(user, original, s.DaoServant); err != nil {
+		return nil, err
+	}
+	return original, nil
+}
+
+// onPublishTweet 推文正式发布时的处理，包括标签、用户提醒、转发与串推计数、搜索索引及缓存
+func onPublishTweet(ds *base.DaoServant, user *ms.User, post *ms.Post, atUsers []string) {
+	// 私密推文不创建标签与用户提醒
+	if post.Visibility != core.PostVisitPrivate {
+		// 创建标签
+		ds.Ds.UpsertTags(user.ID, splitNonEmpty(post.Tags))
+
+		// 保存@的用户并创建消息提醒
+		for _, atUser := range saveMentions(ds.Ds, &ms.Mention{AuthorID: user.ID, PostID: post.ID}, atUsers) {
+			onCreateMessageEvent(&ms.Message{
+				SenderUserID:   user.ID,
+				ReceiverUserID: atUser.ID,
+				Type:           ms.MsgTypePost,
+				Brief:          "在新发布的泡泡动态中@了你",
+				PostID:         post.ID,
+			})
+		}
+	}
+	// 更新原动态的转发数并提醒原作者
+	if post.RepostID > 0 {
+		if original, err := ds.Ds.GetPostByID(post.RepostID); err == nil {
+			if err = ds.Ds.IncrPostShareCount(original, 1); err != nil {
+				logrus.Errorf("Ds.IncrPostShareCount err: %s", err)
+			}
+			if original.UserID != user.ID && post.Visibility != core.PostVisitPrivate {
+				onCreateMessageEvent(&ms.Message{
+					SenderUserID:   user.ID,
+					ReceiverUserID: original.UserID,
+					Type:           ms.MsgTypePost,
+					Brief:          "转发了你的泡泡动态",
+					PostID:         post.ID,
+				})
+			}
+		}
+	}
+	// 更新串推数并让串推首条重新浮到时间线前面
+	if post.ThreadRootID > 0 {
+		if head, err := ds.Ds.GetPostByID(post.ThreadRootID); err == nil {
+			if err = ds.Ds.IncrPostThreadCount(head, 1, time.Now().Unix()); err != nil {
+				logrus.Errorf("Ds.IncrPostThreadCount err: %s", err)
+			}
+		}
+	}
+	// 推送Search
+	ds.PushPostToSearch(post)
 	// 缓存处理
 	// TODO: 缓存逻辑合并处理
-	onTrendsActionEvent(_trendsActionCreateTweet, req.User.ID)
-	onTweetActionEvent(_tweetActionCreate, req.User.ID, req.User.Username)
-	return (*web.CreateTweetResp)(formatedPosts[0]), nil
+	onTrendsActionEvent(_trendsActionCreateTweet, user.ID)
+	onTweetActionEvent(_tweetActionCreate, user.ID, user.Username)
+}
+
+func (s *privSrv) ScheduledTweets(req *web.ScheduledTweetsReq) (*web.ScheduledTweetsResp, error) {
+	posts, total, err := s.Ds.ListScheduledTweets(req.UserId, req.PageSize, (req.Page-1)*req.PageSize)
+	if err != nil {
+		logrus.Errorf("Ds.ListScheduledTweets err: %s", err)
+		return nil, web.ErrGetScheduledPostsFailed
+	}
+	postsFormated, err := s.Ds.MergePosts(posts)
+	if err != nil {
+		logrus.Errorf("Ds.MergePosts err: %s", err)
+		return nil, web.ErrGetScheduledPostsFailed
+	}
+	if err = s.PrepareTweets(req.UserId, postsFormated); err != nil {
+		logrus.Errorf("s.PrepareTweets err: %s", err)
+		return nil, web.ErrGetScheduledPostsFailed
+	}
+	resp := base.PageRespFrom(postsFormated, req.Page, req.PageSize, total)
+	return (*web.ScheduledTweetsResp)(resp), nil
+}
+
+func (s *privSrv) ArchivedTweets(req *web.ArchivedTweetsReq) (*web.ArchivedTweetsResp, error) {
+	posts, total, err := s.Ds.ListArchivedTweets(req.UserId, req.PageSize, (req.Page-1)*req.PageSize)
+	if err != nil {
+		logrus.Errorf("Ds.ListArchivedTweets err: %s", err)
+		return nil, web.ErrGetArchivedPostsFailed
+	}
+	postsFormated, err := s.Ds.MergePosts(posts)
+	if err != nil {
+		logrus.Errorf("Ds.MergePosts err: %s", err)
+		return nil, web.ErrGetArchivedPostsFailed
+	}
+	if err = s.PrepareTweets(req.UserId, postsFormated); err != nil {
+		logrus.Errorf("s.PrepareTweets err: %s", err)
+		return nil, web.ErrGetArchivedPostsFailed
+	}
+	resp := base.PageRespFrom(postsFormated, req.Page, req.PageSize, total)
+	return (*web.ArchivedTweetsResp)(resp), nil
+}
+
+func (s *privSrv) RescheduleTweet(req *web.RescheduleTweetReq) (*web.RescheduleTweetResp, error) {
+	post, err := s.scheduledTweetFrom(req.User, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if req.PublishAt <= time.Now().Unix() {
+		return nil, web.ErrInvalidPublishAt
+	}
+	if err = s.Ds.SchedulePost(post, req.PublishAt); err != nil {
+		logrus.Errorf("Ds.SchedulePost err: %s", err)
+		return nil, web.ErrScheduleTweetFailed
+	}
+	return &web.RescheduleTweetResp{
+		PublishAt: post.PublishAt,
+	}, nil
+}
+
+func (s *privSrv) CancelScheduledTweet(req *web.CancelScheduledTweetReq) error {
+	post, err := s.scheduledTweetFrom(req.User, req.ID)
+	if err != nil {
+		return err
+	}
+	return s.deleteScheduledTweet(post, req.User.ID)
+}
+
+// scheduledTweetFrom 获取当前用户待发布的定时推文
+func (s *privSrv) scheduledTweetFrom(user *ms.User, id int64) (*ms.Post, error) {
+	if user == nil {
+		return nil, web.ErrNoPermission
+	}
+	post, err := s.Ds.GetPostByID(id)
+	if err != nil {
+		logrus.Errorf("Ds.GetPostByID err: %s", err)
+		return nil, web.ErrGetPostFailed
+	}
+	if post.UserID != user.ID {
+		return nil, web.ErrNoPermission
+	}
+	if post.PublishAt == 0 {
+		return nil, web.ErrNotScheduledPost
+	}
+	return post, nil
+}
+
+func (s *privSrv) deleteScheduledTweet(post *ms.Post, operatorId int64) error {
+	if err := s.Ds.TrashPost(post, operatorId); err != nil {
+		logrus.Errorf("Ds.TrashPost err: %s", err)
+		return web.ErrDeletePostFailed
+	}
+	return nil
+}
+
+func (s *privSrv) TrashTweets(req *web.TrashTweetsReq) (*web.TrashTweetsResp, error) {
+	items, total, err := trashTweetsFrom(s.Ds, req.UserId, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	resp := base.PageRespFrom(items, req.Page, req.PageSize, total)
+	return (*web.TrashTweetsResp)(resp), nil
+}
+
+// RestoreTweet 从回收站恢复推文，作者只能恢复自己删除的推文，被管理员删除的需由管理员恢复
+func (s *privSrv) RestoreTweet(req *web.RestoreTweetReq) (*web.RestoreTweetResp, error) {
+	if req.User == nil {
+		return nil, web.ErrNoPermission
+	}
+	trash, err := s.Ds.GetPostTrash(req.ID)
+	if err != nil {
+		logrus.Debugf("Ds.GetPostTrash err: %s", err)
+		return nil, web.ErrNotInTrash
+	}
+	if !req.User.IsAdmin && (trash.UserID != req.User.ID || trash.OperatorID != req.User.ID) {
+		return nil, web.ErrNoPermission
+	}
+	post, err := s.Ds.RestorePost(trash)
+	if err != nil {
+		logrus.Errorf("Ds.RestorePost err: %s", err)
+		return nil, web.ErrRestorePostFailed
+	}
+	// 已发布的推文需要恢复删除时撤销的计数、索引与缓存
+	if post.PublishAt == 0 {
+		s.onRestoreTweet(post)
+	}
+	formatedPosts, err := s.Ds.RevampPosts([]*ms.PostFormated{post.Format()})
+	if err != nil {
+		logrus.Infof("Ds.RevampPosts err: %s", err)
+		return nil, web.ErrRestorePostFailed
+	}
+	if err = s.PreparePolls(req.User.ID, formatedPosts); err != nil {
+		logrus.Infof("s.PreparePolls err: %s", err)
+		return nil, web.ErrRestorePostFailed
+	}
+	return (*web.RestoreTweetResp)(formatedPosts[0]), nil
+}
+
+func (s *privSrv) onRestoreTweet(post *ms.Post) {
+	if post.ThreadRootID > 0 {
+		if head, err := s.Ds.GetPostByID(post.ThreadRootID); err == nil {
+			s.Ds.IncrPostThreadCount(head, 1, 0)
+		}
+	}
+	if post.RepostID > 0 {
+		if original, err := s.Ds.GetPostByID(post.RepostID); err == nil {
+			s.Ds.IncrPostShareCount(original, 1)
+		}
+	}
+	s.PushPostToSearch(post)
+	onTrendsActionEvent(_trendsActionCreateTweet, post.UserID)
+	if author, err := s.Ds.GetUserByID(post.UserID); err == nil {
+		onTweetActionEvent(_tweetActionCreate, author.ID, author.Username)
+	}
+}
+
+func (s *privSrv) Drafts(req *web.DraftsReq) (*web.DraftsResp, error) {
+	drafts, total, err := s.Ds.ListUserDrafts(req.UserId, req.PageSize, (req.Page-1)*req.PageSize)
+	if err != nil {
+		logrus.Errorf("Ds.ListUserDrafts err: %s", err)
+		return nil, web.ErrGetDraftsFailed
+	}
+	items := make([]*web.TweetDraft, 0, len(drafts))
+	for _, draft := range drafts {
+		items = append(items, tweetDraftFrom(draft))
+	}
+	resp := base.PageRespFrom(items, req.Page, req.PageSize, total)
+	return (*web.DraftsResp)(resp), nil
+}
+
+func (s *privSrv) CreateDraft(req *web.CreateDraftReq) (*web.CreateDraftResp, error) {
+	if req.User == nil {
+		return nil, web.ErrNoPermission
+	}
+	contents, err := s.draftContentsCheck(req.Contents)
+	if err != nil {
+		return nil, web.ErrCreateDraftFailed
+	}
+	// 草稿中的媒体资源保持临时状态，发布时再持久化
+	draft, err := s.Ds.CreateDraft(&ms.PostDraft{
+		UserID:          req.User.ID,
+		Contents:        contents,
+		Tags:            strings.Join(tagsFrom(req.Tags), ","),
+		Users:           strings.Join(req.Users, ","),
+		AttachmentPrice: req.AttachmentPrice,
+		Visibility:      ms.PostVisibleT(req.Visibility.ToVisibleValue()),
+		IsSensitive:     boolToInt8(req.IsSensitive),
+		ContentWarning:  req.ContentWarning,
+		GroupIds:        joinInt64s(req.GroupIds),
+		IsStory:         boolToInt8(req.IsStory),
+		ExpiresIn:       req.ExpiresIn,
+		ReplyPolicy:     req.ReplyPolicy,
+	})
+	if err != nil {
+		logrus.Errorf("Ds.CreateDraft err: %s", err)
+		return nil, web.ErrCreateDraftFailed
+	}
+	return (*web.CreateDraftResp)(tweetDraftFrom(draft)), nil
+}
+
+func (s *privSrv) UpdateDraft(req *web.UpdateDraftReq) (*web.UpdateDraftResp, error) {
+	draft, err := s.draftFrom(req.User, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	contents, err := s.draftContentsCheck(req.Contents)
+	if err != nil {
+		return nil, web.ErrUpdateDraftFailed
+	}
+	oldMediaContents := mediaContentsFrom(draftContentsFrom(draft))
+	draft.Contents = contents
+	draft.Tags = strings.Join(tagsFrom(req.Tags), ",")
+	draft.Users = strings.Join(req.Users, ",")
+	draft.AttachmentPrice = req.AttachmentPrice
+	draft.Visibility = ms.PostVisibleT(req.Visibility.ToVisibleValue())
+	draft.IsSensitive = boolToInt8(req.IsSensitive)
+	draft.ContentWarning = req.ContentWarning
+	draft.GroupIds = joinInt64s(req.GroupIds)
+	draft.IsStory = boolToInt8(req.IsStory)
+	draft.ExpiresIn = req.ExpiresIn
+	draft.ReplyPolicy = req.ReplyPolicy
+	if err = s.Ds.UpdateDraft(draft); err != nil {
+		logrus.Errorf("Ds.UpdateDraft err: %s", err)
+		return nil, web.ErrUpdateDraftFailed
+	}
+	// 删除草稿中不再使用的媒体资源
+	newMediaContents := make(map[string]struct{})
+	for _, item := range mediaContentsFrom(req.Contents) {
+		newMediaContents[item] = struct{}{}
+	}
+	removedContents := make([]string, 0, len(oldMediaContents))
+	for _, item := range oldMediaContents {
+		if _, exist := newMediaContents[item]; !exist {
+			removedContents = append(removedContents, item)
+		}
+	}
+	deleteUserOssObjects(s.Ds, s.oss, draft.UserID, removedContents)
+	return (*web.UpdateDraftResp)(tweetDraftFrom(draft)), nil
+}
+
+func (s *privSrv) DeleteDraft(req *web.DeleteDraftReq) error {
+	draft, err := s.draftFrom(req.User, req.ID)
+	if err != nil {
+		return err
+	}
+	if err = s.Ds.DeleteDraft(draft); err != nil {
+		logrus.Errorf("Ds.DeleteDraft err: %s", err)
+		return web.ErrDeleteDraftFailed
+	}
+	deleteUserOssObjects(s.Ds, s.oss, draft.UserID, mediaContentsFrom(draftContentsFrom(draft)))
+	return nil
+}
+
+func (s *privSrv) PublishDraft(req *web.PublishDraftReq) (*web.PublishDraftResp, error) {
+	draft, err := s.draftFrom(req.User, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	// 先删除草稿，并发发布同一草稿时只有一个请求能创建推文
+	published, err := s.Ds.PublishDraft(draft)
+	if err != nil {
+		logrus.Errorf("Ds.PublishDraft err: %s", err)
+		return nil, web.ErrPublishDraftFailed
+	} else if !published {
+		return nil, web.ErrGetDraftFailed
+	}
+	tweet := tweetDraftFrom(draft)
+	resp, err := s.CreateTweet(&web.CreateTweetReq{
+		BaseInfo:        req.BaseInfo,
+		Contents:        tweet.Contents,
+		Tags:            tweet.Tags,
+		Users:           tweet.Users,
+		AttachmentPrice: tweet.AttachmentPrice,
+		Visibility:      tweet.Visibility,
+		GroupIds:        tweet.GroupIds,
+		IsSensitive:     tweet.IsSensitive,
+		ContentWarning:  tweet.ContentWarning,
+		IsStory:         tweet.IsStory,
+		ExpiresIn:       tweet.ExpiresIn,
+		ReplyPolicy:     tweet.ReplyPolicy,
+		ClientIP:        req.ClientIP,
+	})
+	if err != nil {
+		// 推文创建失败时恢复草稿
+		if xerr := s.Ds.RestoreDraft(draft); xerr != nil {
+			logrus.Errorf("Ds.RestoreDraft err: %s", xerr)
+		}
+		return nil, err
+	}
+	return (*web.PublishDraftResp)(resp), nil
+}
+
+// draftFrom 获取当前用户的草稿
+func (s *privSrv) draftFrom(user *ms.User, id int64) (*ms.PostDraft, error) {
+	if user == nil {
+		return nil, web.ErrNoPermission
+	}
+	draft, err := s.Ds.GetDraftByID(id)
+	if err != nil {
+		logrus.Errorf("Ds.GetDraftByID err: %s", err)
+		return nil, web.ErrGetDraftFailed
+	}
+	if draft.UserID != user.ID {
+		return nil, web.ErrNoPermission
+	}
+	return draft, nil
+}
+
+// draftContentsCheck 过滤非法内容项并序列化为草稿内容
+func (s *privSrv) draftContentsCheck(items []*web.PostContentItem) (string, error) {
+	contents := make([]*web.PostContentItem, 0, len(items))
+	for _, item := range items {
+		if err := item.Check(s.Ds); err != nil {
+			// 属性非法
+			logrus.Infof("contents check err: %s", err)
+			continue
+		}
+		contents = append(contents, item)
+	}
+	data, err := json.Marshal(contents)
+	if err != nil {
+		logrus.Errorf("json.Marshal draft contents err: %s", err)
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (s *privSrv) VotePoll(req *web.VotePollReq) (*web.VotePollResp, error) {
+	if req.User == nil {
+		return nil, web.ErrNoPermission
+	}
+	poll, err := s.Ds.GetPostPollByID(req.PollID)
+	if err != nil {
+		logrus.Errorf("Ds.GetPostPollByID err: %s", err)
+		return nil, web.ErrGetPollFailed
+	}
+	post, err := s.Ds.GetPostByID(poll.PostID)
+	if err != nil {
+		logrus.Errorf("Ds.GetPostByID err: %s", err)
+		return nil, web.ErrGetPostFailed
+	}
+	if err = checkPostViewPermission(req.User, post, s.DaoServant); err != nil {
+		return nil, err
+	}
+	if poll.IsClosed() {
+		return nil, web.ErrPollClosed
+	}
+	// 每个用户只能投票一次，多选时一次提交多个选项
+	votes, err := s.Ds.ListUserPollVotes(req.User.ID, []int64{poll.ID})
+	if err != nil {
+		logrus.Errorf("Ds.ListUserPollVotes err: %s", err)
+		return nil, web.ErrVotePollFailed
+	}
+	if len(votes) > 0 {
+		return nil, web.ErrPollAlreadyVoted
+	}
+	posts, err := s.Ds.RevampPosts([]*ms.PostFormated{post.Format()})
+	if err != nil || posts[0].Poll == nil {
+		logrus.Errorf("Ds.RevampPosts err: %v", err)
+		return nil, web.ErrGetPollFailed
+	}
+	pollFormated := posts[0].Poll
+	optionIds, err := pollOptionsFrom(pollFormated, req.OptionIDs)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.Ds.VotePostPoll(poll, req.User.ID, optionIds); errors.Is(err, cs.ErrPollAlreadyVoted) {
+		return nil, web.ErrPollAlreadyVoted
+	} else if err != nil {
+		logrus.Errorf("Ds.VotePostPoll err: %s", err)
+		return nil, web.ErrVotePollFailed
+	}
+	for _, option := range pollFormated.Options {
+		for _, id := range optionIds {
+			if option.ID == id {
+				option.VoteCount++
+			}
+		}
+	}
+	pollFormated.VoteCount++
+	pollFormated.Voted, pollFormated.VotedOptions = true, optionIds
+	return (*web.VotePollResp)(pollFormated), nil
+}
+
+func (s *privSrv) CreateTweetReaction(req *web.CreateTweetReactionReq) (*web.CreateTweetReactionResp, error) {
+	resp, err := s.updateTweetReaction((*web.TweetReactionReq)(req), s.Ds.CreatePostReaction)
+	return (*web.CreateTweetReactionResp)(resp), err
+}
+
+func (s *privSrv) DeleteTweetReaction(req *web.DeleteTweetReactionReq) (*web.DeleteTweetReactionResp, error) {
+	resp, err := s.updateTweetReaction((*web.TweetReactionReq)(req), s.Ds.DeletePostReaction)
+	return (*web.DeleteTweetReactionResp)(resp), err
+}
+
+func (s *privSrv) CreateCommentReaction(req *web.CreateCommentReactionReq) (*web.CreateCommentReactionResp, error) {
+	resp, err := s.updateCommentReaction((*web.CommentReactionReq)(req), s.Ds.CreateCommentReaction)
+	return (*web.CreateCommentReactionResp)(resp), err
+}
+
+func (s *privSrv) DeleteCommentReaction(req *web.DeleteCommentReactionReq) (*web.DeleteCommentReactionResp, error) {
+	resp, err := s.updateCommentReaction((*web.CommentReactionReq)(req), s.Ds.DeleteCommentReaction)
+	return (*web.DeleteCommentReactionResp)(resp), err
+}
+
+func (s *privSrv) updateTweetReaction(req *web.TweetReactionReq, fn func(*ms.Post, int64, string) error) (*web.ReactionInfo, error) {
+	if req.User == nil {
+		return nil, web.ErrNoPermission
+	}
+	if !isValidReaction(req.Reaction) {
+		return nil, web.ErrInvalidReaction
+	}
+	post, err := s.Ds.GetPostByID(req.ID)
+	if err != nil {
+		logrus.Errorf("Ds.GetPostByID err: %s", err)
+		return nil, web.ErrGetPostFailed
+	}
+	if err = checkPostViewPermission(req.User, post, s.DaoServant); err != nil {
+		return nil, err
+	}
+	if err = fn(post, req.User.ID, req.Reaction); err != nil {
+		logrus.Errorf("update post reaction err: %s", err)
+		return nil, web.ErrReactionFailed
+	}
+	counts, err := s.Ds.GetPostReactionCounts([]int64{post.ID})
+	if err != nil {
+		logrus.Errorf("Ds.GetPostReactionCounts err: %s", err)
+		return nil, web.ErrReactionFailed
+	}
+	reactions, err := s.Ds.ListUserPostReactions(req.User.ID, []int64{post.ID})
+	if err != nil {
+		logrus.Errorf("Ds.ListUserPostReactions err: %s", err)
+		return nil, web.ErrReactionFailed
+	}
+	resp := &web.ReactionInfo{
+		Reactions:   counts[post.ID],
+		MyReactions: make([]string, 0, len(reactions)),
+	}
+	if resp.Reactions == nil {
+		resp.Reactions = []*ms.ReactionCount{}
+	}
+	for _, r := range reactions {
+		resp.MyReactions = append(resp.MyReactions, r.Reaction)
+	}
+	return resp, nil
+}
+
+func (s *privSrv) updateCommentReaction(req *web.CommentReactionReq, fn func(*ms.Comment, int64, string) error) (*web.ReactionInfo, error) {
+	if req.User == nil {
+		return nil, web.ErrNoPermission
+	}
+	if !isValidReaction(req.Reaction) {
+		return nil, web.ErrInvalidReaction
+	}
+	comment, err := s.Ds.GetCommentByID(req.ID)
+	if err != nil {
+		logrus.Errorf("Ds.GetCommentByID err: %s", err)
+		return nil, web.ErrGetCommentFailed
+	}
+	post, err := s.Ds.GetPostByID(comment.PostID)
+	if err != nil {
+		logrus.Errorf("Ds.GetPostByID err: %s", err)
+		return nil, web.ErrGetPostFailed
+	}
+	if err = checkPostViewPermission(req.User, post, s.DaoServant); err != nil {
+		return nil, err
+	}
+	if err = fn(comment, req.User.ID, req.Reaction); err != nil {
+		logrus.Errorf("update comment reaction err: %s", err)
+		return nil, web.ErrReactionFailed
+	}
+	onCommentActionEvent(comment.PostID, comment.ID, _commentActionReaction)
+	counts, err := s.Ds.GetCommentReactionCounts([]int64{comment.ID})
+	if err != nil {
+		logrus.Errorf("Ds.GetCommentReactionCounts err: %s", err)
+		return nil, web.ErrReactionFailed
+	}
+	reactions, err := s.Ds.ListUserCommentReactions(req.User.ID, []int64{comment.ID})
+	if err != nil {
+		logrus.Errorf("Ds.ListUserCommentReactions err: %s", err)
+		return nil, web.ErrReactionFailed
+	}
+	resp := &web.ReactionInfo{
+		Reactions:   counts[comment.ID],
+		MyReactions: make([]string, 0, len(reactions)),
+	}
+	if resp.Reactions == nil {
+		resp.Reactions = []*ms.ReactionCount{}
+	}
+	for _, r := range reactions {
+		resp.MyReactions = append(resp.MyReactions, r.Reaction)
+	}
+	return resp, nil
 }
 
 func (s *privSrv) DeleteTweet(req *web.DeleteTweetReq) error {
@@ -347,13 +1167,33 @@ func (s *privSrv) DeleteTweet(req *web.DeleteTweetReq) error {
 	if post.UserID != req.User.ID && !req.User.IsAdmin {
 		return web.ErrNoPermission
 	}
-	mediaContents, err := s.Ds.DeletePost(post)
-	if err != nil {
-		logrus.Errorf("Ds.DeletePost delete post failed: %s", err)
+	// 未发布的定时推文没有产生过计数、索引等副作用
+	if post.PublishAt > 0 {
+		return s.deleteScheduledTweet(post, req.User.ID)
+	}
+	// 移入回收站，媒体内容在彻底清除时再删除
+	if err = s.Ds.TrashPost(post, req.User.ID); err != nil {
+		logrus.Errorf("Ds.TrashPost err: %s", err)
 		return web.ErrDeletePostFailed
 	}
-	// 删除推文的媒体内容
-	deleteOssObjects(s.oss, mediaContents)
+	// 删除推文及其评论、回复中的@
+	deleteMentions(s.Ds, &ms.Mention{PostID: post.ID})
+	// 更新串推数，被删除的动态在串推中留下空缺；首条被删除时由下一条接替，串推不会从时间线中消失
+	if post.ThreadRootID > 0 {
+		if head, err := s.Ds.GetPostByID(post.ThreadRootID); err == nil {
+			s.Ds.IncrPostThreadCount(head, -1, 0)
+		}
+	} else if post.ThreadCount > 0 {
+		if _, err := s.Ds.PromoteThreadHead(post); err != nil {
+			logrus.Errorf("Ds.PromoteThreadHead err: %s", err)
+		}
+	}
+	// 更新原动态的转发数
+	if post.RepostID > 0 {
+		if original, err := s.Ds.GetPostByID(post.RepostID); err == nil {
+			s.Ds.IncrPostShareCount(original, -1)
+		}
+	}
 	// 删除索引
 	s.DeleteSearchPost(post)
 	if err != nil {
@@ -382,6 +1222,7 @@ func (s *privSrv) DeleteCommentReply(req *web.DeleteCommentReplyReq) error {
 		logrus.Errorf("s.deletePostCommentReply err: %s", err)
 		return web.ErrDeleteCommentFailed
 	}
+	deleteMentions(s.Ds, &ms.Mention{CommentID: reply.CommentID, ReplyID: reply.ID})
 	// 缓存处理， 宽松处理错误
 	if comment, err := s.Ds.GetCommentByID(reply.CommentID); err == nil {
 		onCommentActionEvent(comment.PostID, comment.ID, _commentActionReplyDelete)
@@ -389,6 +1230,54 @@ func (s *privSrv) DeleteCommentReply(req *web.DeleteCommentReplyReq) error {
 	return nil
 }
 
+func (s *privSrv) EditCommentReply(req *web.EditCommentReplyReq) (*web.EditCommentReplyResp, error) {
+	reply, err := s.Ds.GetCommentReplyByID(req.ID)
+	if err != nil {
+		logrus.Errorf("Ds.GetCommentReplyByID err: %s", err)
+		return nil, web.ErrGetReplyFailed
+	}
+	if err = checkCommentEditable(req.User, reply.UserID, reply.CreatedOn); err != nil {
+		return nil, err
+	}
+	if err = checkReplyContent(req.Content); err != nil {
+		return nil, err
+	}
+	comment, err := s.Ds.GetCommentByID(reply.CommentID)
+	if err != nil {
+		logrus.Errorf("Ds.GetCommentByID err: %s", err)
+		return nil, web.ErrGetCommentFailed
+	}
+	if err = checkCommentPostEditable(req.User, comment.PostID, s.Ds); err != nil {
+		return nil, err
+	}
+	if err = s.Ds.EditCommentReply(reply, req.User.ID, req.Content); err != nil {
+		logrus.Errorf("Ds.EditCommentReply err: %s", err)
+		return nil, web.ErrEditReplyFailed
+	}
+	// 回复的对象与编辑后内容中@的用户，之前已经@过的用户不再重复提醒
+	atUsers := mentionsFrom(nil, reply.Content)
+	if reply.AtUserID > 0 {
+		if user, err := s.Ds.GetUserByID(reply.AtUserID); err == nil {
+			atUsers = mentionsFrom(append([]string{user.Username}, atUsers...))
+		}
+	}
+	src := &ms.Mention{AuthorID: reply.UserID, PostID: comment.PostID, CommentID: comment.ID, ReplyID: reply.ID}
+	for _, user := range saveMentions(s.Ds, src, atUsers) {
+		onCreateMessageEvent(&ms.Message{
+			SenderUserID:   reply.UserID,
+			ReceiverUserID: user.ID,
+			Type:           ms.MsgTypeReply,
+			Brief:          "在编辑后的泡泡评论回复中@了你",
+			PostID:         comment.PostID,
+			CommentID:      comment.ID,
+			ReplyID:        reply.ID,
+		})
+	}
+	// 缓存处理
+	onCommentActionEvent(comment.PostID, comment.ID, _commentActionEdit)
+	return (*web.EditCommentReplyResp)(reply), nil
+}
+
 func (s *privSrv) CreateCommentReply(req *web.CreateCommentReplyReq) (_ *web.CreateCommentReplyResp, xerr error) {
 	var (
 		post     *ms.Post
@@ -397,9 +1286,19 @@ func (s *privSrv) CreateCommentReply(req *web.CreateCommentReplyReq) (_ *web.Cre
 		err      error
 	)
 
+	if err = checkReplyContent(req.Content); err != nil {
+		return nil, err
+	}
 	if post, comment, atUserID, err = s.createPostPreHandler(req.CommentID, req.Uid, req.AtUserID); err != nil {
+		// 评论数与回复权限的限制需要告知用户
+		if err == web.ErrMaxCommentCount || err == web.ErrReplyNotAllowed {
+			return nil, err
+		}
 		return nil, web.ErrCreateReplyFailed
 	}
+	if err = checkPaidPostPermission(req.Uid, post, s.DaoServant); err != nil {
+		return nil, err
+	}
 
 	// 创建评论
 	reply := &ms.CommentReply{
@@ -449,21 +1348,29 @@ func (s *privSrv) CreateCommentReply(req *web.CreateCommentReplyReq) (_ *web.Cre
 			ReplyID:        reply.ID,
 		})
 	}
+	// 回复的对象与回复内容中@的用户
+	atUsers := mentionsFrom(nil, reply.Content)
 	if atUserID > 0 {
-		user, err := s.Ds.GetUserByID(atUserID)
-		if err == nil && user.ID != req.Uid && commentMaster.ID != user.ID && postMaster.ID != user.ID {
-			// 创建消息提醒
-			onCreateMessageEvent(&ms.Message{
-				SenderUserID:   req.Uid,
-				ReceiverUserID: user.ID,
-				Type:           ms.MsgTypeReply,
-				Brief:          "在泡泡评论的回复中@了你",
-				PostID:         post.ID,
-				CommentID:      comment.ID,
-				ReplyID:        reply.ID,
-			})
+		if user, err := s.Ds.GetUserByID(atUserID); err == nil {
+			atUsers = mentionsFrom(append([]string{user.Username}, atUsers...))
 		}
 	}
+	src := &ms.Mention{AuthorID: req.Uid, PostID: post.ID, CommentID: comment.ID, ReplyID: reply.ID}
+	for _, user := range saveMentions(s.Ds, src, atUsers) {
+		// 评论作者与动态作者已经收到回复提醒
+		if user.ID == comment.UserID || user.ID == post.UserID {
+			continue
+		}
+		onCreateMessageEvent(&ms.Message{
+			SenderUserID:   req.Uid,
+			ReceiverUserID: user.ID,
+			Type:           ms.MsgTypeReply,
+			Brief:          "在泡泡评论的回复中@了你",
+			PostID:         post.ID,
+			CommentID:      comment.ID,
+			ReplyID:        reply.ID,
+		})
+	}
 	// 缓存处理
 	onCommentActionEvent(comment.PostID, comment.ID, _commentActionReplyCreate)
 	return (*web.CreateCommentReplyResp)(reply), nil
@@ -494,6 +1401,8 @@ func (s *privSrv) DeleteComment(req *web.DeleteCommentReq) error {
 		logrus.Errorf("Ds.DeleteComment err: %s", err)
 		return web.ErrDeleteCommentFailed
 	}
+	// 删除评论及其回复中的@
+	deleteMentions(s.Ds, &ms.Mention{PostID: comment.PostID, CommentID: comment.ID})
 	onCommentActionEvent(comment.PostID, comment.ID, _commentActionDelete)
 	return nil
 }
@@ -525,6 +1434,9 @@ func (s *privSrv) CreateComment(req *web.CreateCommentReq) (_ *web.CreateComment
 		}
 	}()
 
+	if err = checkCommentContents(req.Contents); err != nil {
+		return nil, err
+	}
 	if mediaContents, err = persistMediaContents(s.oss, req.Contents); err != nil {
 		return nil, xerror.ServerError
 	}
@@ -538,6 +1450,12 @@ func (s *privSrv) CreateComment(req *web.CreateCommentReq) (_ *web.CreateComment
 	if post.CommentCount >= conf.AppSetting.MaxCommentCount {
 		return nil, web.ErrMaxCommentCount
 	}
+	if err = checkPaidPostPermission(req.Uid, post, s.DaoServant); err != nil {
+		return nil, err
+	}
+	if err = checkReplyPermission(req.Uid, post, s.Ds); err != nil {
+		return nil, err
+	}
 	comment := &ms.Comment{
 		PostID: post.ID,
 		UserID: req.Uid,
@@ -587,13 +1505,12 @@ func (s *privSrv) CreateComment(req *web.CreateCommentReq) (_ *web.CreateComment
 			CommentID:      comment.ID,
 		})
 	}
-	for _, u := range req.Users {
-		user, err := s.Ds.GetUserByUsername(u)
-		if err != nil || user.ID == req.Uid || user.ID == postMaster.ID {
+	src := &ms.Mention{AuthorID: req.Uid, PostID: post.ID, CommentID: comment.ID}
+	for _, user := range saveMentions(s.Ds, src, mentionsFrom(req.Users, textsFrom(req.Contents)...)) {
+		// 作者已经收到评论提醒
+		if user.ID == post.UserID {
 			continue
 		}
-
-		// 创建消息提醒
 		onCreateMessageEvent(&ms.Message{
 			SenderUserID:   req.Uid,
 			ReceiverUserID: user.ID,
@@ -608,12 +1525,76 @@ func (s *privSrv) CreateComment(req *web.CreateCommentReq) (_ *web.CreateComment
 	return (*web.CreateCommentResp)(comment), nil
 }
 
+func (s *privSrv) EditComment(req *web.EditCommentReq) (_ *web.EditCommentResp, xerr error) {
+	comment, err := s.Ds.GetCommentByID(req.ID)
+	if err != nil {
+		logrus.Errorf("Ds.GetCommentByID err: %s", err)
+		return nil, web.ErrGetCommentFailed
+	}
+	if err = checkCommentEditable(req.User, comment.UserID, comment.CreatedOn); err != nil {
+		return nil, err
+	}
+	if err = checkCommentContents(req.Contents); err != nil {
+		return nil, err
+	}
+	if err = checkCommentPostEditable(req.User, comment.PostID, s.Ds); err != nil {
+		return nil, err
+	}
+	var mediaContents []string
+	defer func() {
+		if xerr != nil {
+			deleteOssObjects(s.oss, mediaContents)
+		}
+	}()
+	if mediaContents, err = persistMediaContents(s.oss, req.Contents); err != nil {
+		return nil, web.ErrEditCommentFailed
+	}
+	contents := make([]*ms.CommentContent, 0, len(req.Contents))
+	for _, item := range req.Contents {
+		// 检查附件是否是本站资源
+		if item.Type == ms.ContentTypeImage || item.Type == ms.ContentTypeVideo || item.Type == ms.ContentTypeAttachment {
+			if err := s.Ds.CheckAttachment(item.Content); err != nil {
+				continue
+			}
+		}
+		contents = append(contents, &ms.CommentContent{
+			CommentID: comment.ID,
+			UserID:    comment.UserID,
+			Content:   item.Content,
+			Type:      item.Type,
+			Sort:      item.Sort,
+		})
+	}
+	// 旧的媒体内容仍被历史版本引用，这里不删除
+	if err = s.Ds.EditComment(comment, req.User.ID, contents); err != nil {
+		logrus.Errorf("Ds.EditComment err: %s", err)
+		return nil, web.ErrEditCommentFailed
+	}
+	// 之前已经@过的用户不再重复提醒
+	src := &ms.Mention{AuthorID: comment.UserID, PostID: comment.PostID, CommentID: comment.ID}
+	for _, user := range saveMentions(s.Ds, src, mentionsFrom(req.Users, textsFrom(req.Contents)...)) {
+		onCreateMessageEvent(&ms.Message{
+			SenderUserID:   comment.UserID,
+			ReceiverUserID: user.ID,
+			Type:           ms.MsgtypeComment,
+			Brief:          "在编辑后的泡泡评论中@了你",
+			PostID:         comment.PostID,
+			CommentID:      comment.ID,
+		})
+	}
+	// 缓存处理
+	onCommentActionEvent(comment.PostID, comment.ID, _commentActionEdit)
+	resp := comment.Format()
+	resp.Contents = contents
+	return (*web.EditCommentResp)(resp), nil
+}
+
 func (s *privSrv) CollectionTweet(req *web.CollectionTweetReq) (*web.CollectionTweetResp, error) {
 	status := false
 	collection, err := s.Ds.GetUserPostCollection(req.ID, req.Uid)
 	if err != nil {
 		// 创建Star
-		if _, xerr := s.createPostCollection(req.ID, req.Uid); xerr != nil {
+		if _, xerr := s.createPostCollection(req.ID, req.Uid, req.FolderID); xerr != nil {
 			return nil, xerr
 		}
 		status = true
@@ -628,6 +1609,94 @@ func (s *privSrv) CollectionTweet(req *web.CollectionTweetReq) (*web.CollectionT
 	}, nil
 }
 
+func (s *privSrv) MoveCollectionTweet(req *web.MoveCollectionTweetReq) error {
+	collection, err := s.Ds.GetUserPostCollection(req.ID, req.Uid)
+	if err != nil {
+		logrus.Errorf("Ds.GetUserPostCollection err: %s", err)
+		return web.ErrMoveCollectionFailed
+	}
+	folder, xerr := s.collectionFolderFrom(req.Uid, req.FolderID)
+	if xerr != nil {
+		return xerr
+	}
+	if err = s.Ds.MoveCollectionToFolder(collection, folder.ID); err != nil {
+		logrus.Errorf("Ds.MoveCollectionToFolder err: %s", err)
+		return web.ErrMoveCollectionFailed
+	}
+	return nil
+}
+
+func (s *privSrv) CreateCollectionFolder(req *web.CreateCollectionFolderReq) (*web.CreateCollectionFolderResp, error) {
+	name, ok := folderNameFrom(req.Name)
+	if !ok {
+		return nil, web.ErrInvalidFolderName
+	}
+	// 确保默认收藏夹先于自建收藏夹存在
+	if _, err := s.Ds.GetDefaultCollectionFolder(req.Uid); err != nil {
+		logrus.Errorf("Ds.GetDefaultCollectionFolder err: %s", err)
+		return nil, web.ErrCreateFolderFailed
+	}
+	folder, err := s.Ds.CreateCollectionFolder(&ms.PostCollectionFolder{
+		UserID:   req.Uid,
+		Name:     name,
+		IsPublic: boolToInt8(req.IsPublic),
+	})
+	if err != nil {
+		logrus.Errorf("Ds.CreateCollectionFolder err: %s", err)
+		return nil, web.ErrCreateFolderFailed
+	}
+	return (*web.CreateCollectionFolderResp)(folder.Format()), nil
+}
+
+func (s *privSrv) UpdateCollectionFolder(req *web.UpdateCollectionFolderReq) (*web.UpdateCollectionFolderResp, error) {
+	name, ok := folderNameFrom(req.Name)
+	if !ok {
+		return nil, web.ErrInvalidFolderName
+	}
+	folder, xerr := s.collectionFolderFrom(req.Uid, req.ID)
+	if xerr != nil {
+		return nil, xerr
+	}
+	folder.Name, folder.IsPublic = name, boolToInt8(req.IsPublic)
+	if err := s.Ds.UpdateCollectionFolder(folder); err != nil {
+		logrus.Errorf("Ds.UpdateCollectionFolder err: %s", err)
+		return nil, web.ErrUpdateFolderFailed
+	}
+	return (*web.UpdateCollectionFolderResp)(folder.Format()), nil
+}
+
+func (s *privSrv) DeleteCollectionFolder(req *web.DeleteCollectionFolderReq) error {
+	folder, xerr := s.collectionFolderFrom(req.Uid, req.ID)
+	if xerr != nil {
+		return xerr
+	}
+	if folder.IsDefault == 1 {
+		return web.ErrDeleteDefaultFolder
+	}
+	if err := s.Ds.DeleteCollectionFolder(folder); err != nil {
+		logrus.Errorf("Ds.DeleteCollectionFolder err: %s", err)
+		return web.ErrDeleteFolderFailed
+	}
+	return nil
+}
+
+// collectionFolderFrom 获取用户自己的收藏夹，folderID为0时使用默认收藏夹
+func (s *privSrv) collectionFolderFrom(userID, folderID int64) (*ms.PostCollectionFolder, error) {
+	if folderID == 0 {
+		folder, err := s.Ds.GetDefaultCollectionFolder(userID)
+		if err != nil {
+			logrus.Errorf("Ds.GetDefaultCollectionFolder err: %s", err)
+			return nil, xerror.ServerError
+		}
+		return folder, nil
+	}
+	folder, err := s.Ds.GetCollectionFolderByID(folderID)
+	if err != nil || folder.UserID != userID {
+		return nil, web.ErrGetFolderFailed
+	}
+	return folder, nil
+}
+
 func (s *privSrv) StarTweet(req *web.StarTweetReq) (*web.StarTweetResp, error) {
 	status := false
 	star, err := s.Ds.GetUserPostStar(req.ID, req.Uid)
@@ -659,10 +1728,19 @@ func (s *privSrv) VisibleTweet(req *web.VisibleTweetReq) (*web.VisibleTweetResp,
 	if xerr := checkPermision(req.User, post.UserID); xerr != nil {
 		return nil, xerr
 	}
+	groupIds, err := audienceGroupsFrom(s.Ds, post.UserID, req.Visibility, req.GroupIds)
+	if err != nil {
+		return nil, err
+	}
 	if err = s.Ds.VisiblePost(post, req.Visibility.ToVisibleValue()); err != nil {
 		logrus.Warnf("s.Ds.VisiblePost: %s", err)
 		return nil, web.ErrVisblePostFailed
 	}
+	// 不再是分组可见时groupIds为空，一并清除原来所选的分组
+	if err = s.Ds.SetPostAudience(post.ID, groupIds); err != nil {
+		logrus.Warnf("s.Ds.SetPostAudience: %s", err)
+		return nil, web.ErrVisblePostFailed
+	}
 
 	// 推送Search
 	post.Visibility = ms.PostVisibleT(req.Visibility.ToVisibleValue())
@@ -691,6 +1769,32 @@ func (s *privSrv) StickTweet(req *web.StickTweetReq) (*web.StickTweetResp, error
 	}, nil
 }
 
+func (s *privSrv) PinTweet(req *web.PinTweetReq) (*web.PinTweetResp, error) {
+	post, err := s.Ds.GetPostByID(req.ID)
+	if err != nil {
+		logrus.Errorf("Ds.GetPostByID err: %v\n", err)
+		return nil, web.ErrPinPostFailed
+	}
+	// 只能置顶自己的动态，串推后续与未发布的定时动态不在主页展示
+	if post.UserID != req.User.ID {
+		return nil, web.ErrNoPermission
+	}
+	if post.ThreadRootID > 0 || post.PublishAt > 0 {
+		return nil, web.ErrPinPostFailed
+	}
+	if err = s.Ds.PinPost(post, conf.AppSetting.MaxPinnedTweets); errors.Is(err, cs.ErrMaxPinnedPosts) {
+		return nil, web.ErrMaxPinnedPosts
+	} else if err != nil {
+		logrus.Errorf("Ds.PinPost err: %s", err)
+		return nil, web.ErrPinPostFailed
+	}
+	resp := &web.PinTweetResp{}
+	if post.PinnedOn > 0 {
+		resp.PinStatus = 1
+	}
+	return resp, nil
+}
+
 func (s *privSrv) HighlightTweet(req *web.HighlightTweetReq) (res *web.HighlightTweetResp, err error) {
 	if status, xerr := s.Ds.HighlightPost(req.User.ID, req.ID); xerr == nil {
 		res = &web.HighlightTweetResp{
@@ -765,6 +1869,10 @@ func (s *privSrv) createPostPreHandler(commentID int64, userID, atUserID int64)
 		return nil, nil, atUserID, web.ErrMaxCommentCount
 	}
 
+	if err = checkReplyPermission(userID, post, s.Ds); err != nil {
+		return nil, nil, atUserID, err
+	}
+
 	if userID == atUserID {
 		atUserID = 0
 	}
@@ -831,7 +1939,7 @@ func (s *privSrv) deletePostStar(star *ms.PostStar) error {
 	return nil
 }
 
-func (s *privSrv) createPostCollection(postID, userID int64) (*ms.PostCollection, error) {
+func (s *privSrv) createPostCollection(postID, userID, folderID int64) (*ms.PostCollection, error) {
 	post, err := s.Ds.GetPostByID(postID)
 	if err != nil {
 		return nil, xerror.ServerError
@@ -843,7 +1951,11 @@ func (s *privSrv) createPostCollection(postID, userID int64) (*ms.PostCollection
 		return nil, web.ErrNoPermission
 	}
 
-	collection, err := s.Ds.CreatePostCollection(postID, userID)
+	folder, xerr := s.collectionFolderFrom(userID, folderID)
+	if xerr != nil {
+		return nil, xerr
+	}
+	collection, err := s.Ds.CreatePostCollection(postID, userID, folder.ID)
 	if err != nil {
 		return nil, xerror.ServerError
 	}
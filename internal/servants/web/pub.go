@@ -11,11 +11,13 @@ import (
 	"image/color"
 	"image/png"
 	"regexp"
+	"time"
 	"unicode/utf8"
 
 	"github.com/afocus/captcha"
 	"github.com/gofrs/uuid/v5"
 	api "github.com/rocboss/paopao-ce/auto/api/v1"
+	"github.com/rocboss/paopao-ce/internal/conf"
 	"github.com/rocboss/paopao-ce/internal/core/ms"
 	"github.com/rocboss/paopao-ce/internal/model/web"
 	"github.com/rocboss/paopao-ce/internal/servants/base"
@@ -32,8 +34,9 @@ var (
 )
 
 const (
-	_MaxLoginErrTimes = 10
-	_MaxPhoneCaptcha  = 10
+	_MaxLoginErrTimes    = 10
+	_MaxResetPwdErrTimes = 5
+	_MaxPhoneCaptcha     = 10
 )
 
 type pubSrv struct {
@@ -104,7 +107,11 @@ func (s *pubSrv) Register(req *web.RegisterReq) (*web.RegisterResp, error) {
 		logrus.Errorf("scheckPassword err: %v", err)
 		return nil, web.ErrUserRegisterFailed
 	}
-	password, salt := encryptPasswordAndSalt(req.Password)
+	password, salt, err := encryptPasswordAndSalt(req.Password)
+	if err != nil {
+		logrus.Errorf("encryptPasswordAndSalt err: %s", err)
+		return nil, web.ErrUserRegisterFailed
+	}
 	user := &ms.User{
 		Nickname: req.Username,
 		Username: req.Username,
@@ -113,7 +120,7 @@ func (s *pubSrv) Register(req *web.RegisterReq) (*web.RegisterResp, error) {
 		Salt:     salt,
 		Status:   ms.UserStatusNormal,
 	}
-	user, err := s.Ds.CreateUser(user)
+	user, err = s.Ds.CreateUser(user)
 	if err != nil {
 		logrus.Errorf("Ds.CreateUser err: %s", err)
 		return nil, web.ErrUserRegisterFailed
@@ -143,6 +150,10 @@ func (s *pubSrv) Login(req *web.LoginReq) (*web.LoginResp, error) {
 			}
 			// 清空登录计数
 			s.Redis.DelCountLoginErr(ctx, user.ID)
+			// 旧的密码摘要透明升级为当前算法，salt保持不变以免已签发的令牌失效
+			if passwordNeedsUpgrade(user.Password) {
+				s.upgradePassword(user, req.Password)
+			}
 		} else {
 			// 登录错误计数
 			s.Redis.IncrCountLoginErr(ctx, user.ID)
@@ -152,15 +163,239 @@ func (s *pubSrv) Login(req *web.LoginReq) (*web.LoginResp, error) {
 		return nil, xerror.UnauthorizedAuthNotExist
 	}
 
-	token, err := app.GenerateToken(user)
+	// 开启二次验证的账户需要先完成二次验证才能换取访问令牌
+	if enabled := twoFactorEnabled(s.Ds, user.ID); enabled || twoFactorRequired(user) {
+		challengeToken, err := app.GenerateChallengeToken(user, !enabled)
+		if err != nil {
+			logrus.Errorf("app.GenerateChallengeToken err: %v", err)
+			return nil, xerror.UnauthorizedTokenGenerate
+		}
+		twoFactor := web.TwoFactorVerify
+		if !enabled {
+			twoFactor = web.TwoFactorEnroll
+		}
+		return &web.LoginResp{
+			TwoFactor:      twoFactor,
+			ChallengeToken: challengeToken,
+		}, nil
+	}
+	return s.createSession(user, sessionDeviceFrom(req.Device, req.UserAgent), req.ClientIP)
+}
+
+func (s *pubSrv) TwoFactorSetup(req *web.TwoFactorSetupReq) (*web.TwoFactorSetupResp, error) {
+	user, claims, err := s.challengeUserFrom(req.ChallengeToken)
 	if err != nil {
-		logrus.Errorf("app.GenerateToken err: %v", err)
-		return nil, xerror.UnauthorizedTokenGenerate
+		return nil, err
+	}
+	if !claims.Enroll || twoFactorEnabled(s.Ds, user.ID) {
+		return nil, web.ErrUserHasBindOTP
 	}
-	return &web.LoginResp{
-		Token: token,
+	return setupTwoFactor(s.Ds, user)
+}
+
+func (s *pubSrv) TwoFactorLogin(req *web.TwoFactorLoginReq) (*web.TwoFactorLoginResp, error) {
+	user, claims, err := s.challengeUserFrom(req.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+	var recoveryCodes []string
+	if err = limitTwoFactor(s.Redis, user.ID, func() (err error) {
+		if claims.Enroll {
+			recoveryCodes, err = enableTwoFactor(s.Ds, user.ID, req.Code)
+		} else {
+			err = checkTwoFactor(s.Ds, user.ID, req.Code)
+		}
+		return
+	}); err != nil {
+		return nil, err
+	}
+	resp, err := s.createSession(user, sessionDeviceFrom(req.Device, req.UserAgent), req.ClientIP)
+	if err != nil {
+		return nil, err
+	}
+	return &web.TwoFactorLoginResp{
+		LoginResp:     *resp,
+		RecoveryCodes: recoveryCodes,
 	}, nil
+}
+
+// challengeUserFrom 校验挑战令牌，修改密码后之前签发的挑战令牌随之失效
+func (s *pubSrv) challengeUserFrom(token string) (*ms.User, *app.ChallengeClaims, error) {
+	claims, err := app.ParseChallengeToken(token)
+	if err != nil {
+		return nil, nil, web.ErrTwoFactorChallenge
+	}
+	user, err := s.Ds.GetUserByID(claims.UID)
+	if err != nil || app.IssuerFrom(user.Salt) != claims.Issuer {
+		return nil, nil, web.ErrTwoFactorChallenge
+	}
+	if user.Status == ms.UserStatusClosed {
+		return nil, nil, web.ErrUserHasBeenBanned
+	}
+	return user, claims, nil
+}
+
+// createSession 每次登录创建一个新的会话，各设备持有各自的刷新令牌
+func (s *pubSrv) createSession(user *ms.User, device string, ip string) (*web.LoginResp, error) {
+	refreshToken, digest, err := app.GenerateRefreshToken()
+	if err != nil {
+		logrus.Errorf("app.GenerateRefreshToken err: %v", err)
+		return nil, xerror.UnauthorizedTokenGenerate
+	}
+	now := time.Now()
+	session, err := s.Ds.CreateSession(&ms.UserSession{
+		UserId:       user.ID,
+		Device:       device,
+		IP:           ip,
+		IPLoc:        utils.GetIPLoc(ip),
+		RefreshToken: digest,
+		ExpiresOn:    now.Add(conf.JWTSetting.RefreshExpire).Unix(),
+		LastSeenOn:   now.Unix(),
+	})
+	if err != nil {
+		logrus.Errorf("Ds.CreateSession err: %v", err)
+		return nil, xerror.UnauthorizedTokenGenerate
+	}
+	return sessionTokensFrom(user, session.ID, refreshToken)
+}
+
+func (s *pubSrv) RefreshToken(req *web.RefreshTokenReq) (*web.RefreshTokenResp, error) {
+	digest := app.RefreshTokenDigest(req.RefreshToken)
+	session, err := s.Ds.GetSessionByRefreshToken(digest)
+	if err != nil {
+		// 已被轮换掉的刷新令牌再次出现说明令牌可能被盗用，撤销该会话让双方都需要重新登录
+		if rotated, err := s.Ds.GetSessionByRotatedToken(digest); err == nil {
+			logrus.Warnf("refresh token of session[%d] reused, revoke it", rotated.ID)
+			if err = s.Ds.RevokeSession(rotated); err != nil {
+				logrus.Errorf("Ds.RevokeSession err: %s", err)
+			}
+		}
+		return nil, xerror.UnauthorizedTokenError
+	}
+	if session.IsExpired() {
+		return nil, xerror.UnauthorizedTokenTimeout
+	}
+	user, err := s.Ds.GetUserByID(session.UserId)
+	if err != nil {
+		return nil, xerror.UnauthorizedAuthNotExist
+	}
+	if user.Status == ms.UserStatusClosed {
+		return nil, web.ErrUserHasBeenBanned
+	}
+	// 管理员被要求开启二次验证后，未开启的需要重新登录完成设置
+	if twoFactorRequired(user) && !twoFactorEnabled(s.Ds, user.ID) {
+		return nil, web.ErrTwoFactorRequired
+	}
+	refreshToken, newDigest, err := app.GenerateRefreshToken()
+	if err != nil {
+		logrus.Errorf("app.GenerateRefreshToken err: %v", err)
+		return nil, xerror.UnauthorizedTokenGenerate
+	}
+	now := time.Now()
+	if session.IP != req.ClientIP {
+		session.IP, session.IPLoc = req.ClientIP, utils.GetIPLoc(req.ClientIP)
+	}
+	session.RefreshToken = newDigest
+	session.ExpiresOn = now.Add(conf.JWTSetting.RefreshExpire).Unix()
+	session.LastSeenOn = now.Unix()
+	// 旧的刷新令牌只能使用一次，并发刷新时只有一个请求能成功
+	if err = s.Ds.RotateSession(session, digest); err != nil {
+		logrus.Debugf("Ds.RotateSession session[%d] err: %v", session.ID, err)
+		return nil, xerror.UnauthorizedTokenError
+	}
+	resp, err := sessionTokensFrom(user, session.ID, refreshToken)
+	return (*web.RefreshTokenResp)(resp), err
+}
 
+func (s *pubSrv) ForgotPassword(req *web.ForgotPasswordReq) (*web.ForgotPasswordResp, error) {
+	if !_enablePhoneVerify {
+		return nil, web.ErrDisallowResetPassword
+	}
+	ctx := context.Background()
+	// 验证图片验证码
+	if imgCaptcha, err := s.Redis.GetImgCaptcha(ctx, req.ImgCaptchaID); err != nil || imgCaptcha != req.ImgCaptcha {
+		logrus.Debugf("get imgCaptcha err:%s expect:%s got:%s", err, imgCaptcha, req.ImgCaptcha)
+		return nil, web.ErrErrorCaptchaPassword
+	}
+	s.Redis.DelImgCaptcha(ctx, req.ImgCaptchaID)
+
+	// 账户不存在、已封禁或未绑定手机时同样返回成功，避免被用来探测账户
+	user, err := s.Ds.GetUserByUsername(req.Username)
+	if err != nil || user.Model == nil || user.ID <= 0 || user.Status == ms.UserStatusClosed || user.Phone == "" {
+		return &web.ForgotPasswordResp{}, nil
+	}
+	// 今日频次限制与发送失败同样返回成功，不同的错误同样会暴露账户已绑定手机
+	if count, _ := s.Redis.GetCountSmsCaptcha(ctx, user.Phone); count >= _MaxPhoneCaptcha {
+		logrus.Warnf("too many phone captcha send for user: %d", user.ID)
+		return &web.ForgotPasswordResp{}, nil
+	}
+	if err := s.Ds.SendPhoneCaptcha(user.Phone); err != nil {
+		logrus.WithError(err).Errorf("SendPhoneCaptcha failed for user: %d", user.ID)
+		return &web.ForgotPasswordResp{}, nil
+	}
+	// 写入计数缓存
+	s.Redis.IncrCountSmsCaptcha(ctx, user.Phone)
+	return &web.ForgotPasswordResp{}, nil
+}
+
+func (s *pubSrv) ResetPassword(req *web.ResetPasswordReq) error {
+	if !_enablePhoneVerify {
+		return web.ErrDisallowResetPassword
+	}
+	if err := checkPassword(req.Password); err != nil {
+		return err
+	}
+	ctx := context.Background()
+	// 与找回密码一样不区分账户是否存在或绑定手机，避免被用来探测账户
+	user, err := s.Ds.GetUserByUsername(req.Username)
+	if err != nil || user.Model == nil || user.ID <= 0 || user.Phone == "" {
+		return web.ErrErrorPhoneCaptcha
+	}
+	// 使用独立的错误计数避免暴力尝试短信验证码，不影响账户正常登录
+	if count, err := s.Redis.GetCountResetPasswordErr(ctx, user.ID); err == nil && count >= _MaxResetPwdErrTimes {
+		return web.ErrTooManyResetPwdError
+	}
+	// 重置密码的验证码只能使用一次
+	if err = checkPhoneCaptcha(s.Ds, user.Phone, req.Captcha, 1); err != nil {
+		s.Redis.IncrCountResetPasswordErr(ctx, user.ID)
+		return err
+	}
+	if user.Status == ms.UserStatusClosed {
+		return web.ErrUserHasBeenBanned
+	}
+	// 重新生成salt，已签发的访问令牌随之失效
+	password, salt, err := encryptPasswordAndSalt(req.Password)
+	if err != nil {
+		logrus.Errorf("encryptPasswordAndSalt err: %s", err)
+		return web.ErrResetPasswordFailed
+	}
+	user.Password, user.Salt = password, salt
+	if err = s.Ds.UpdateUser(user); err != nil {
+		logrus.Errorf("Ds.UpdateUser err: %s", err)
+		return web.ErrResetPasswordFailed
+	}
+	// 全部设备需要重新登录
+	if err = s.Ds.RevokeAllSessions(user.ID, 0); err != nil {
+		logrus.Errorf("Ds.RevokeAllSessions err: %s", err)
+	}
+	// 密码已重置，之前的登录错误计数一并清除
+	s.Redis.DelCountResetPasswordErr(ctx, user.ID)
+	s.Redis.DelCountLoginErr(ctx, user.ID)
+	return nil
+}
+
+// upgradePassword 使用当前算法重新生成密码摘要，失败时不影响本次登录
+func (s *pubSrv) upgradePassword(user *ms.User, password string) {
+	hashed, err := encryptPassword(password, user.Salt)
+	if err != nil {
+		logrus.Errorf("encryptPassword err: %s", err)
+		return
+	}
+	oldPassword := user.Password
+	user.Password = hashed
+	if err = s.Ds.UpgradeUserPassword(user, oldPassword); err != nil {
+		logrus.Errorf("Ds.UpgradeUserPassword err: %s", err)
+	}
 }
 
 func (s *pubSrv) Version() (*web.VersionResp, error) {
@@ -5,21 +5,57 @@
 package web
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"image"
 	"math/rand"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 	"unicode/utf8"
 
+	"github.com/alimy/tryst/cfg"
 	"github.com/gofrs/uuid/v5"
+	"github.com/rocboss/paopao-ce/internal/conf"
 	"github.com/rocboss/paopao-ce/internal/core"
+	"github.com/rocboss/paopao-ce/internal/core/cs"
 	"github.com/rocboss/paopao-ce/internal/core/ms"
 	"github.com/rocboss/paopao-ce/internal/model/web"
+	"github.com/rocboss/paopao-ce/internal/servants/base"
+	"github.com/rocboss/paopao-ce/pkg/app"
+	"github.com/rocboss/paopao-ce/pkg/hashtag"
+	"github.com/rocboss/paopao-ce/pkg/otp"
+	"github.com/rocboss/paopao-ce/pkg/types"
 	"github.com/rocboss/paopao-ce/pkg/utils"
 	"github.com/rocboss/paopao-ce/pkg/xerror"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
 )
 
+// _mentionRegexp 匹配文本中的@用户名，用户名仅由字母和数字组成
+var _mentionRegexp = regexp.MustCompile(`@([a-zA-Z0-9]+)`)
+
+// argon2id的参数，参考RFC 9106的推荐配置
+const (
+	_argon2Time    = 3
+	_argon2Memory  = 64 * 1024
+	_argon2Threads = 4
+)
+
+// _recoveryCodesSize 开启二次验证时生成的恢复码个数
+const _recoveryCodesSize = 10
+
+// _maxCommentLength 评论与回复的最大字符数，与前端默认的评论长度限制一致
+const _maxCommentLength = 300
+
+// _passwordVerifiers 校验密码时根据摘要中的算法标识选择，校验参数取自摘要本身
+var _passwordVerifiers = []types.PasswordProvider{
+	types.NewBcryptPasswordProvider(bcrypt.DefaultCost),
+	types.NewArgon2idPasswordProvider(_argon2Time, _argon2Memory, _argon2Threads),
+}
+
 var defaultAvatars = []string{
 	"https://assets.paopao.info/public/avatar/default/zoe.png",
 	"https://assets.paopao.info/public/avatar/default/william.png",
@@ -78,6 +114,132 @@ func getRandomAvatar() string {
 	return defaultAvatars[rand.Intn(len(defaultAvatars))]
 }
 
+// checkPhoneCaptcha 校验短信验证码，校验通过后原子地消耗一次使用次数，最多可使用maxTimes次
+func checkPhoneCaptcha(ds core.DataService, phone string, captcha string, maxTimes int) error {
+	c, err := ds.GetLatestPhoneCaptcha(phone)
+	if err != nil {
+		return web.ErrErrorPhoneCaptcha
+	}
+	if c.Captcha != captcha {
+		return web.ErrErrorPhoneCaptcha
+	}
+	if c.ExpiredOn < time.Now().Unix() {
+		return web.ErrErrorPhoneCaptcha
+	}
+	if c.UseTimes >= maxTimes {
+		return web.ErrMaxPhoneCaptchaUseTimes
+	}
+	// 并发请求只有一个能消耗最后一次使用次数
+	if err = ds.UsePhoneCaptcha(c, maxTimes); err != nil {
+		return web.ErrMaxPhoneCaptchaUseTimes
+	}
+	return nil
+}
+
+// twoFactorEnabled 用户是否已开启二次验证，查询失败时按已开启处理，避免跳过二次验证
+func twoFactorEnabled(ds core.DataService, userId int64) bool {
+	userOtp, err := ds.GetUserOTP(userId)
+	if err != nil {
+		logrus.Errorf("Ds.GetUserOTP err: %s", err)
+		return true
+	}
+	return userOtp != nil && userOtp.IsEnabled()
+}
+
+// twoFactorRequired 可配置管理员账户必须开启二次验证
+func twoFactorRequired(user *ms.User) bool {
+	return user.IsAdmin && conf.AppSetting.AdminTwoFactor
+}
+
+// setupTwoFactor 生成待确认的二次验证密钥
+func setupTwoFactor(ds core.DataService, user *ms.User) (*web.TwoFactorSetupResp, error) {
+	secret, err := otp.GenerateSecret()
+	if err != nil {
+		logrus.Errorf("otp.GenerateSecret err: %s", err)
+		return nil, web.ErrTwoFactorFailed
+	}
+	if _, err = ds.SetupUserOTP(user.ID, secret); err != nil {
+		logrus.Errorf("Ds.SetupUserOTP err: %s", err)
+		return nil, web.ErrTwoFactorFailed
+	}
+	return &web.TwoFactorSetupResp{
+		Secret: secret,
+		URI:    otp.URI(conf.AppSetting.TwoFactorIssuer, user.Username, secret),
+	}, nil
+}
+
+// enableTwoFactor 确认验证码后开启二次验证，并生成恢复码
+func enableTwoFactor(ds core.DataService, userId int64, code string) ([]string, error) {
+	userOtp, err := ds.GetUserOTP(userId)
+	if err != nil || userOtp == nil {
+		return nil, web.ErrUserNoBindOTP
+	}
+	if userOtp.IsEnabled() {
+		return nil, web.ErrUserHasBindOTP
+	}
+	counter, ok := otp.Validate(userOtp.Secret, code, time.Now(), 1)
+	if !ok {
+		return nil, web.ErrUserOTPInvalid
+	}
+	if err = ds.EnableUserOTP(userOtp, counter); err != nil {
+		logrus.Errorf("Ds.EnableUserOTP err: %s", err)
+		return nil, web.ErrTwoFactorFailed
+	}
+	return resetRecoveryCodes(ds, userId)
+}
+
+// resetRecoveryCodes 生成新的恢复码，只保存摘要，明文仅返回这一次
+func resetRecoveryCodes(ds core.DataService, userId int64) ([]string, error) {
+	codes, err := otp.GenerateRecoveryCodes(_recoveryCodesSize)
+	if err != nil {
+		logrus.Errorf("otp.GenerateRecoveryCodes err: %s", err)
+		return nil, web.ErrTwoFactorFailed
+	}
+	digests := make([]string, 0, len(codes))
+	for _, code := range codes {
+		digests = append(digests, otp.RecoveryCodeDigest(code))
+	}
+	if err = ds.ResetRecoveryCodes(userId, digests); err != nil {
+		logrus.Errorf("Ds.ResetRecoveryCodes err: %s", err)
+		return nil, web.ErrTwoFactorFailed
+	}
+	return codes, nil
+}
+
+// limitTwoFactor 与登录共用错误计数执行二次验证，避免暴力尝试验证码
+func limitTwoFactor(redis core.RedisCache, userId int64, check func() error) error {
+	ctx := context.Background()
+	if count, err := redis.GetCountLoginErr(ctx, userId); err == nil && count >= _MaxLoginErrTimes {
+		return web.ErrTooManyLoginError
+	}
+	if err := check(); err != nil {
+		redis.IncrCountLoginErr(ctx, userId)
+		return err
+	}
+	redis.DelCountLoginErr(ctx, userId)
+	return nil
+}
+
+// checkTwoFactor 校验已开启的二次验证，code可以是验证码或一次性恢复码
+func checkTwoFactor(ds core.DataService, userId int64, code string) error {
+	userOtp, err := ds.GetUserOTP(userId)
+	if err != nil || userOtp == nil || !userOtp.IsEnabled() {
+		return web.ErrUserNoBindOTP
+	}
+	if code = strings.TrimSpace(code); len(code) == otp.Digits {
+		counter, ok := otp.Validate(userOtp.Secret, code, time.Now(), 1)
+		// 同一验证码只能使用一次
+		if !ok || ds.UseUserOTP(userOtp, counter) != nil {
+			return web.ErrUserOTPInvalid
+		}
+		return nil
+	}
+	if err = ds.UseRecoveryCode(userId, otp.RecoveryCodeDigest(code)); err != nil {
+		return web.ErrUserOTPInvalid
+	}
+	return nil
+}
+
 // checkPassword 密码检查
 func checkPassword(password string) error {
 	// 检测用户是否合规
@@ -87,16 +249,50 @@ func checkPassword(password string) error {
 	return nil
 }
 
-// ValidPassword 检查密码是否一致
+// newPasswordProvider 根据功能项选择密码摘要算法，默认使用bcrypt，Auth:MD5仅用于兼容
+func newPasswordProvider() types.PasswordProvider {
+	switch {
+	case cfg.If("Auth:MD5"):
+		return nil
+	case cfg.If("Auth:Argon2"):
+		return types.NewArgon2idPasswordProvider(_argon2Time, _argon2Memory, _argon2Threads)
+	default:
+		return types.NewBcryptPasswordProvider(bcrypt.DefaultCost)
+	}
+}
+
+// ValidPassword 检查密码是否一致，根据摘要中的算法标识选择算法，没有标识的为旧的MD5摘要
 func validPassword(dbPassword, password, salt string) bool {
+	for _, p := range _passwordVerifiers {
+		if p.Match([]byte(dbPassword)) {
+			return p.Compare([]byte(dbPassword), []byte(password)) == nil
+		}
+	}
 	return strings.Compare(dbPassword, utils.EncodeMD5(utils.EncodeMD5(password)+salt)) == 0
 }
 
-// encryptPasswordAndSalt 密码加密&生成salt
-func encryptPasswordAndSalt(password string) (string, string) {
+// passwordNeedsUpgrade 密码摘要是否需要升级为当前配置的算法
+func passwordNeedsUpgrade(dbPassword string) bool {
+	return _passwordProvider != nil && !_passwordProvider.Match([]byte(dbPassword))
+}
+
+// encryptPassword 使用当前配置的算法加密密码，只有MD5算法使用salt
+func encryptPassword(password string, salt string) (string, error) {
+	if _passwordProvider == nil {
+		return utils.EncodeMD5(utils.EncodeMD5(password) + salt), nil
+	}
+	res, err := _passwordProvider.Generate([]byte(password))
+	if err != nil {
+		return "", err
+	}
+	return string(res), nil
+}
+
+// encryptPasswordAndSalt 密码加密&生成salt，salt同时用于令牌的强制下线机制
+func encryptPasswordAndSalt(password string) (string, string, error) {
 	salt := uuid.Must(uuid.NewV4()).String()[:8]
-	password = utils.EncodeMD5(utils.EncodeMD5(password) + salt)
-	return password, salt
+	password, err := encryptPassword(password, salt)
+	return password, salt, err
 }
 
 // deleteOssObjects 删除推文的媒体内容, 宽松处理错误(就是不处理), 后续完善
@@ -114,6 +310,19 @@ func deleteOssObjects(oss core.ObjectStorageService, mediaContents []string) {
 	}
 }
 
+// deleteUserOssObjects 只删除由该用户上传的媒体资源，避免草稿中引用的他人资源被误删
+func deleteUserOssObjects(ds core.DataService, oss core.ObjectStorageService, userId int64, mediaContents []string) {
+	if len(mediaContents) == 0 {
+		return
+	}
+	contents, err := ds.FilterUserAttachments(userId, mediaContents)
+	if err != nil {
+		logrus.Warnf("Ds.FilterUserAttachments err: %s", err)
+		return
+	}
+	deleteOssObjects(oss, contents)
+}
+
 // persistMediaContents 获取媒体内容并持久化
 func persistMediaContents(oss core.ObjectStorageService, contents []*web.PostContentItem) (items []string, err error) {
 	items = make([]string, 0, len(contents))
@@ -136,6 +345,120 @@ func persistMediaContents(oss core.ObjectStorageService, contents []*web.PostCon
 	return
 }
 
+// mediaContentsFrom 获取内容项中的媒体资源地址
+func mediaContentsFrom(contents []*web.PostContentItem) []string {
+	items := make([]string, 0, len(contents))
+	for _, item := range contents {
+		switch item.Type {
+		case ms.ContentTypeImage,
+			ms.ContentTypeVideo,
+			ms.ContentTypeAudio,
+			ms.ContentTypeAttachment,
+			ms.ContentTypeChargeAttachment:
+			items = append(items, item.Content)
+		}
+	}
+	return items
+}
+
+// draftContentsFrom 解析草稿中保存的内容项
+func draftContentsFrom(draft *ms.PostDraft) []*web.PostContentItem {
+	var contents []*web.PostContentItem
+	if err := json.Unmarshal([]byte(draft.Contents), &contents); err != nil {
+		logrus.Warnf("json.Unmarshal draft(%d) contents err: %s", draft.ID, err)
+	}
+	return contents
+}
+
+func tweetDraftFrom(draft *ms.PostDraft) *web.TweetDraft {
+	return &web.TweetDraft{
+		ID:              draft.ID,
+		Contents:        draftContentsFrom(draft),
+		Tags:            splitNonEmpty(draft.Tags),
+		Users:           splitNonEmpty(draft.Users),
+		AttachmentPrice: draft.AttachmentPrice,
+		Visibility:      web.TweetVisibleType(draft.Visibility.ToOutValue()),
+		GroupIds:        splitInt64s(draft.GroupIds),
+		IsSensitive:     draft.IsSensitive > 0,
+		ContentWarning:  draft.ContentWarning,
+		IsStory:         draft.IsStory > 0,
+		ExpiresIn:       draft.ExpiresIn,
+		ReplyPolicy:     draft.ReplyPolicy,
+		CreatedOn:       draft.CreatedOn,
+		ModifiedOn:      draft.ModifiedOn,
+	}
+}
+
+func splitNonEmpty(s string) []string {
+	res := []string{}
+	for _, item := range strings.Split(s, ",") {
+		if item != "" {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+func joinInt64s(ids []int64) string {
+	items := make([]string, 0, len(ids))
+	for _, id := range ids {
+		items = append(items, strconv.FormatInt(id, 10))
+	}
+	return strings.Join(items, ",")
+}
+
+func splitInt64s(s string) []int64 {
+	res := []int64{}
+	for _, item := range splitNonEmpty(s) {
+		if id, err := strconv.ParseInt(item, 10, 64); err == nil {
+			res = append(res, id)
+		}
+	}
+	return res
+}
+
+// pollSpecFrom 获取内容项中的投票，只取第一个投票内容项，没有投票时返回nil
+func pollSpecFrom(contents []*web.PostContentItem, publishAt int64) (*web.PollSpec, error) {
+	for _, item := range contents {
+		if item.Type != ms.ContentTypePoll {
+			continue
+		}
+		spec, err := item.ParsePoll()
+		if err != nil {
+			return nil, web.ErrInvalidPoll.WithDetails(err.Error())
+		}
+		// 定时发布的推文，投票截止时间必须晚于发布时间
+		if spec.ExpiresAt > 0 && publishAt > 0 && spec.ExpiresAt <= publishAt {
+			return nil, web.ErrInvalidPoll.WithDetails("投票截止时间早于发布时间")
+		}
+		return spec, nil
+	}
+	return nil, nil
+}
+
+// pollOptionsFrom 校验用户选择的投票选项，单选只能选择一项
+func pollOptionsFrom(poll *ms.PostPollFormated, optionIds []int64) ([]int64, error) {
+	options := make(map[int64]struct{}, len(poll.Options))
+	for _, option := range poll.Options {
+		options[option.ID] = struct{}{}
+	}
+	res := make([]int64, 0, len(optionIds))
+	selected := make(map[int64]struct{}, len(optionIds))
+	for _, id := range optionIds {
+		if _, exist := options[id]; !exist {
+			return nil, web.ErrInvalidPollOptions
+		}
+		if _, exist := selected[id]; !exist {
+			selected[id] = struct{}{}
+			res = append(res, id)
+		}
+	}
+	if len(res) == 0 || (!poll.Multiple && len(res) > 1) {
+		return nil, web.ErrInvalidPollOptions
+	}
+	return res, nil
+}
+
 func fileCheck(uploadType string, size int64) error {
 	if uploadType != "public/video" &&
 		uploadType != "public/image" &&
@@ -188,17 +511,139 @@ func getImageSize(img image.Rectangle) (int, int) {
 	return width, height
 }
 
-func tagsFrom(originTags []string) []string {
+// tagsFrom 规范化显式指定的话题并合并文本中提取的话题，去重后返回
+func tagsFrom(originTags []string, texts ...string) []string {
 	tags := make([]string, 0, len(originTags))
-	for _, tag := range originTags {
-		// TODO: 优化tag有效性检测
-		if tag = strings.TrimSpace(tag); len(tag) > 0 {
+	seen := make(map[string]struct{}, len(originTags))
+	add := func(tag string) {
+		if _, exist := seen[tag]; !exist {
+			seen[tag] = struct{}{}
 			tags = append(tags, tag)
 		}
 	}
+	for _, tag := range originTags {
+		if tag, ok := hashtag.Normalize(tag); ok {
+			add(tag)
+		}
+	}
+	for _, text := range texts {
+		for _, tag := range hashtag.Extract(text) {
+			add(tag)
+		}
+	}
 	return tags
 }
 
+// diffTags 比较新旧话题，返回新增与移除的话题
+func diffTags(oldTags, newTags []string) (added []string, removed []string) {
+	olds := make(map[string]struct{}, len(oldTags))
+	for _, tag := range oldTags {
+		olds[tag] = struct{}{}
+	}
+	news := make(map[string]struct{}, len(newTags))
+	for _, tag := range newTags {
+		news[tag] = struct{}{}
+		if _, exist := olds[tag]; !exist {
+			added = append(added, tag)
+		}
+	}
+	for _, tag := range oldTags {
+		if _, exist := news[tag]; !exist {
+			removed = append(removed, tag)
+		}
+	}
+	return
+}
+
+// mentionedUsernames 从文本内容中提取@的用户名
+func mentionedUsernames(contents []*ms.PostContent) []string {
+	var texts []string
+	for _, c := range contents {
+		if c.Type == ms.ContentTypeText {
+			texts = append(texts, c.Content)
+		}
+	}
+	return mentionsFrom(nil, texts...)
+}
+
+// mentionsFrom 合并显式指定的用户名与文本中@的用户名，去重后返回
+func mentionsFrom(users []string, texts ...string) []string {
+	var usernames []string
+	seen := make(map[string]struct{})
+	add := func(username string) {
+		if _, exist := seen[username]; !exist && username != "" {
+			seen[username] = struct{}{}
+			usernames = append(usernames, username)
+		}
+	}
+	for _, u := range users {
+		add(strings.TrimSpace(u))
+	}
+	for _, text := range texts {
+		for _, match := range _mentionRegexp.FindAllStringSubmatch(text, -1) {
+			add(match[1])
+		}
+	}
+	return usernames
+}
+
+// textsFrom 获取请求内容中的文本
+func textsFrom(items []*web.PostContentItem) []string {
+	var texts []string
+	for _, item := range items {
+		if item.Type == ms.ContentTypeText {
+			texts = append(texts, item.Content)
+		}
+	}
+	return texts
+}
+
+// checkCommentContents 评论不能为空，文本总长度不超过_maxCommentLength个字符，与前端的限制一致
+func checkCommentContents(items []*web.PostContentItem) error {
+	size, empty := 0, true
+	for _, item := range items {
+		if item.Type == ms.ContentTypeText {
+			size += utf8.RuneCountInString(item.Content)
+			if strings.TrimSpace(item.Content) != "" {
+				empty = false
+			}
+		} else if item.Content != "" {
+			empty = false
+		}
+	}
+	if empty {
+		return web.ErrEmptyCommentContent
+	}
+	if size > _maxCommentLength {
+		return web.ErrCommentContentTooLong
+	}
+	return nil
+}
+
+// checkReplyContent 评论回复不能为空且不超过_maxCommentLength个字符
+func checkReplyContent(content string) error {
+	if strings.TrimSpace(content) == "" {
+		return web.ErrEmptyCommentContent
+	}
+	if utf8.RuneCountInString(content) > _maxCommentLength {
+		return web.ErrCommentContentTooLong
+	}
+	return nil
+}
+
+// checkCommentPostEditable 动态已删除时不能再编辑其下的评论，锁定的动态仅管理员可以编辑评论
+func checkCommentPostEditable(user *ms.User, postId int64, ds core.DataService) error {
+	post, err := ds.GetPostByID(postId)
+	if err != nil {
+		logrus.Errorf("Ds.GetPostByID err: %s", err)
+		return web.ErrGetPostFailed
+	}
+	if post.IsLock == 1 && !user.IsAdmin {
+		return web.ErrNoPermission
+	}
+	return nil
+}
+
 // checkPermision 检查是否拥有者或管理员
 func checkPermision(user *ms.User, targetUserId int64) error {
 	if user == nil || (user.ID != targetUserId && !user.IsAdmin) {
@@ -207,8 +652,190 @@ func checkPermision(user *ms.User, targetUserId int64) error {
 	return nil
 }
 
+// folderNameFrom 规范化收藏夹名称，名称长度需在1~64个字符之间
+func folderNameFrom(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	if size := utf8.RuneCountInString(name); size == 0 || size > 64 {
+		return "", false
+	}
+	return name, true
+}
+
+func boolToInt8(b bool) int8 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// isValidReaction 检查是否为站点支持的表情回应
+func isValidReaction(reaction string) bool {
+	for _, r := range conf.WebProfileSetting.Reactions {
+		if r == reaction {
+			return true
+		}
+	}
+	return false
+}
+
+// sensitiveFrom 规范化敏感标记与内容警告，填写了内容警告的动态视为作者标记为敏感
+func sensitiveFrom(isSensitive bool, warning string) (ms.PostSensitiveT, string, bool) {
+	warning = strings.TrimSpace(warning)
+	if utf8.RuneCountInString(warning) > 255 {
+		return ms.PostSensitiveNone, "", false
+	}
+	if isSensitive || warning != "" {
+		return ms.PostSensitiveByAuthor, warning, true
+	}
+	return ms.PostSensitiveNone, "", true
+}
+
+// editSensitiveFrom 编辑动态时的敏感标记与内容警告，未传的字段保持不变，
+// 管理员强制标记的敏感动态作者不能取消标记，只能修改内容警告
+func editSensitiveFrom(post *ms.Post, isSensitive *bool, warning *string) (ms.PostSensitiveT, string, bool) {
+	if isSensitive == nil && warning == nil {
+		return post.IsSensitive, post.ContentWarning, true
+	}
+	sensitive, contentWarning := post.IsSensitive != ms.PostSensitiveNone, post.ContentWarning
+	if isSensitive != nil {
+		sensitive = *isSensitive
+		// 只传了取消标记时一并清除原有的内容警告
+		if !sensitive && warning == nil {
+			contentWarning = ""
+		}
+	}
+	if warning != nil {
+		contentWarning = *warning
+	}
+	res, contentWarning, ok := sensitiveFrom(sensitive, contentWarning)
+	if !ok {
+		return res, contentWarning, false
+	}
+	if post.IsSensitive == ms.PostSensitiveByAdmin {
+		res = ms.PostSensitiveByAdmin
+		if contentWarning == "" {
+			contentWarning = post.ContentWarning
+		}
+	}
+	return res, contentWarning, true
+}
+
+// expiresInFrom 限时推文的有效时长，未指定时使用默认时长，返回0表示不过期
+func expiresInFrom(isStory bool, expiresIn int64) (int64, bool) {
+	if expiresIn == 0 && isStory {
+		expiresIn = conf.AppSetting.StoryDefaultTTL
+	}
+	if expiresIn < 0 || expiresIn > conf.AppSetting.StoryMaxTTL {
+		return 0, false
+	}
+	return expiresIn, true
+}
+
+// replyCursorFrom 解析回复列表的游标，格式为"回复ID.点赞数"，为空时从头开始
+func replyCursorFrom(cursor string) (*cs.ReplyCursor, bool) {
+	if cursor == "" {
+		return nil, true
+	}
+	id, thumbs, found := strings.Cut(cursor, ".")
+	if !found {
+		return nil, false
+	}
+	replyId, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || replyId <= 0 {
+		return nil, false
+	}
+	thumbsUpCount, err := strconv.ParseInt(thumbs, 10, 32)
+	if err != nil {
+		return nil, false
+	}
+	return &cs.ReplyCursor{
+		ID:            replyId,
+		ThumbsUpCount: int32(thumbsUpCount),
+	}, true
+}
+
+func replyCursorString(reply *ms.CommentReplyFormated) string {
+	return fmt.Sprintf("%d.%d", reply.ID, reply.ThumbsUpCount)
+}
+
+// checkCommentEditable 作者只能在发布后的编辑时限内编辑评论或回复，管理员不受限制
+func checkCommentEditable(user *ms.User, authorId int64, createdOn int64) error {
+	if user == nil {
+		return web.ErrNoPermission
+	}
+	if user.IsAdmin {
+		return nil
+	}
+	if user.ID != authorId {
+		return web.ErrNoPermission
+	}
+	if time.Now().Unix()-createdOn > conf.AppSetting.CommentEditWindow {
+		return web.ErrCommentEditExpired
+	}
+	return nil
+}
+
+// contactGroupNameFrom 分组名称去除首尾空白后不能为空且不超过32个字符
+func contactGroupNameFrom(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > 32 {
+		return "", false
+	}
+	return name, true
+}
+
+// audienceGroupsFrom 分组可见的推文至少需要选择一个自己创建的分组，其他可见性不需要分组
+func audienceGroupsFrom(ds core.DataService, userId int64, visibility web.TweetVisibleType, groupIds []int64) ([]int64, error) {
+	if visibility != web.TweetVisitGroup {
+		return nil, nil
+	}
+	res := make([]int64, 0, len(groupIds))
+	seen := make(map[int64]struct{}, len(groupIds))
+	for _, id := range groupIds {
+		if _, exist := seen[id]; exist {
+			continue
+		}
+		group, err := ds.GetContactGroup(id)
+		if err != nil || group.UserId != userId {
+			return nil, web.ErrInvalidAudienceGroups
+		}
+		seen[id] = struct{}{}
+		res = append(res, id)
+	}
+	if len(res) == 0 {
+		return nil, web.ErrInvalidAudienceGroups
+	}
+	return res, nil
+}
+
+// checkReplyPermission 检查用户是否可以评论或回复推文，作者本人总是可以回复
+func checkReplyPermission(userId int64, post *ms.Post, ds core.DataService) error {
+	if userId == post.UserID {
+		return nil
+	}
+	allowed := true
+	switch post.ReplyPolicy {
+	case ms.PostReplyFollowers:
+		allowed = ds.IsFollow(userId, post.UserID)
+	case ms.PostReplyFriends:
+		allowed = ds.IsFriend(post.UserID, userId)
+	case ms.PostReplyMentioned:
+		allowed = ds.IsMentioned(&ms.Mention{PostID: post.ID}, userId)
+	case ms.PostReplyNobody:
+		allowed = false
+	}
+	if !allowed {
+		return web.ErrReplyNotAllowed
+	}
+	return nil
+}
+
 // checkPostViewPermission 检查当前用户是否可读指定post
-func checkPostViewPermission(user *ms.User, post *ms.Post, ds core.DataService) error {
+func checkPostViewPermission(user *ms.User, post *ms.Post, ds *base.DaoServant) error {
+	// 未发布的定时推文与已过期的限时推文仅作者可见
+	if (post.PublishAt > 0 || post.IsExpired()) && (user == nil || (user.ID != post.UserID && !user.IsAdmin)) {
+		return web.ErrNoPermission
+	}
 	if post.Visibility == core.PostVisitPublic {
 		return nil
 	}
@@ -225,11 +852,86 @@ func checkPostViewPermission(user *ms.User, post *ms.Post, ds core.DataService)
 		return web.ErrNoPermission
 	}
 
+	if post.Visibility.IsPaid() {
+		return checkPaidPostPermission(user.ID, post, ds)
+	}
+
+	// 分组可见的推文只有被作者加入所选分组的好友可见
+	if post.Visibility == core.PostVisitGroup && !ds.Ds.IsPostAudience(post.ID, user.ID) {
+		return web.ErrNoPermission
+	}
+
 	if post.Visibility == core.PostVisitFriend {
-		if !ds.IsFriend(post.UserID, user.ID) && !ds.IsFriend(user.ID, post.UserID) {
+		if !ds.Ds.IsFriend(post.UserID, user.ID) && !ds.Ds.IsFriend(user.ID, post.UserID) {
+			return web.ErrNoPermission
+		}
+	}
+	if post.Visibility == core.PostVisitFollowing {
+		if !ds.Ds.IsFollow(user.ID, post.UserID) {
 			return web.ErrNoPermission
 		}
 	}
-	// TODO: add following check logic
 	return nil
 }
+
+// checkPaidPostPermission 检查用户是否可以看到付费可见推文的完整内容，其他可见性的推文不在这里检查
+func checkPaidPostPermission(userId int64, post *ms.Post, ds *base.DaoServant) error {
+	if !post.Visibility.IsPaid() || userId == post.UserID {
+		return nil
+	}
+	if userId <= 0 {
+		return web.ErrNotSponsor
+	}
+	user, err := ds.Ds.GetUserByID(userId)
+	if err != nil {
+		logrus.Errorf("Ds.GetUserByID err: %s", err)
+		return web.ErrNoPermission
+	}
+	act := ms.ActViewChargeTweet
+	if post.Visibility == ms.PostVisitSubscription {
+		act = ms.ActViewSubscriptionTweet
+	}
+	if !ds.Ams.IsAllow(user, &ms.Action{Act: act, UserId: post.UserID}) {
+		return web.ErrNotSponsor
+	}
+	return nil
+}
+
+// trashTweetsFrom 获取回收站中的推文并补充彻底清除时间，userId为0时获取全部用户的
+func trashTweetsFrom(ds core.DataService, userId int64, page, pageSize int) ([]*ms.PostTrashFormated, int64, error) {
+	items, total, err := ds.ListPostTrash(userId, pageSize, (page-1)*pageSize)
+	if err != nil {
+		logrus.Errorf("Ds.ListPostTrash err: %s", err)
+		return nil, 0, web.ErrGetTrashFailed
+	}
+	retention := int64(conf.AppSetting.TrashRetentionDays) * 86400
+	for _, item := range items {
+		item.PurgeOn = item.TrashedOn + retention
+	}
+	return items, total, nil
+}
+
+// sessionDeviceFrom 登录设备名称，未指定时使用User-Agent
+func sessionDeviceFrom(device string, userAgent string) string {
+	if device = strings.TrimSpace(device); device == "" {
+		device = userAgent
+	}
+	if runes := []rune(device); len(runes) > 255 {
+		device = string(runes[:255])
+	}
+	return device
+}
+
+// sessionTokensFrom 为登录会话签发访问令牌
+func sessionTokensFrom(user *ms.User, sessionId int64, refreshToken string) (*web.LoginResp, error) {
+	token, err := app.GenerateToken(user, sessionId)
+	if err != nil {
+		logrus.Errorf("app.GenerateToken err: %v", err)
+		return nil, xerror.UnauthorizedTokenGenerate
+	}
+	return &web.LoginResp{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(conf.JWTSetting.Expire / time.Second),
+	}, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package web
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rocboss/paopao-ce/internal/core/ms"
+	"github.com/rocboss/paopao-ce/internal/model/web"
+)
+
+var _ = Describe("editSensitiveFrom", func() {
+	boolPtr := func(v bool) *bool { return &v }
+	stringPtr := func(v string) *string { return &v }
+
+	It("keeps the content warning when the edit does not send it", func() {
+		post := &ms.Post{IsSensitive: ms.PostSensitiveByAuthor, ContentWarning: "spoiler"}
+		sensitive, warning, ok := editSensitiveFrom(post, nil, nil)
+		Expect(ok).To(BeTrue())
+		Expect(sensitive).To(Equal(ms.PostSensitiveByAuthor))
+		Expect(warning).To(Equal("spoiler"))
+	})
+
+	It("updates only the sent field", func() {
+		post := &ms.Post{IsSensitive: ms.PostSensitiveByAuthor, ContentWarning: "spoiler"}
+		sensitive, warning, ok := editSensitiveFrom(post, nil, stringPtr("violence"))
+		Expect(ok).To(BeTrue())
+		Expect(sensitive).To(Equal(ms.PostSensitiveByAuthor))
+		Expect(warning).To(Equal("violence"))
+
+		post = &ms.Post{}
+		sensitive, warning, ok = editSensitiveFrom(post, boolPtr(true), nil)
+		Expect(ok).To(BeTrue())
+		Expect(sensitive).To(Equal(ms.PostSensitiveByAuthor))
+		Expect(warning).To(BeEmpty())
+	})
+
+	It("clears the content warning when unmarked", func() {
+		post := &ms.Post{IsSensitive: ms.PostSensitiveByAuthor, ContentWarning: "spoiler"}
+		sensitive, warning, ok := editSensitiveFrom(post, boolPtr(false), nil)
+		Expect(ok).To(BeTrue())
+		Expect(sensitive).To(Equal(ms.PostSensitiveNone))
+		Expect(warning).To(BeEmpty())
+	})
+
+	It("keeps the mark set by admins", func() {
+		post := &ms.Post{IsSensitive: ms.PostSensitiveByAdmin, ContentWarning: "nsfw"}
+		sensitive, warning, ok := editSensitiveFrom(post, boolPtr(false), nil)
+		Expect(ok).To(BeTrue())
+		Expect(sensitive).To(Equal(ms.PostSensitiveByAdmin))
+		Expect(warning).To(Equal("nsfw"))
+	})
+})
+
+var _ = Describe("checkCommentContents", func() {
+	It("rejects blank comments", func() {
+		Expect(checkCommentContents(nil)).To(MatchError(web.ErrEmptyCommentContent))
+		Expect(checkCommentContents([]*web.PostContentItem{
+			{Type: ms.ContentTypeText, Content: "  "},
+		})).To(MatchError(web.ErrEmptyCommentContent))
+		Expect(checkReplyContent(" \n")).To(MatchError(web.ErrEmptyCommentContent))
+	})
+
+	It("accepts an image only comment", func() {
+		Expect(checkCommentContents([]*web.PostContentItem{
+			{Type: ms.ContentTypeImage, Content: "https://example.com/a.png"},
+		})).To(Succeed())
+	})
+
+	It("counts characters rather than bytes", func() {
+		text := strings.Repeat("泡", _maxCommentLength)
+		Expect(checkCommentContents([]*web.PostContentItem{
+			{Type: ms.ContentTypeText, Content: text},
+		})).To(Succeed())
+		Expect(checkReplyContent(text)).To(Succeed())
+		Expect(checkCommentContents([]*web.PostContentItem{
+			{Type: ms.ContentTypeText, Content: text},
+			{Type: ms.ContentTypeText, Content: "!"},
+		})).To(MatchError(web.ErrCommentContentTooLong))
+		Expect(checkReplyContent(text + "!")).To(MatchError(web.ErrCommentContentTooLong))
+	})
+})
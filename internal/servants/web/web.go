@@ -15,6 +15,7 @@ import (
 	"github.com/rocboss/paopao-ce/internal/dao"
 	"github.com/rocboss/paopao-ce/internal/dao/cache"
 	"github.com/rocboss/paopao-ce/internal/servants/base"
+	"github.com/rocboss/paopao-ce/pkg/types"
 )
 
 var (
@@ -24,6 +25,7 @@ var (
 	_ac                   core.AppCache
 	_wc                   core.WebCache
 	_oss                  core.ObjectStorageService
+	_passwordProvider     types.PasswordProvider
 	_onceInitial          sync.Once
 )
 
@@ -49,7 +51,7 @@ func RouteWeb(e *gin.Engine) {
 		api.RegisterAlipayPrivServant(e, newAlipayPrivSrv(ds, client))
 	})
 	// shedule jobs if need
-	scheduleJobs()
+	scheduleJobs(ds)
 }
 
 // lazyInitial do some package lazy initialize for performance
@@ -57,6 +59,7 @@ func lazyInitial() {
 	_onceInitial.Do(func() {
 		_enablePhoneVerify = cfg.If("Sms")
 		_disallowUserRegister = cfg.If("Web:DisallowUserRegister")
+		_passwordProvider = newPasswordProvider()
 		_maxWhisperNumDaily = conf.AppSetting.MaxWhisperDaily
 		_maxCaptchaTimes = conf.AppSetting.MaxCaptchaTimes
 		_oss = dao.ObjectStorageService()
@@ -13,4 +13,13 @@ type Admin struct {
 	// ChangeUserStatus 管理·禁言/解封用户
 	ChangeUserStatus func(Post, web.ChangeUserStatusReq)         `mir:"admin/user/status"`
 	SiteInfo         func(Get, web.SiteInfoReq) web.SiteInfoResp `mir:"admin/site/status"`
+
+	// ChangeTweetSensitive 管理·强制标记/取消动态敏感内容
+	ChangeTweetSensitive func(Post, web.ChangeTweetSensitiveReq) `mir:"admin/post/sensitive"`
+
+	// ListTrashTweets 管理·获取所有用户回收站中的动态
+	ListTrashTweets func(Get, web.ListTrashTweetsReq) web.ListTrashTweetsResp `mir:"admin/post/trash"`
+
+	// CommentRevisions 管理·获取评论或回复的编辑历史
+	CommentRevisions func(Get, web.CommentRevisionsReq) web.CommentRevisionsResp `mir:"admin/comment/revisions"`
 }
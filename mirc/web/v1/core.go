@@ -34,15 +34,48 @@ type Core struct {
 	// GetStars 获取用户点赞列表
 	GetStars func(Get, web.GetStarsReq) web.GetStarsResp `mir:"user/stars"`
 
+	// GetMentions 获取@我的动态、评论与回复列表
+	GetMentions func(Get, web.GetMentionsReq) web.GetMentionsResp `mir:"user/mentions"`
+
 	// UserPhoneBind 绑定用户手机号
 	UserPhoneBind func(Post, web.UserPhoneBindReq) `mir:"user/phone"`
 
 	// ChangePassword 修改密码
 	ChangePassword func(Post, web.ChangePasswordReq) `mir:"user/password"`
 
+	// ListSessions 获取当前用户的登录设备列表
+	ListSessions func(Get, web.ListSessionsReq) web.ListSessionsResp `mir:"user/sessions"`
+
+	// RevokeSession 下线指定的登录设备
+	RevokeSession func(Post, web.RevokeSessionReq) `mir:"user/session/revoke"`
+
+	// RevokeAllSessions 下线全部登录设备，可保留当前设备
+	RevokeAllSessions func(Post, web.RevokeAllSessionsReq) `mir:"user/sessions/revoke"`
+
+	// SetupTwoFactor 生成二次验证密钥，确认验证码后才会开启
+	SetupTwoFactor func(Post, web.SetupTwoFactorReq) web.SetupTwoFactorResp `mir:"user/2fa/setup"`
+
+	// EnableTwoFactor 确认验证码并开启二次验证，返回一次性恢复码
+	EnableTwoFactor func(Post, web.EnableTwoFactorReq) web.EnableTwoFactorResp `mir:"user/2fa/enable"`
+
+	// DisableTwoFactor 关闭二次验证
+	DisableTwoFactor func(Post, web.DisableTwoFactorReq) `mir:"user/2fa/disable"`
+
+	// ResetRecoveryCodes 重新生成二次验证的恢复码
+	ResetRecoveryCodes func(Post, web.ResetRecoveryCodesReq) web.ResetRecoveryCodesResp `mir:"user/2fa/recovery"`
+
 	// ChangeNickname 修改昵称
 	ChangeNickname func(Post, web.ChangeNicknameReq) `mir:"user/nickname"`
 
+	// ChangeShowSensitive 修改是否直接展示敏感内容
+	ChangeShowSensitive func(Post, web.ChangeShowSensitiveReq) `mir:"user/sensitive"`
+
+	// ChargeUser 为创作者充电，充电后可查看其充电可见的动态
+	ChargeUser func(Post, web.ChargeUserReq) web.ChargeUserResp `mir:"user/charge"`
+
+	// SubscribeUser 订阅创作者，订阅有效期内可查看其全部付费可见的动态
+	SubscribeUser func(Post, web.SubscribeUserReq) web.SubscribeUserResp `mir:"user/subscribe"`
+
 	// ChangeAvatar 修改头像
 	ChangeAvatar func(Post, web.ChangeAvatarReq) `mir:"user/avatar"`
 
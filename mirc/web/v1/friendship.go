@@ -24,4 +24,19 @@ type Friendship struct {
 
 	// GetContacts 获取好友列表
 	GetContacts func(Get, web.GetContactsReq) web.GetContactsResp `mir:"user/contacts"`
+
+	// ListContactGroups 获取联系人分组列表
+	ListContactGroups func(Get, web.ListContactGroupsReq) web.ListContactGroupsResp `mir:"friend/groups"`
+
+	// CreateContactGroup 创建联系人分组
+	CreateContactGroup func(Post, web.CreateContactGroupReq) web.CreateContactGroupResp `mir:"friend/group/create" binding:"json"`
+
+	// RenameContactGroup 重命名联系人分组
+	RenameContactGroup func(Post, web.RenameContactGroupReq) `mir:"friend/group/rename" binding:"json"`
+
+	// DeleteContactGroup 删除联系人分组
+	DeleteContactGroup func(Post, web.DeleteContactGroupReq) `mir:"friend/group/delete" binding:"json"`
+
+	// SetContactGroup 把好友加入分组或移出分组
+	SetContactGroup func(Post, web.SetContactGroupReq) `mir:"friend/group/assign" binding:"json"`
 }
@@ -16,6 +16,12 @@ type Loose struct {
 	// GetUserTweets 获取用户动态列表
 	GetUserTweets func(Get, web.GetUserTweetsReq) web.GetUserTweetsResp `mir:"user/posts"`
 
+	// CollectionFolders 获取用户的收藏夹列表
+	CollectionFolders func(Get, web.CollectionFoldersReq) web.CollectionFoldersResp `mir:"user/collection/folders"`
+
+	// FolderTweets 获取收藏夹中的动态
+	FolderTweets func(Get, web.FolderTweetsReq) web.FolderTweetsResp `mir:"collection/folder/posts"`
+
 	// GetUserProfile 获取用户基本信息
 	GetUserProfile func(Get, web.GetUserProfileReq) web.GetUserProfileResp `mir:"user/profile"`
 
@@ -25,6 +31,18 @@ type Loose struct {
 	// TweetComments 获取动态评论
 	TweetComments func(Get, web.TweetCommentsReq) web.TweetCommentsResp `mir:"post/comments"`
 
+	// CommentReplies 分页加载评论的更多回复
+	CommentReplies func(Get, web.CommentRepliesReq) web.CommentRepliesResp `mir:"post/comment/replies"`
+
 	// TweetDetail 获取动态详情
 	TweetDetail func(Get, web.TweetDetailReq) web.TweetDetailResp `mir:"post"`
+
+	// TweetViews 上报动态曝光
+	TweetViews func(Post, web.TweetViewsReq) `mir:"post/views"`
+
+	// TweetRevisions 获取动态编辑历史
+	TweetRevisions func(Get, web.TweetRevisionsReq) web.TweetRevisionsResp `mir:"post/revisions"`
+
+	// TweetThread 获取动态所在的串推
+	TweetThread func(Get, web.TweetThreadReq) web.TweetThreadResp `mir:"post/thread"`
 }
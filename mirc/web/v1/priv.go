@@ -22,6 +22,57 @@ type Priv struct {
 	// CreateTweet 发布动态
 	CreateTweet func(Post, Chain, web.CreateTweetReq) web.CreateTweetResp `mir:"post"`
 
+	// EditTweet 编辑动态
+	EditTweet func(Post, Chain, web.EditTweetReq) web.EditTweetResp `mir:"post/edit"`
+
+	// ScheduledTweets 获取待发布的定时动态
+	ScheduledTweets func(Get, web.ScheduledTweetsReq) web.ScheduledTweetsResp `mir:"post/scheduled"`
+
+	// ArchivedTweets 获取已过期的限时动态归档
+	ArchivedTweets func(Get, web.ArchivedTweetsReq) web.ArchivedTweetsResp `mir:"post/archive"`
+
+	// RescheduleTweet 修改定时动态的发布时间
+	RescheduleTweet func(Post, web.RescheduleTweetReq) web.RescheduleTweetResp `mir:"post/schedule"`
+
+	// CancelScheduledTweet 取消定时动态
+	CancelScheduledTweet func(Delete, web.CancelScheduledTweetReq) `mir:"post/schedule"`
+
+	// Drafts 获取草稿列表
+	Drafts func(Get, web.DraftsReq) web.DraftsResp `mir:"post/drafts"`
+
+	// TrashTweets 获取回收站中的动态
+	TrashTweets func(Get, web.TrashTweetsReq) web.TrashTweetsResp `mir:"post/trash"`
+
+	// RestoreTweet 从回收站恢复动态
+	RestoreTweet func(Post, web.RestoreTweetReq) web.RestoreTweetResp `mir:"post/restore"`
+
+	// CreateDraft 保存草稿
+	CreateDraft func(Post, web.CreateDraftReq) web.CreateDraftResp `mir:"post/draft"`
+
+	// UpdateDraft 更新草稿
+	UpdateDraft func(Post, web.UpdateDraftReq) web.UpdateDraftResp `mir:"post/draft/update"`
+
+	// DeleteDraft 删除草稿
+	DeleteDraft func(Delete, web.DeleteDraftReq) `mir:"post/draft"`
+
+	// PublishDraft 发布草稿
+	PublishDraft func(Post, Chain, web.PublishDraftReq) web.PublishDraftResp `mir:"post/draft/publish"`
+
+	// VotePoll 动态投票
+	VotePoll func(Post, web.VotePollReq) web.VotePollResp `mir:"post/poll/vote"`
+
+	// CreateTweetReaction 添加动态表情回应
+	CreateTweetReaction func(Post, web.CreateTweetReactionReq) web.CreateTweetReactionResp `mir:"post/reaction"`
+
+	// DeleteTweetReaction 取消动态表情回应
+	DeleteTweetReaction func(Delete, web.DeleteTweetReactionReq) web.DeleteTweetReactionResp `mir:"post/reaction"`
+
+	// CreateCommentReaction 添加评论表情回应
+	CreateCommentReaction func(Post, web.CreateCommentReactionReq) web.CreateCommentReactionResp `mir:"post/comment/reaction"`
+
+	// DeleteCommentReaction 取消评论表情回应
+	DeleteCommentReaction func(Delete, web.DeleteCommentReactionReq) web.DeleteCommentReactionResp `mir:"post/comment/reaction"`
+
 	// DeleteTweet 删除动态
 	DeleteTweet func(Delete, web.DeleteTweetReq) `mir:"post"`
 
@@ -31,12 +82,27 @@ type Priv struct {
 	// CollectionTweet 动态收藏操作
 	CollectionTweet func(Post, web.CollectionTweetReq) web.CollectionTweetResp `mir:"post/collection"`
 
+	// MoveCollectionTweet 将收藏的动态移动到其他收藏夹
+	MoveCollectionTweet func(Post, web.MoveCollectionTweetReq) `mir:"post/collection/move"`
+
+	// CreateCollectionFolder 创建收藏夹
+	CreateCollectionFolder func(Post, web.CreateCollectionFolderReq) web.CreateCollectionFolderResp `mir:"collection/folder"`
+
+	// UpdateCollectionFolder 更新收藏夹
+	UpdateCollectionFolder func(Post, web.UpdateCollectionFolderReq) web.UpdateCollectionFolderResp `mir:"collection/folder/update"`
+
+	// DeleteCollectionFolder 删除收藏夹，其中的收藏移入默认收藏夹
+	DeleteCollectionFolder func(Delete, web.DeleteCollectionFolderReq) `mir:"collection/folder"`
+
 	// LockTweet 锁定动态
 	LockTweet func(Post, web.LockTweetReq) web.LockTweetResp `mir:"post/lock"`
 
 	// StickTweet 置顶动态
 	StickTweet func(Post, web.StickTweetReq) web.StickTweetResp `mir:"post/stick"`
 
+	// PinTweet 在个人主页置顶动态
+	PinTweet func(Post, web.PinTweetReq) web.PinTweetResp `mir:"post/pin"`
+
 	// HighlightTweet 推文亮点设置
 	HighlightTweet func(Post, web.HighlightTweetReq) web.HighlightTweetResp `mir:"post/highlight"`
 
@@ -49,6 +115,9 @@ type Priv struct {
 	// DeletePostComment 删除动态评论
 	DeleteComment func(Delete, web.DeleteCommentReq) `mir:"post/comment"`
 
+	// EditComment 编辑动态评论
+	EditComment func(Post, web.EditCommentReq) web.EditCommentResp `mir:"post/comment/edit"`
+
 	// HighlightComment 精选动态评论
 	HighlightComment func(Post, web.HighlightCommentReq) web.HighlightCommentResp `mir:"post/comment/highlight"`
 
@@ -58,6 +127,9 @@ type Priv struct {
 	// DeleteCommentReply 删除评论回复
 	DeleteCommentReply func(Delete, web.DeleteCommentReplyReq) `mir:"post/comment/reply"`
 
+	// EditCommentReply 编辑评论回复
+	EditCommentReply func(Post, web.EditCommentReplyReq) web.EditCommentReplyResp `mir:"post/comment/reply/edit"`
+
 	// ThumbsUpTweetComment 点赞评论
 	ThumbsUpTweetComment func(Post, web.TweetCommentThumbsReq) `mir:"tweet/comment/thumbsup"`
 
@@ -16,6 +16,21 @@ type Pub struct {
 	// Login 用户登录
 	Login func(Post, web.LoginReq) web.LoginResp `mir:"/auth/login"`
 
+	// RefreshToken 使用刷新令牌换取新的访问令牌，刷新令牌同时轮换
+	RefreshToken func(Post, web.RefreshTokenReq) web.RefreshTokenResp `mir:"/auth/refresh"`
+
+	// TwoFactorSetup 管理员被要求开启二次验证时，使用挑战令牌生成二次验证密钥
+	TwoFactorSetup func(Post, web.TwoFactorSetupReq) web.TwoFactorSetupResp `mir:"/auth/2fa/setup"`
+
+	// TwoFactorLogin 使用挑战令牌与二次验证码换取访问令牌
+	TwoFactorLogin func(Post, web.TwoFactorLoginReq) web.TwoFactorLoginResp `mir:"/auth/2fa/login"`
+
+	// ForgotPassword 忘记密码，校验图形验证码后向绑定的手机号发送短信验证码
+	ForgotPassword func(Post, web.ForgotPasswordReq) web.ForgotPasswordResp `mir:"/auth/password/forgot"`
+
+	// ResetPassword 使用短信验证码重置密码，重置后全部设备需要重新登录
+	ResetPassword func(Post, web.ResetPasswordReq) `mir:"/auth/password/reset"`
+
 	// Register 用户注册
 	Register func(Post, web.RegisterReq) web.RegisterResp `mir:"/auth/register"`
 
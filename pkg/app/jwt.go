@@ -6,6 +6,8 @@ package app
 
 import (
 	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"time"
 
@@ -17,18 +19,36 @@ import (
 type Claims struct {
 	UID      int64  `json:"uid"`
 	Username string `json:"username"`
+	SID      int64  `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// ChallengeClaims 二次验证的挑战令牌，Enroll为true时需要先开启二次验证
+type ChallengeClaims struct {
+	UID    int64 `json:"uid"`
+	Enroll bool  `json:"enroll,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// _challengeExpire 挑战令牌的有效时长
+const _challengeExpire = 5 * time.Minute
+
 func GetJWTSecret() []byte {
 	return []byte(conf.JWTSetting.Secret)
 }
 
-func GenerateToken(user *ms.User) (string, error) {
+// getChallengeSecret 挑战令牌使用独立的密钥，避免被当作访问令牌使用
+func getChallengeSecret() []byte {
+	return []byte(conf.JWTSetting.Secret + ":challenge")
+}
+
+// GenerateToken 生成访问令牌，sessionId为签发令牌的登录会话
+func GenerateToken(user *ms.User, sessionId int64) (string, error) {
 	expireTime := time.Now().Add(conf.JWTSetting.Expire)
 	claims := Claims{
 		UID:      user.ID,
 		Username: user.Username,
+		SID:      sessionId,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expireTime),
 			Issuer:    IssuerFrom(user.Salt),
@@ -59,3 +79,44 @@ func IssuerFrom(data string) string {
 	res := md5.Sum(contents)
 	return hex.EncodeToString(res[:])
 }
+
+// GenerateRefreshToken 生成随机的刷新令牌，服务端只保存其摘要
+func GenerateRefreshToken() (token string, digest string, err error) {
+	data := make([]byte, 32)
+	if _, err = rand.Read(data); err != nil {
+		return
+	}
+	token = hex.EncodeToString(data)
+	digest = RefreshTokenDigest(token)
+	return
+}
+
+// RefreshTokenDigest 刷新令牌的SHA256摘要
+func RefreshTokenDigest(token string) string {
+	res := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(res[:])
+}
+
+// GenerateChallengeToken 密码验证通过后签发挑战令牌，换取访问令牌前需要完成二次验证
+func GenerateChallengeToken(user *ms.User, enroll bool) (string, error) {
+	claims := ChallengeClaims{
+		UID:    user.ID,
+		Enroll: enroll,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(_challengeExpire)),
+			Issuer:    IssuerFrom(user.Salt),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(getChallengeSecret())
+}
+
+func ParseChallengeToken(token string) (*ChallengeClaims, error) {
+	tokenClaims, err := jwt.ParseWithClaims(token, &ChallengeClaims{}, func(_ *jwt.Token) (any, error) {
+		return getChallengeSecret(), nil
+	})
+	if err != nil || tokenClaims == nil || !tokenClaims.Valid {
+		return nil, jwt.ErrTokenNotValidYet
+	}
+	res, _ := tokenClaims.Claims.(*ChallengeClaims)
+	return res, nil
+}
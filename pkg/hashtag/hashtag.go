@@ -0,0 +1,54 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package hashtag 从文本中提取#话题并规范化
+package hashtag
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxRuneLen 话题的最大字符数
+const MaxRuneLen = 32
+
+// _tagRegexp 匹配行首或空白后的#话题，全角＃同样识别，话题到空白、#、@或逗号为止
+var _tagRegexp = regexp.MustCompile(`(?:^|\s)[#＃]([^\s#＃@,，]+)`)
+
+// Normalize 规范化话题：NFKC兼容等价(全角转半角、半角片假名转全角)、大小写折叠、去除首尾的#与标点，
+// 话题为空、过长或包含分隔字符时返回false
+func Normalize(tag string) (string, bool) {
+	tag = cases.Fold().String(norm.NFKC.String(tag))
+	tag = strings.TrimLeft(strings.TrimSpace(tag), "#")
+	tag = strings.TrimRightFunc(tag, unicode.IsPunct)
+	if tag == "" || utf8.RuneCountInString(tag) > MaxRuneLen || strings.ContainsAny(tag, "#@,") {
+		return "", false
+	}
+	if strings.IndexFunc(tag, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsControl(r)
+	}) >= 0 {
+		return "", false
+	}
+	return tag, true
+}
+
+// Extract 提取文本中的话题，返回规范化并去重后的结果
+func Extract(text string) []string {
+	var tags []string
+	seen := make(map[string]struct{})
+	for _, match := range _tagRegexp.FindAllStringSubmatch(text, -1) {
+		tag, ok := Normalize(match[1])
+		if _, exist := seen[tag]; !ok || exist {
+			continue
+		}
+		seen[tag] = struct{}{}
+		tags = append(tags, tag)
+	}
+	return tags
+}
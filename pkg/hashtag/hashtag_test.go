@@ -0,0 +1,53 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package hashtag_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rocboss/paopao-ce/pkg/hashtag"
+)
+
+var _ = Describe("Hashtag", func() {
+	It("normalize tag", func() {
+		for origin, expect := range map[string]string{
+			"GoLang":    "golang",
+			" #泡泡 ":     "泡泡",
+			"ＧｏＬａｎｇ":    "golang",
+			"Straße":    "strasse",
+			"golang!":   "golang",
+			"c++":       "c++",
+			"ﾊﾟｵﾊﾟｵ":    "パオパオ",
+			"＃Ｔｏｐｉｃ１２３": "topic123",
+		} {
+			tag, ok := hashtag.Normalize(origin)
+			Expect(ok).To(BeTrue(), origin)
+			Expect(tag).To(Equal(expect), origin)
+		}
+	})
+
+	It("reject invalid tag", func() {
+		for _, origin := range []string{"", "  ", "#", "!!!", "a b", "a,b", "a，b", "a@b", "a\tb", strings.Repeat("x", hashtag.MaxRuneLen+1)} {
+			_, ok := hashtag.Normalize(origin)
+			Expect(ok).To(BeFalse(), origin)
+		}
+		tag, ok := hashtag.Normalize(strings.Repeat("泡", hashtag.MaxRuneLen))
+		Expect(ok).To(BeTrue())
+		Expect(tag).To(Equal(strings.Repeat("泡", hashtag.MaxRuneLen)))
+	})
+
+	It("extract tags from text", func() {
+		Expect(hashtag.Extract("hello #Go and ＃泡泡，nice #go\n#PaoPao. #")).To(Equal([]string{"go", "泡泡", "paopao"}))
+		Expect(hashtag.Extract("#first at line start")).To(Equal([]string{"first"}))
+	})
+
+	It("skip non tag sharp", func() {
+		Expect(hashtag.Extract("see https://example.com/page#anchor and issue#12")).To(BeEmpty())
+		Expect(hashtag.Extract("@user #a#b")).To(Equal([]string{"a"}))
+	})
+})
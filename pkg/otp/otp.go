@@ -0,0 +1,127 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package otp 基于RFC 6238的TOTP二次验证码，兼容常见的身份验证器应用
+package otp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Period 验证码的有效周期，单位秒
+	Period = 30
+	// Digits 验证码的位数
+	Digits = 6
+
+	_digitsModulo     = 1000000
+	_secretSize       = 20
+	_recoveryCodeSize = 6
+)
+
+var _encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret 生成随机的base32编码密钥
+func GenerateSecret() (string, error) {
+	data := make([]byte, _secretSize)
+	if _, err := rand.Read(data); err != nil {
+		return "", err
+	}
+	return _encoding.EncodeToString(data), nil
+}
+
+// Counter 时间t所在的验证码周期
+func Counter(t time.Time) int64 {
+	return t.Unix() / Period
+}
+
+// Code 生成时间t对应的验证码
+func Code(secret string, t time.Time) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return hotp(key, Counter(t)), nil
+}
+
+// Validate 校验验证码，允许前后skew个周期的时间偏差，返回匹配的周期用于防止重放
+func Validate(secret string, code string, t time.Time, skew int64) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != Digits {
+		return 0, false
+	}
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return 0, false
+	}
+	counter := Counter(t)
+	for i := -skew; i <= skew; i++ {
+		if subtle.ConstantTimeCompare([]byte(hotp(key, counter+i)), []byte(code)) == 1 {
+			return counter + i, true
+		}
+	}
+	return 0, false
+}
+
+// URI 生成身份验证器使用的otpauth链接，可直接转换为二维码
+func URI(issuer string, account string, secret string) string {
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(Digits))
+	params.Set("period", fmt.Sprint(Period))
+	label := url.PathEscape(account)
+	if issuer != "" {
+		params.Set("issuer", issuer)
+		label = url.PathEscape(issuer + ":" + account)
+	}
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+// GenerateRecoveryCodes 生成n个一次性恢复码，格式为xxxxx-xxxxx
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	res := make([]string, 0, n)
+	data := make([]byte, _recoveryCodeSize)
+	for i := 0; i < n; i++ {
+		if _, err := rand.Read(data); err != nil {
+			return nil, err
+		}
+		code := strings.ToLower(_encoding.EncodeToString(data))
+		res = append(res, code[:5]+"-"+code[5:])
+	}
+	return res, nil
+}
+
+// RecoveryCodeDigest 恢复码的SHA256摘要，忽略大小写与分隔符
+func RecoveryCodeDigest(code string) string {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	res := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(res[:])
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	return _encoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+}
+
+func hotp(key []byte, counter int64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+	h := hmac.New(sha1.New, key)
+	h.Write(msg)
+	sum := h.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", Digits, value%_digitsModulo)
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package otp_test
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rocboss/paopao-ce/pkg/otp"
+)
+
+var _ = Describe("Otp", Ordered, func() {
+	// RFC 6238附录B的SHA1测试密钥"12345678901234567890"
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	It("code with rfc6238 test vectors", func() {
+		for unix, code := range map[int64]string{
+			59:         "287082",
+			1111111109: "081804",
+			1111111111: "050471",
+			1234567890: "005924",
+			2000000000: "279037",
+		} {
+			res, err := otp.Code(secret, time.Unix(unix, 0))
+			Expect(err).To(Succeed())
+			Expect(res).To(Equal(code))
+		}
+	})
+
+	It("validate with skew", func() {
+		now := time.Unix(1111111111, 0)
+		counter, ok := otp.Validate(secret, "050471", now, 1)
+		Expect(ok).To(BeTrue())
+		Expect(counter).To(Equal(otp.Counter(now)))
+		counter, ok = otp.Validate(secret, "050471", now.Add(otp.Period*time.Second), 1)
+		Expect(ok).To(BeTrue())
+		Expect(counter).To(Equal(otp.Counter(now)))
+		_, ok = otp.Validate(secret, "050471", now.Add(3*otp.Period*time.Second), 1)
+		Expect(ok).To(BeFalse())
+		_, ok = otp.Validate(secret, "05047", now, 1)
+		Expect(ok).To(BeFalse())
+		_, ok = otp.Validate("not-base32!", "050471", now, 1)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("generate secret", func() {
+		s, err := otp.GenerateSecret()
+		Expect(err).To(Succeed())
+		Expect(s).To(HaveLen(32))
+		code, err := otp.Code(s, time.Now())
+		Expect(err).To(Succeed())
+		_, ok := otp.Validate(s, code, time.Now(), 1)
+		Expect(ok).To(BeTrue())
+	})
+
+	It("otpauth uri", func() {
+		uri := otp.URI("paopao", "alimy", secret)
+		Expect(uri).To(HavePrefix("otpauth://totp/paopao:alimy?"))
+		Expect(uri).To(ContainSubstring("secret=" + secret))
+		Expect(uri).To(ContainSubstring("issuer=paopao"))
+	})
+
+	It("recovery codes", func() {
+		codes, err := otp.GenerateRecoveryCodes(10)
+		Expect(err).To(Succeed())
+		Expect(codes).To(HaveLen(10))
+		for _, code := range codes {
+			Expect(code).To(MatchRegexp(`^[a-z2-7]{5}-[a-z2-7]{5}$`))
+		}
+		Expect(otp.RecoveryCodeDigest(codes[0])).To(Equal(otp.RecoveryCodeDigest(strings.ToUpper(strings.ReplaceAll(codes[0], "-", "")))))
+		Expect(otp.RecoveryCodeDigest(codes[0])).NotTo(Equal(otp.RecoveryCodeDigest(codes[1])))
+	})
+})
@@ -5,12 +5,41 @@
 package types
 
 import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	_argon2idSaltLength = 16
+	_argon2idKeyLength  = 32
+	_argon2idFormat     = "$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s"
+	// 摘要中参数的上限，避免构造的摘要占用过多内存与计算时间
+	_argon2idMaxMemory = 1 << 20
+	_argon2idMaxTime   = 16
+	_argon2idMaxKeyLen = 64
+)
+
+var (
+	ErrMismatchedPassword  = errors.New("hashedPassword is not the hash of the given password")
+	ErrInvalidPasswordHash = errors.New("hashedPassword is not a valid encoded hash")
+
+	_bcryptPrefix   = []byte("$2")
+	_argon2idPrefix = []byte("$argon2id$")
+)
+
+// PasswordProvider 密码摘要算法，生成的摘要自带算法标识与参数
 type PasswordProvider interface {
 	Generate(password []byte) ([]byte, error)
 	Compare(hashedPassword, password []byte) error
+	// Match 摘要是否由该算法生成
+	Match(hashedPassword []byte) bool
 }
 
 func NewBcryptPasswordProvider(cost int) PasswordProvider {
@@ -19,10 +48,25 @@ func NewBcryptPasswordProvider(cost int) PasswordProvider {
 	}
 }
 
+// NewArgon2idPasswordProvider 生成PHC格式的argon2id摘要，memory单位为KiB
+func NewArgon2idPasswordProvider(time uint32, memory uint32, threads uint8) PasswordProvider {
+	return &argon2idPasswordProvider{
+		time:    time,
+		memory:  memory,
+		threads: threads,
+	}
+}
+
 type bcryptPasswordProvider struct {
 	cost int
 }
 
+type argon2idPasswordProvider struct {
+	time    uint32
+	memory  uint32
+	threads uint8
+}
+
 func (p *bcryptPasswordProvider) Generate(password []byte) ([]byte, error) {
 	return bcrypt.GenerateFromPassword(password, p.cost)
 }
@@ -30,3 +74,60 @@ func (p *bcryptPasswordProvider) Generate(password []byte) ([]byte, error) {
 func (p *bcryptPasswordProvider) Compare(hashedPassword, password []byte) error {
 	return bcrypt.CompareHashAndPassword(hashedPassword, password)
 }
+
+func (p *bcryptPasswordProvider) Match(hashedPassword []byte) bool {
+	return bytes.HasPrefix(hashedPassword, _bcryptPrefix)
+}
+
+func (p *argon2idPasswordProvider) Generate(password []byte) ([]byte, error) {
+	salt := make([]byte, _argon2idSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	key := argon2.IDKey(password, salt, p.time, p.memory, p.threads, _argon2idKeyLength)
+	encoding := base64.RawStdEncoding
+	return fmt.Appendf(nil, _argon2idFormat, argon2.Version, p.memory, p.time, p.threads,
+		encoding.EncodeToString(salt), encoding.EncodeToString(key)), nil
+}
+
+// Compare 使用摘要中记录的参数重新计算，参数调整后旧摘要依然可以校验
+func (p *argon2idPasswordProvider) Compare(hashedPassword, password []byte) error {
+	if !p.Match(hashedPassword) {
+		return ErrInvalidPasswordHash
+	}
+	var (
+		version, memory, time uint32
+		threads               uint8
+	)
+	parts := bytes.Split(hashedPassword, []byte("$"))
+	if len(parts) != 6 {
+		return ErrInvalidPasswordHash
+	}
+	if _, err := fmt.Sscanf(string(parts[2]), "v=%d", &version); err != nil || version != argon2.Version {
+		return ErrInvalidPasswordHash
+	}
+	if _, err := fmt.Sscanf(string(parts[3]), "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+		return ErrInvalidPasswordHash
+	}
+	if memory == 0 || memory > _argon2idMaxMemory || time == 0 || time > _argon2idMaxTime || threads == 0 {
+		return ErrInvalidPasswordHash
+	}
+	encoding := base64.RawStdEncoding
+	salt, err := encoding.DecodeString(string(parts[4]))
+	if err != nil {
+		return ErrInvalidPasswordHash
+	}
+	key, err := encoding.DecodeString(string(parts[5]))
+	if err != nil || len(key) == 0 || len(key) > _argon2idMaxKeyLen {
+		return ErrInvalidPasswordHash
+	}
+	otherKey := argon2.IDKey(password, salt, time, memory, threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(key, otherKey) != 1 {
+		return ErrMismatchedPassword
+	}
+	return nil
+}
+
+func (p *argon2idPasswordProvider) Match(hashedPassword []byte) bool {
+	return bytes.HasPrefix(hashedPassword, _argon2idPrefix)
+}
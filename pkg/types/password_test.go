@@ -0,0 +1,75 @@
+// Copyright 2024 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package types_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rocboss/paopao-ce/pkg/types"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var _ = Describe("PasswordProvider", Ordered, func() {
+	var (
+		bcryptProvider   types.PasswordProvider
+		argon2idProvider types.PasswordProvider
+		password         = []byte("paopao-ce")
+	)
+
+	BeforeAll(func() {
+		bcryptProvider = types.NewBcryptPasswordProvider(bcrypt.MinCost)
+		argon2idProvider = types.NewArgon2idPasswordProvider(1, 1024, 1)
+	})
+
+	It("bcrypt generate and compare", func() {
+		hashed, err := bcryptProvider.Generate(password)
+		Expect(err).To(Succeed())
+		Expect(bcryptProvider.Match(hashed)).To(BeTrue())
+		Expect(argon2idProvider.Match(hashed)).To(BeFalse())
+		Expect(bcryptProvider.Compare(hashed, password)).To(Succeed())
+		Expect(bcryptProvider.Compare(hashed, []byte("paopao"))).NotTo(Succeed())
+	})
+
+	It("argon2id generate and compare", func() {
+		hashed, err := argon2idProvider.Generate(password)
+		Expect(err).To(Succeed())
+		Expect(bytes.HasPrefix(hashed, []byte("$argon2id$v=19$m=1024,t=1,p=1$"))).To(BeTrue())
+		Expect(argon2idProvider.Match(hashed)).To(BeTrue())
+		Expect(bcryptProvider.Match(hashed)).To(BeFalse())
+		Expect(argon2idProvider.Compare(hashed, password)).To(Succeed())
+		Expect(argon2idProvider.Compare(hashed, []byte("paopao"))).To(MatchError(types.ErrMismatchedPassword))
+	})
+
+	It("argon2id compare with encoded params", func() {
+		hashed, err := types.NewArgon2idPasswordProvider(2, 2048, 2).Generate(password)
+		Expect(err).To(Succeed())
+		Expect(argon2idProvider.Compare(hashed, password)).To(Succeed())
+	})
+
+	It("argon2id compare invalid hash", func() {
+		Expect(argon2idProvider.Compare([]byte("e10adc3949ba59abbe56e057f20f883e"), password)).To(MatchError(types.ErrInvalidPasswordHash))
+		Expect(argon2idProvider.Compare([]byte("$argon2id$v=19$m=1024,t=1,p=1$!!$!!"), password)).To(MatchError(types.ErrInvalidPasswordHash))
+		Expect(argon2idProvider.Compare([]byte("$argon2id$v=16$m=1024,t=1,p=1$c2FsdA$a2V5"), password)).To(MatchError(types.ErrInvalidPasswordHash))
+	})
+
+	It("argon2id compare rejects out of range params", func() {
+		for _, params := range []string{
+			"m=0,t=1,p=1",
+			"m=1024,t=0,p=1",
+			"m=1024,t=1,p=0",
+			"m=-1,t=1,p=1",
+			"m=1024,t=-1,p=1",
+			"m=1024,t=1,p=-1",
+			"m=4294967295,t=1,p=1",
+			"m=1024,t=1000000,p=1",
+		} {
+			hashed := []byte("$argon2id$v=19$" + params + "$c2FsdHNhbHRzYWx0$a2V5a2V5a2V5a2V5")
+			Expect(argon2idProvider.Compare(hashed, password)).To(MatchError(types.ErrInvalidPasswordHash), params)
+		}
+	})
+})
@@ -0,0 +1,325 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package unfurl 抓取链接的OpenGraph元信息用于生成链接预览
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/charset"
+)
+
+const (
+	_maxRedirects   = 5
+	_maxTitleLen    = 256
+	_maxDescLen     = 512
+	_maxSiteNameLen = 128
+)
+
+var (
+	ErrInvalidURL      = errors.New("unfurl: invalid url")
+	ErrForbiddenAddr   = errors.New("unfurl: forbidden address")
+	ErrTooManyRedirect = errors.New("unfurl: too many redirects")
+	ErrUnexpectedType  = errors.New("unfurl: unexpected content type")
+	ErrTooLarge        = errors.New("unfurl: content too large")
+)
+
+// Preview 链接预览信息
+type Preview struct {
+	URL         string `json:"url"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Image       string `json:"image"`
+	SiteName    string `json:"site_name"`
+}
+
+// Unfurler 链接预览抓取器，默认拒绝访问内网、回环等非公网地址
+type Unfurler struct {
+	client      *http.Client
+	maxBodySize int64
+	userAgent   string
+}
+
+// Option 抓取器选项
+type Option func(*options)
+
+type options struct {
+	allowPrivate bool
+	userAgent    string
+}
+
+// AllowPrivate 允许访问非公网地址，仅用于测试
+func AllowPrivate() Option {
+	return func(opts *options) {
+		opts.allowPrivate = true
+	}
+}
+
+// UserAgent 设置抓取时使用的User-Agent
+func UserAgent(ua string) Option {
+	return func(opts *options) {
+		opts.userAgent = ua
+	}
+}
+
+// New 创建抓取器，timeout为单次抓取的总超时，maxBodySize为读取页面的最大字节数
+func New(timeout time.Duration, maxBodySize int64, opts ...Option) *Unfurler {
+	o := &options{
+		userAgent: "Mozilla/5.0 (compatible; paopao-ce/unfurl)",
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	dialer := &net.Dialer{
+		Timeout: timeout,
+	}
+	if !o.allowPrivate {
+		// 在建立连接时检查解析后的地址，避免DNS重绑定绕过检查
+		dialer.Control = func(_network, address string, _c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !IsPublicIP(ip) {
+				return ErrForbiddenAddr
+			}
+			return nil
+		}
+	}
+	transport := &http.Transport{
+		// 不使用代理，代理会绕过地址检查
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   timeout,
+		ResponseHeaderTimeout: timeout,
+		MaxIdleConns:          16,
+		IdleConnTimeout:       30 * time.Second,
+	}
+	return &Unfurler{
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   timeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= _maxRedirects {
+					return ErrTooManyRedirect
+				}
+				if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+					return ErrInvalidURL
+				}
+				return nil
+			},
+		},
+		maxBodySize: maxBodySize,
+		userAgent:   o.userAgent,
+	}
+}
+
+// Unfurl 抓取链接页面并解析其预览信息
+func (u *Unfurler) Unfurl(ctx context.Context, rawURL string) (*Preview, error) {
+	resp, err := u.get(ctx, rawURL, "text/html,application/xhtml+xml")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	contentType := resp.Header.Get("Content-Type")
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, ErrUnexpectedType
+	}
+	// 元信息都在页面头部，超过上限的部分直接截断
+	body, err := charset.NewReader(io.LimitReader(resp.Body, u.maxBodySize), contentType)
+	if err != nil {
+		return nil, err
+	}
+	preview := parse(body, resp.Request.URL)
+	preview.URL = rawURL
+	return preview, nil
+}
+
+// FetchImage 抓取预览图，超过maxSize字节时返回ErrTooLarge
+func (u *Unfurler) FetchImage(ctx context.Context, rawURL string, maxSize int64) ([]byte, string, error) {
+	resp, err := u.get(ctx, rawURL, "image/*")
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	contentType := resp.Header.Get("Content-Type")
+	if !strings.HasPrefix(contentType, "image/") {
+		return nil, "", ErrUnexpectedType
+	}
+	if resp.ContentLength > maxSize {
+		return nil, "", ErrTooLarge
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, "", ErrTooLarge
+	}
+	return data, contentType, nil
+}
+
+func (u *Unfurler) get(ctx context.Context, rawURL string, accept string) (*http.Response, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Hostname() == "" {
+		return nil, ErrInvalidURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", u.userAgent)
+	req.Header.Set("Accept", accept)
+	resp, err := u.client.Do(req)
+	if err != nil {
+		if errors.Is(err, ErrForbiddenAddr) {
+			return nil, ErrForbiddenAddr
+		}
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unfurl: unexpected status code %d", resp.StatusCode)
+	}
+	return resp, nil
+}
+
+// IsPublicIP 判断是否为公网地址
+func IsPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		// 100.64.0.0/10 运营商级NAT，0.0.0.0/8 本网络，255.255.255.255 广播
+		if ip4[0] == 100 && ip4[1]&0xc0 == 64 || ip4[0] == 0 || ip4.Equal(net.IPv4bcast) {
+			return false
+		}
+	}
+	return true
+}
+
+// parse 解析页面头部的OpenGraph/Twitter Card元信息，缺失时回退到title与description
+func parse(r io.Reader, base *url.URL) *Preview {
+	var (
+		preview            Preview
+		title, description string
+		inTitle            bool
+	)
+	z := html.NewTokenizer(r)
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			goto done
+		case html.TextToken:
+			if inTitle && title == "" {
+				title = strings.TrimSpace(string(z.Text()))
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			switch string(name) {
+			case "title":
+				inTitle = false
+			case "head":
+				goto done
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			switch string(name) {
+			case "body":
+				goto done
+			case "title":
+				inTitle = tt == html.StartTagToken
+			case "meta":
+				if !hasAttr {
+					continue
+				}
+				var key, content string
+				for {
+					k, v, more := z.TagAttr()
+					switch string(k) {
+					case "property", "name":
+						if key == "" {
+							key = strings.ToLower(string(v))
+						}
+					case "content":
+						content = strings.TrimSpace(string(v))
+					}
+					if !more {
+						break
+					}
+				}
+				switch key {
+				case "og:title":
+					preview.Title = content
+				case "og:description":
+					preview.Description = content
+				case "og:image", "og:image:url", "og:image:secure_url":
+					if preview.Image == "" {
+						preview.Image = content
+					}
+				case "og:site_name":
+					preview.SiteName = content
+				case "twitter:title":
+					title = firstNonEmpty(title, content)
+				case "twitter:image", "twitter:image:src":
+					if preview.Image == "" {
+						preview.Image = content
+					}
+				case "description", "twitter:description":
+					description = firstNonEmpty(description, content)
+				}
+			}
+		}
+	}
+done:
+	preview.Title = truncate(firstNonEmpty(preview.Title, title), _maxTitleLen)
+	preview.Description = truncate(firstNonEmpty(preview.Description, description), _maxDescLen)
+	preview.SiteName = truncate(firstNonEmpty(preview.SiteName, base.Hostname()), _maxSiteNameLen)
+	if preview.Image != "" {
+		if ref, err := url.Parse(preview.Image); err == nil {
+			if img := base.ResolveReference(ref); img.Scheme == "http" || img.Scheme == "https" {
+				preview.Image = img.String()
+			} else {
+				preview.Image = ""
+			}
+		} else {
+			preview.Image = ""
+		}
+	}
+	return &preview
+}
+
+func firstNonEmpty(s ...string) string {
+	for _, v := range s {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func truncate(s string, size int) string {
+	if utf8.RuneCountInString(s) <= size {
+		return s
+	}
+	return string([]rune(s)[:size])
+}
@@ -0,0 +1,150 @@
+// Copyright 2023 ROC. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package unfurl_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rocboss/paopao-ce/pkg/unfurl"
+)
+
+var _ = Describe("Unfurl", Ordered, func() {
+	var server *httptest.Server
+
+	BeforeAll(func() {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/og", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte(`<!DOCTYPE html><html><head>
+<title>Fallback Title</title>
+<meta property="og:title" content="泡泡 OpenGraph">
+<meta property="og:description" content="a tiny description">
+<meta property="og:image" content="/static/cover.png">
+<meta property="og:site_name" content="PaoPao">
+</head><body><meta property="og:title" content="ignored"></body></html>`))
+		})
+		mux.HandleFunc("/plain", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(`<html><head><title> Plain Page </title>
+<meta name="description" content="plain description"></head></html>`))
+		})
+		mux.HandleFunc("/gbk", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html; charset=gbk")
+			// "中文" in GBK
+			w.Write([]byte("<html><head><title>\xd6\xd0\xce\xc4</title></head></html>"))
+		})
+		mux.HandleFunc("/json", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{}`))
+		})
+		mux.HandleFunc("/huge", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html><head><!--" + strings.Repeat("x", 4096) + "--><title>Too Far</title></head></html>"))
+		})
+		mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(500 * time.Millisecond)
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html><head><title>Slow</title></head></html>"))
+		})
+		mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/redirect", http.StatusFound)
+		})
+		mux.HandleFunc("/image.png", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "image/png")
+			w.Write(make([]byte, 64))
+		})
+		server = httptest.NewServer(mux)
+	})
+
+	AfterAll(func() {
+		server.Close()
+	})
+
+	It("parse opengraph meta", func() {
+		u := unfurl.New(time.Second, 1<<20, unfurl.AllowPrivate())
+		preview, err := u.Unfurl(context.Background(), server.URL+"/og")
+		Expect(err).To(BeNil())
+		Expect(preview.URL).To(Equal(server.URL + "/og"))
+		Expect(preview.Title).To(Equal("泡泡 OpenGraph"))
+		Expect(preview.Description).To(Equal("a tiny description"))
+		Expect(preview.Image).To(Equal(server.URL + "/static/cover.png"))
+		Expect(preview.SiteName).To(Equal("PaoPao"))
+	})
+
+	It("fallback to title and description", func() {
+		u := unfurl.New(time.Second, 1<<20, unfurl.AllowPrivate())
+		preview, err := u.Unfurl(context.Background(), server.URL+"/plain")
+		Expect(err).To(BeNil())
+		Expect(preview.Title).To(Equal("Plain Page"))
+		Expect(preview.Description).To(Equal("plain description"))
+		Expect(preview.Image).To(BeEmpty())
+		Expect(preview.SiteName).To(Equal("127.0.0.1"))
+	})
+
+	It("decode non utf-8 charset", func() {
+		u := unfurl.New(time.Second, 1<<20, unfurl.AllowPrivate())
+		preview, err := u.Unfurl(context.Background(), server.URL+"/gbk")
+		Expect(err).To(BeNil())
+		Expect(preview.Title).To(Equal("中文"))
+	})
+
+	It("reject private address by default", func() {
+		u := unfurl.New(time.Second, 1<<20)
+		_, err := u.Unfurl(context.Background(), server.URL+"/og")
+		Expect(err).To(Equal(unfurl.ErrForbiddenAddr))
+	})
+
+	It("reject invalid url and content type", func() {
+		u := unfurl.New(time.Second, 1<<20, unfurl.AllowPrivate())
+		_, err := u.Unfurl(context.Background(), "ftp://example.com/file")
+		Expect(err).To(Equal(unfurl.ErrInvalidURL))
+		_, err = u.Unfurl(context.Background(), server.URL+"/json")
+		Expect(err).To(Equal(unfurl.ErrUnexpectedType))
+	})
+
+	It("truncate body over size cap", func() {
+		u := unfurl.New(time.Second, 1024, unfurl.AllowPrivate())
+		preview, err := u.Unfurl(context.Background(), server.URL+"/huge")
+		Expect(err).To(BeNil())
+		Expect(preview.Title).To(BeEmpty())
+	})
+
+	It("stop on timeout and redirect loop", func() {
+		u := unfurl.New(100*time.Millisecond, 1<<20, unfurl.AllowPrivate())
+		_, err := u.Unfurl(context.Background(), server.URL+"/slow")
+		Expect(err).NotTo(BeNil())
+		_, err = u.Unfurl(context.Background(), server.URL+"/redirect")
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("fetch image with size cap", func() {
+		u := unfurl.New(time.Second, 1<<20, unfurl.AllowPrivate())
+		data, contentType, err := u.FetchImage(context.Background(), server.URL+"/image.png", 128)
+		Expect(err).To(BeNil())
+		Expect(contentType).To(Equal("image/png"))
+		Expect(data).To(HaveLen(64))
+		_, _, err = u.FetchImage(context.Background(), server.URL+"/image.png", 32)
+		Expect(err).To(Equal(unfurl.ErrTooLarge))
+		_, _, err = u.FetchImage(context.Background(), server.URL+"/og", 1024)
+		Expect(err).To(Equal(unfurl.ErrUnexpectedType))
+	})
+
+	It("check public ip", func() {
+		for _, ip := range []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "100.64.0.1", "0.0.0.0", "::1", "fc00::1", "fe80::1"} {
+			Expect(unfurl.IsPublicIP(net.ParseIP(ip))).To(BeFalse(), ip)
+		}
+		for _, ip := range []string{"8.8.8.8", "1.1.1.1", "2606:4700:4700::1111"} {
+			Expect(unfurl.IsPublicIP(net.ParseIP(ip))).To(BeTrue(), ip)
+		}
+	})
+})
@@ -0,0 +1,17 @@
+ALTER TABLE `p_post` ADD COLUMN `edited_on` BIGINT NOT NULL DEFAULT 0 COMMENT '最后编辑时间';
+
+CREATE TABLE `p_post_content_revision` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '历史内容ID',
+	`post_id` BIGINT NOT NULL DEFAULT '0' COMMENT 'POST ID',
+	`user_id` BIGINT NOT NULL DEFAULT '0' COMMENT '用户ID',
+	`revision` BIGINT NOT NULL DEFAULT '0' COMMENT '版本号，从1开始递增',
+	`content` varchar(4000) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '内容',
+	`type` tinyint NOT NULL DEFAULT '2' COMMENT '类型，1标题，2文字段落，3图片地址，4视频地址，5语音地址，6链接地址，7附件资源，8收费资源',
+	`sort` int NOT NULL DEFAULT '100' COMMENT '排序，越小越靠前',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	PRIMARY KEY (`id`) USING BTREE,
+	KEY `idx_post_content_revision_post_id_revision` (`post_id`, `revision`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='冒泡/文章内容编辑历史';
@@ -0,0 +1,2 @@
+DROP INDEX `idx_post_repost_id` ON `p_post`;
+ALTER TABLE `p_post` DROP COLUMN `repost_id`;
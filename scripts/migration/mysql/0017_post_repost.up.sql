@@ -0,0 +1,2 @@
+ALTER TABLE `p_post` ADD COLUMN `repost_id` BIGINT NOT NULL DEFAULT 0 COMMENT '转发的原动态ID，0为非转发';
+CREATE INDEX `idx_post_repost_id` ON `p_post` (`repost_id`) USING BTREE;
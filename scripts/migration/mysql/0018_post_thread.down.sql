@@ -0,0 +1,4 @@
+DROP INDEX `idx_post_thread_root_id` ON `p_post`;
+ALTER TABLE `p_post` DROP COLUMN `thread_root_id`;
+ALTER TABLE `p_post` DROP COLUMN `thread_parent_id`;
+ALTER TABLE `p_post` DROP COLUMN `thread_count`;
@@ -0,0 +1,16 @@
+CREATE TABLE `p_post_draft` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '草稿ID',
+	`user_id` BIGINT NOT NULL DEFAULT '0' COMMENT '用户ID',
+	`contents` TEXT CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '内容项，JSON格式',
+	`tags` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '标签',
+	`users` varchar(1024) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '@的用户名',
+	`visibility` tinyint NOT NULL DEFAULT '0' COMMENT '可见性: 0私密 10充电可见 20订阅可见 30保留 40保留 50好友可见 60关注可见 70保留 80保留 90公开',
+	`attachment_price` BIGINT NOT NULL DEFAULT '0' COMMENT '附件价格(分)',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	PRIMARY KEY (`id`) USING BTREE,
+	KEY `idx_post_draft_user_id` (`user_id`) USING BTREE,
+	KEY `idx_post_draft_modified_on` (`modified_on`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='冒泡/文章草稿';
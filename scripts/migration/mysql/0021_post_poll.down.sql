@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS `p_post_poll_vote`;
+DROP TABLE IF EXISTS `p_post_poll_option`;
+DROP TABLE IF EXISTS `p_post_poll`;
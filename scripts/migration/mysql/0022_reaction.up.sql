@@ -0,0 +1,64 @@
+ALTER TABLE `p_post` ADD COLUMN `reaction_count` BIGINT NOT NULL DEFAULT 0 COMMENT '表情回应数';
+
+-- 视图的列在创建时固定，需要重建以包含新增的列
+DROP VIEW IF EXISTS p_post_by_media;
+DROP VIEW IF EXISTS p_post_by_comment;
+
+CREATE VIEW p_post_by_media AS 
+SELECT post.* 
+FROM
+	( SELECT DISTINCT post_id FROM p_post_content WHERE ( TYPE = 3 OR TYPE = 4 OR TYPE = 7 OR TYPE = 8 ) AND is_del = 0 ) media
+	JOIN p_post post ON media.post_id = post.ID 
+WHERE
+	post.is_del = 0;
+
+CREATE VIEW p_post_by_comment AS 
+SELECT P.*, C.user_id comment_user_id
+FROM
+	(
+	SELECT
+		post_id,
+		user_id
+	FROM
+		p_comment 
+	WHERE
+		is_del = 0 UNION
+	SELECT
+		post_id,
+		reply.user_id user_id
+	FROM
+		p_comment_reply reply
+		JOIN p_comment COMMENT ON reply.comment_id = COMMENT.ID 
+	WHERE
+		reply.is_del = 0 
+		AND COMMENT.is_del = 0 
+	)
+	C JOIN p_post P ON C.post_id = P.ID 
+WHERE
+	P.is_del = 0;
+
+CREATE TABLE `p_post_reaction` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT 'ID',
+	`post_id` BIGINT NOT NULL DEFAULT '0' COMMENT 'POST ID',
+	`user_id` BIGINT NOT NULL DEFAULT '0' COMMENT '用户ID',
+	`reaction` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL DEFAULT '' COMMENT '表情回应',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE KEY `idx_post_reaction_post_user_reaction` (`post_id`, `user_id`, `reaction`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='冒泡/文章表情回应';
+
+CREATE TABLE `p_comment_reaction` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT 'ID',
+	`comment_id` BIGINT NOT NULL DEFAULT '0' COMMENT '评论ID',
+	`user_id` BIGINT NOT NULL DEFAULT '0' COMMENT '用户ID',
+	`reaction` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL DEFAULT '' COMMENT '表情回应',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE KEY `idx_comment_reaction_comment_user_reaction` (`comment_id`, `user_id`, `reaction`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='评论表情回应';
@@ -0,0 +1,3 @@
+DROP INDEX `idx_post_collection_folder_id` ON `p_post_collection`;
+ALTER TABLE `p_post_collection` DROP COLUMN `folder_id`;
+DROP TABLE IF EXISTS `p_post_collection_folder`;
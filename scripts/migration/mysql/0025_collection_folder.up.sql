@@ -0,0 +1,25 @@
+CREATE TABLE `p_post_collection_folder` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '收藏夹ID',
+	`user_id` BIGINT NOT NULL DEFAULT '0' COMMENT '用户ID',
+	`name` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '收藏夹名称',
+	`is_public` tinyint NOT NULL DEFAULT '0' COMMENT '是否公开 0 为私密、1 为公开',
+	`is_default` tinyint NOT NULL DEFAULT '0' COMMENT '是否默认收藏夹',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	`default_user_id` BIGINT GENERATED ALWAYS AS (IF(`is_default` = 1 AND `is_del` = 0, `user_id`, NULL)) VIRTUAL COMMENT '默认收藏夹的用户ID，保证每个用户只有一个默认收藏夹',
+	PRIMARY KEY (`id`) USING BTREE,
+	KEY `idx_post_collection_folder_user_id` (`user_id`) USING BTREE,
+	UNIQUE KEY `idx_post_collection_folder_default_user_id` (`default_user_id`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='冒泡/文章收藏夹';
+
+ALTER TABLE `p_post_collection` ADD COLUMN `folder_id` BIGINT NOT NULL DEFAULT '0' COMMENT '收藏夹ID';
+CREATE INDEX `idx_post_collection_folder_id` ON `p_post_collection` (`folder_id`);
+
+-- 已有收藏迁移到各用户的默认收藏夹
+INSERT INTO `p_post_collection_folder` (`user_id`, `name`, `is_public`, `is_default`, `created_on`, `modified_on`)
+SELECT DISTINCT `user_id`, '默认收藏夹', 0, 1, UNIX_TIMESTAMP(), UNIX_TIMESTAMP() FROM `p_post_collection` WHERE `is_del` = 0;
+
+UPDATE `p_post_collection` c JOIN `p_post_collection_folder` f ON f.`user_id` = c.`user_id` AND f.`is_default` = 1
+SET c.`folder_id` = f.`id`;
@@ -0,0 +1,41 @@
+ALTER TABLE `p_post` ADD COLUMN `is_sensitive` tinyint NOT NULL DEFAULT '0' COMMENT '敏感标记 0 为未标记、1 为作者标记、2 为管理员强制标记';
+ALTER TABLE `p_post` ADD COLUMN `content_warning` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '内容警告';
+ALTER TABLE `p_post_content` ADD COLUMN `is_sensitive` tinyint NOT NULL DEFAULT '0' COMMENT '是否敏感媒体';
+ALTER TABLE `p_user` ADD COLUMN `show_sensitive` tinyint NOT NULL DEFAULT '0' COMMENT '是否直接展示敏感内容';
+
+-- 视图的列在创建时固定，需要重建以包含新增的列
+DROP VIEW IF EXISTS p_post_by_media;
+DROP VIEW IF EXISTS p_post_by_comment;
+
+CREATE VIEW p_post_by_media AS 
+SELECT post.* 
+FROM
+	( SELECT DISTINCT post_id FROM p_post_content WHERE ( TYPE = 3 OR TYPE = 4 OR TYPE = 7 OR TYPE = 8 ) AND is_del = 0 ) media
+	JOIN p_post post ON media.post_id = post.ID 
+WHERE
+	post.is_del = 0;
+
+CREATE VIEW p_post_by_comment AS 
+SELECT P.*, C.user_id comment_user_id
+FROM
+	(
+	SELECT
+		post_id,
+		user_id
+	FROM
+		p_comment 
+	WHERE
+		is_del = 0 UNION
+	SELECT
+		post_id,
+		reply.user_id user_id
+	FROM
+		p_comment_reply reply
+		JOIN p_comment COMMENT ON reply.comment_id = COMMENT.ID 
+	WHERE
+		reply.is_del = 0 
+		AND COMMENT.is_del = 0 
+	)
+	C JOIN p_post P ON C.post_id = P.ID 
+WHERE
+	P.is_del = 0;
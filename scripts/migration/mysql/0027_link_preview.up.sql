@@ -0,0 +1,16 @@
+CREATE TABLE `p_link_preview` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '链接预览ID',
+	`url_hash` char(32) NOT NULL DEFAULT '' COMMENT '链接MD5',
+	`url` varchar(2000) NOT NULL DEFAULT '' COMMENT '链接地址',
+	`title` varchar(256) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '标题',
+	`description` varchar(512) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '描述',
+	`image` varchar(2000) NOT NULL DEFAULT '' COMMENT '预览图',
+	`site_name` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '站点名称',
+	`status` tinyint NOT NULL DEFAULT '0' COMMENT '抓取状态 1 为成功、2 为失败',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE KEY `idx_link_preview_url_hash` (`url_hash`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='链接预览';
@@ -0,0 +1,16 @@
+CREATE TABLE `p_mention` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '提及ID',
+	`user_id` BIGINT NOT NULL DEFAULT '0' COMMENT '被@的用户ID',
+	`author_id` BIGINT NOT NULL DEFAULT '0' COMMENT '发起@的用户ID',
+	`post_id` BIGINT NOT NULL DEFAULT '0' COMMENT 'POST ID',
+	`comment_id` BIGINT NOT NULL DEFAULT '0' COMMENT '评论ID',
+	`reply_id` BIGINT NOT NULL DEFAULT '0' COMMENT '回复ID',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	PRIMARY KEY (`id`) USING BTREE,
+	KEY `idx_mention_user_id` (`user_id`) USING BTREE,
+	KEY `idx_mention_post_id` (`post_id`) USING BTREE,
+	KEY `idx_mention_comment_id` (`comment_id`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='@提及';
@@ -0,0 +1,14 @@
+CREATE TABLE `p_post_trash` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '回收站ID',
+	`post_id` BIGINT NOT NULL DEFAULT '0' COMMENT 'POST ID',
+	`user_id` BIGINT NOT NULL DEFAULT '0' COMMENT '推文作者ID',
+	`operator_id` BIGINT NOT NULL DEFAULT '0' COMMENT '删除操作者ID，作者本人或管理员',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '恢复或清除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	PRIMARY KEY (`id`) USING BTREE,
+	KEY `idx_post_trash_post_id` (`post_id`) USING BTREE,
+	KEY `idx_post_trash_user_id` (`user_id`) USING BTREE,
+	KEY `idx_post_trash_created_on` (`created_on`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='推文回收站';
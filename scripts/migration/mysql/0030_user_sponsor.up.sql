@@ -0,0 +1,14 @@
+CREATE TABLE `p_user_sponsor` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '付费支持ID',
+	`user_id` BIGINT NOT NULL DEFAULT '0' COMMENT '支持者ID',
+	`creator_id` BIGINT NOT NULL DEFAULT '0' COMMENT '被支持的创作者ID',
+	`charged_amount` BIGINT NOT NULL DEFAULT '0' COMMENT '累计充电金额(分)',
+	`subscribe_expired_on` BIGINT NOT NULL DEFAULT '0' COMMENT '订阅到期时间',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE KEY `idx_user_sponsor_user_creator` (`user_id`, `creator_id`) USING BTREE,
+	KEY `idx_user_sponsor_creator_id` (`creator_id`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='用户对创作者的充电与订阅';
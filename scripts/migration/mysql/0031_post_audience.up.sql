@@ -0,0 +1,12 @@
+CREATE TABLE `p_post_audience` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '推文可见分组ID',
+	`post_id` BIGINT NOT NULL DEFAULT '0' COMMENT '推文ID',
+	`group_id` BIGINT NOT NULL DEFAULT '0' COMMENT '联系人分组ID',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	PRIMARY KEY (`id`) USING BTREE,
+	KEY `idx_post_audience_post_id` (`post_id`) USING BTREE,
+	KEY `idx_post_audience_group_id` (`group_id`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='分组可见推文的可见分组';
@@ -0,0 +1,17 @@
+CREATE TABLE `p_user_session` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '会话ID',
+	`user_id` BIGINT NOT NULL DEFAULT '0' COMMENT '用户ID',
+	`device` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '登录设备',
+	`ip` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '最近访问IP地址',
+	`ip_loc` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '最近访问IP城市地址',
+	`refresh_token` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '刷新令牌的SHA256摘要',
+	`expires_on` BIGINT NOT NULL DEFAULT '0' COMMENT '刷新令牌过期时间',
+	`last_seen_on` BIGINT NOT NULL DEFAULT '0' COMMENT '最近访问时间',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	PRIMARY KEY (`id`) USING BTREE,
+	KEY `idx_user_session_user_id` (`user_id`) USING BTREE,
+	KEY `idx_user_session_refresh_token` (`refresh_token`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='用户登录会话';
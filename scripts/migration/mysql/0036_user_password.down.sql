@@ -0,0 +1 @@
+ALTER TABLE `p_user` MODIFY COLUMN `password` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT 'MD5密码';
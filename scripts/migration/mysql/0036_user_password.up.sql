@@ -0,0 +1 @@
+ALTER TABLE `p_user` MODIFY COLUMN `password` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '密码摘要，bcrypt/argon2id摘要自带算法标识，MD5摘要需配合盐值';
@@ -0,0 +1,6 @@
+ALTER TABLE `p_post_draft` ADD COLUMN `is_sensitive` tinyint NOT NULL DEFAULT '0' COMMENT '是否敏感内容';
+ALTER TABLE `p_post_draft` ADD COLUMN `content_warning` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '内容警告';
+ALTER TABLE `p_post_draft` ADD COLUMN `group_ids` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '分组可见的分组ID';
+ALTER TABLE `p_post_draft` ADD COLUMN `is_story` tinyint NOT NULL DEFAULT '0' COMMENT '是否限时推文';
+ALTER TABLE `p_post_draft` ADD COLUMN `expires_in` BIGINT NOT NULL DEFAULT '0' COMMENT '限时推文的有效时长(秒)';
+ALTER TABLE `p_post_draft` ADD COLUMN `reply_policy` tinyint NOT NULL DEFAULT '0' COMMENT '回复权限 0所有人 1关注者 2好友 3被@的用户 4仅作者';
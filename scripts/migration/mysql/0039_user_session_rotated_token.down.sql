@@ -0,0 +1,2 @@
+ALTER TABLE `p_user_session` DROP INDEX `idx_user_session_rotated_token`;
+ALTER TABLE `p_user_session` DROP COLUMN `rotated_token`;
@@ -0,0 +1,2 @@
+ALTER TABLE `p_user_session` ADD COLUMN `rotated_token` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '上一个已轮换的刷新令牌的SHA256摘要，用于检测重放';
+ALTER TABLE `p_user_session` ADD INDEX `idx_user_session_rotated_token` (`rotated_token`) USING BTREE;
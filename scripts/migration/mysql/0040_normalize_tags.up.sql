@@ -0,0 +1,2 @@
+-- 话题规范化(NFKC与大小写折叠)无法在SQL中完成，由程序在迁移到此版本后合并已有话题，这里不做改动
+SELECT 1;
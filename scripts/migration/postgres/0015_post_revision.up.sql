@@ -0,0 +1,16 @@
+ALTER TABLE p_post ADD COLUMN edited_on BIGINT NOT NULL DEFAULT 0; -- 最后编辑时间
+
+CREATE TABLE p_post_content_revision (
+	id BIGSERIAL PRIMARY KEY,
+	post_id BIGINT NOT NULL DEFAULT 0,
+	user_id BIGINT NOT NULL DEFAULT 0,
+	revision BIGINT NOT NULL DEFAULT 0, -- 版本号，从1开始递增
+	content TEXT NOT NULL DEFAULT '',
+	"type" SMALLINT NOT NULL DEFAULT 2, -- 类型，1标题，2文字段落，3图片地址，4视频地址，5语音地址，6链接地址，7附件资源，8收费资源
+	sort SMALLINT NOT NULL DEFAULT 100,
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_post_content_revision_post_id_revision ON p_post_content_revision USING btree (post_id, revision);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_post_repost_id;
+ALTER TABLE p_post DROP COLUMN repost_id;
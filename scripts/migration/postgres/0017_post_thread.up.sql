@@ -0,0 +1,4 @@
+ALTER TABLE p_post ADD COLUMN thread_root_id BIGINT NOT NULL DEFAULT 0; -- 所属串推的首条动态ID，0为串推首条或非串推
+ALTER TABLE p_post ADD COLUMN thread_parent_id BIGINT NOT NULL DEFAULT 0; -- 串推中的上一条动态ID
+ALTER TABLE p_post ADD COLUMN thread_count BIGINT NOT NULL DEFAULT 0; -- 串推后续动态数，仅首条有效
+CREATE INDEX idx_post_thread_root_id ON p_post USING btree (thread_root_id);
@@ -0,0 +1,41 @@
+CREATE TABLE p_post_poll (
+	id BIGSERIAL PRIMARY KEY,
+	post_id BIGINT NOT NULL DEFAULT 0,
+	user_id BIGINT NOT NULL DEFAULT 0,
+	multiple SMALLINT NOT NULL DEFAULT 0, -- 是否多选 0 为单选、1 为多选
+	expires_at BIGINT NOT NULL DEFAULT 0, -- 截止时间，0 为不截止
+	vote_count BIGINT NOT NULL DEFAULT 0, -- 参与投票的人数
+	closed_on BIGINT NOT NULL DEFAULT 0, -- 结束处理时间
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_post_poll_post_id ON p_post_poll USING btree (post_id);
+CREATE INDEX idx_post_poll_expires_at ON p_post_poll USING btree (closed_on, expires_at);
+
+CREATE TABLE p_post_poll_option (
+	id BIGSERIAL PRIMARY KEY,
+	poll_id BIGINT NOT NULL DEFAULT 0,
+	content VARCHAR(255) NOT NULL DEFAULT '',
+	sort BIGINT NOT NULL DEFAULT 100,
+	vote_count BIGINT NOT NULL DEFAULT 0,
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_post_poll_option_poll_id ON p_post_poll_option USING btree (poll_id);
+
+CREATE TABLE p_post_poll_vote (
+	id BIGSERIAL PRIMARY KEY,
+	poll_id BIGINT NOT NULL DEFAULT 0,
+	option_id BIGINT NOT NULL DEFAULT 0,
+	user_id BIGINT NOT NULL DEFAULT 0,
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE UNIQUE INDEX idx_post_poll_vote_poll_option_user ON p_post_poll_vote USING btree (poll_id, option_id, user_id);
+CREATE INDEX idx_post_poll_vote_user_id ON p_post_poll_vote USING btree (user_id);
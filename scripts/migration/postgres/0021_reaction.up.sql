@@ -0,0 +1,62 @@
+ALTER TABLE p_post ADD COLUMN reaction_count BIGINT NOT NULL DEFAULT 0; -- 表情回应数
+
+-- 视图的列在创建时固定，需要重建以包含新增的列
+DROP VIEW IF EXISTS p_post_by_media;
+DROP VIEW IF EXISTS p_post_by_comment;
+
+CREATE VIEW p_post_by_media AS 
+SELECT post.* 
+FROM
+	( SELECT DISTINCT post_id FROM p_post_content WHERE ( TYPE = 3 OR TYPE = 4 OR TYPE = 7 OR TYPE = 8 ) AND is_del = 0 ) media
+	JOIN p_post post ON media.post_id = post.ID 
+WHERE
+	post.is_del = 0;
+
+CREATE VIEW p_post_by_comment AS 
+SELECT P.*, C.user_id comment_user_id
+FROM
+	(
+	SELECT
+		post_id,
+		user_id
+	FROM
+		p_comment 
+	WHERE
+		is_del = 0 UNION
+	SELECT
+		post_id,
+		reply.user_id user_id
+	FROM
+		p_comment_reply reply
+		JOIN p_comment COMMENT ON reply.comment_id = COMMENT.ID 
+	WHERE
+		reply.is_del = 0 
+		AND COMMENT.is_del = 0 
+	)
+	C JOIN p_post P ON C.post_id = P.ID 
+WHERE
+	P.is_del = 0;
+
+CREATE TABLE p_post_reaction (
+	id BIGSERIAL PRIMARY KEY,
+	post_id BIGINT NOT NULL DEFAULT 0,
+	user_id BIGINT NOT NULL DEFAULT 0,
+	reaction VARCHAR(32) NOT NULL DEFAULT '', -- 表情回应
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE UNIQUE INDEX idx_post_reaction_post_user_reaction ON p_post_reaction USING btree (post_id, user_id, reaction);
+
+CREATE TABLE p_comment_reaction (
+	id BIGSERIAL PRIMARY KEY,
+	comment_id BIGINT NOT NULL DEFAULT 0,
+	user_id BIGINT NOT NULL DEFAULT 0,
+	reaction VARCHAR(32) NOT NULL DEFAULT '', -- 表情回应
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE UNIQUE INDEX idx_comment_reaction_comment_user_reaction ON p_comment_reaction USING btree (comment_id, user_id, reaction);
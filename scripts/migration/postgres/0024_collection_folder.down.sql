@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_post_collection_folder_id;
+DROP INDEX IF EXISTS idx_post_collection_folder_default_user_id;
+ALTER TABLE p_post_collection DROP COLUMN folder_id;
+DROP TABLE IF EXISTS p_post_collection_folder;
@@ -0,0 +1,24 @@
+CREATE TABLE p_post_collection_folder (
+	id BIGSERIAL PRIMARY KEY,
+	user_id BIGINT NOT NULL DEFAULT 0,
+	name VARCHAR(64) NOT NULL DEFAULT '', -- 收藏夹名称
+	is_public SMALLINT NOT NULL DEFAULT 0, -- 是否公开 0 为私密、1 为公开
+	is_default SMALLINT NOT NULL DEFAULT 0, -- 是否默认收藏夹
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_post_collection_folder_user_id ON p_post_collection_folder USING btree (user_id);
+CREATE UNIQUE INDEX idx_post_collection_folder_default_user_id ON p_post_collection_folder USING btree (user_id) WHERE is_default = 1 AND is_del = 0;
+
+ALTER TABLE p_post_collection ADD COLUMN folder_id BIGINT NOT NULL DEFAULT 0;
+CREATE INDEX idx_post_collection_folder_id ON p_post_collection USING btree (folder_id);
+
+-- 已有收藏迁移到各用户的默认收藏夹
+INSERT INTO p_post_collection_folder (user_id, name, is_public, is_default, created_on, modified_on)
+SELECT DISTINCT user_id, '默认收藏夹', 0, 1, EXTRACT(EPOCH FROM NOW())::BIGINT, EXTRACT(EPOCH FROM NOW())::BIGINT FROM p_post_collection WHERE is_del = 0;
+
+UPDATE p_post_collection c SET folder_id = f.id
+FROM p_post_collection_folder f
+WHERE f.user_id = c.user_id AND f.is_default = 1;
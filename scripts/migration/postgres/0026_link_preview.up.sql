@@ -0,0 +1,15 @@
+CREATE TABLE p_link_preview (
+	id BIGSERIAL PRIMARY KEY,
+	url_hash CHAR(32) NOT NULL DEFAULT '', -- 链接MD5
+	url VARCHAR(2000) NOT NULL DEFAULT '',
+	title VARCHAR(256) NOT NULL DEFAULT '',
+	description VARCHAR(512) NOT NULL DEFAULT '',
+	image VARCHAR(2000) NOT NULL DEFAULT '', -- 预览图
+	site_name VARCHAR(128) NOT NULL DEFAULT '',
+	status SMALLINT NOT NULL DEFAULT 0, -- 抓取状态 1 为成功、2 为失败
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE UNIQUE INDEX idx_link_preview_url_hash ON p_link_preview USING btree (url_hash);
@@ -0,0 +1,15 @@
+CREATE TABLE p_mention (
+	id BIGSERIAL PRIMARY KEY,
+	user_id BIGINT NOT NULL DEFAULT 0, -- 被@的用户ID
+	author_id BIGINT NOT NULL DEFAULT 0, -- 发起@的用户ID
+	post_id BIGINT NOT NULL DEFAULT 0,
+	comment_id BIGINT NOT NULL DEFAULT 0,
+	reply_id BIGINT NOT NULL DEFAULT 0,
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_mention_user_id ON p_mention USING btree (user_id);
+CREATE INDEX idx_mention_post_id ON p_mention USING btree (post_id);
+CREATE INDEX idx_mention_comment_id ON p_mention USING btree (comment_id);
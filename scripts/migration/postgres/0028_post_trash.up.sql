@@ -0,0 +1,13 @@
+CREATE TABLE p_post_trash (
+	id BIGSERIAL PRIMARY KEY,
+	post_id BIGINT NOT NULL DEFAULT 0,
+	user_id BIGINT NOT NULL DEFAULT 0, -- 推文作者ID
+	operator_id BIGINT NOT NULL DEFAULT 0, -- 删除操作者ID，作者本人或管理员
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_post_trash_post_id ON p_post_trash USING btree (post_id);
+CREATE INDEX idx_post_trash_user_id ON p_post_trash USING btree (user_id);
+CREATE INDEX idx_post_trash_created_on ON p_post_trash USING btree (created_on);
@@ -0,0 +1,13 @@
+CREATE TABLE p_user_sponsor (
+	id BIGSERIAL PRIMARY KEY,
+	user_id BIGINT NOT NULL DEFAULT 0, -- 支持者ID
+	creator_id BIGINT NOT NULL DEFAULT 0, -- 被支持的创作者ID
+	charged_amount BIGINT NOT NULL DEFAULT 0, -- 累计充电金额(分)
+	subscribe_expired_on BIGINT NOT NULL DEFAULT 0, -- 订阅到期时间
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE UNIQUE INDEX idx_user_sponsor_user_creator ON p_user_sponsor USING btree (user_id, creator_id);
+CREATE INDEX idx_user_sponsor_creator_id ON p_user_sponsor USING btree (creator_id);
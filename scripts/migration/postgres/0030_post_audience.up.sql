@@ -0,0 +1,11 @@
+CREATE TABLE p_post_audience (
+	id BIGSERIAL PRIMARY KEY,
+	post_id BIGINT NOT NULL DEFAULT 0, -- 推文ID
+	group_id BIGINT NOT NULL DEFAULT 0, -- 联系人分组ID
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_post_audience_post_id ON p_post_audience USING btree (post_id);
+CREATE INDEX idx_post_audience_group_id ON p_post_audience USING btree (group_id);
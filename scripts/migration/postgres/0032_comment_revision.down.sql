@@ -0,0 +1,3 @@
+ALTER TABLE p_comment DROP COLUMN edited_on;
+ALTER TABLE p_comment_reply DROP COLUMN edited_on;
+DROP TABLE IF EXISTS p_comment_revision;
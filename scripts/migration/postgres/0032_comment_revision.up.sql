@@ -0,0 +1,19 @@
+ALTER TABLE p_comment ADD COLUMN edited_on BIGINT NOT NULL DEFAULT 0; -- 最后编辑时间
+ALTER TABLE p_comment_reply ADD COLUMN edited_on BIGINT NOT NULL DEFAULT 0; -- 最后编辑时间
+
+CREATE TABLE p_comment_revision (
+	id BIGSERIAL PRIMARY KEY,
+	comment_id BIGINT NOT NULL DEFAULT 0,
+	reply_id BIGINT NOT NULL DEFAULT 0, -- 回复ID，0为评论本身的历史内容
+	user_id BIGINT NOT NULL DEFAULT 0,
+	editor_id BIGINT NOT NULL DEFAULT 0, -- 编辑者ID
+	revision BIGINT NOT NULL DEFAULT 0, -- 版本号，从1开始递增
+	content TEXT NOT NULL DEFAULT '',
+	"type" SMALLINT NOT NULL DEFAULT 2, -- 类型，1标题，2文字段落，3图片地址，4视频地址，5语音地址，6链接地址
+	sort SMALLINT NOT NULL DEFAULT 100,
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_comment_revision_comment_reply_revision ON p_comment_revision USING btree (comment_id, reply_id, revision);
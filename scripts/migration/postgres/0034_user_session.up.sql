@@ -0,0 +1,16 @@
+CREATE TABLE p_user_session (
+	id BIGSERIAL PRIMARY KEY,
+	user_id BIGINT NOT NULL DEFAULT 0,
+	device VARCHAR(255) NOT NULL DEFAULT '', -- 登录设备
+	ip VARCHAR(64) NOT NULL DEFAULT '', -- 最近访问IP地址
+	ip_loc VARCHAR(64) NOT NULL DEFAULT '',
+	refresh_token VARCHAR(64) NOT NULL DEFAULT '', -- 刷新令牌的SHA256摘要
+	expires_on BIGINT NOT NULL DEFAULT 0, -- 刷新令牌过期时间
+	last_seen_on BIGINT NOT NULL DEFAULT 0, -- 最近访问时间
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_user_session_user_id ON p_user_session USING btree (user_id);
+CREATE INDEX idx_user_session_refresh_token ON p_user_session USING btree (refresh_token);
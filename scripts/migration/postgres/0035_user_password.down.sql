@@ -0,0 +1 @@
+ALTER TABLE p_user ALTER COLUMN password SET DATA TYPE VARCHAR(32);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS p_user_otp;
+DROP TABLE IF EXISTS p_user_recovery_code;
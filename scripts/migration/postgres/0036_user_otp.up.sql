@@ -0,0 +1,24 @@
+CREATE TABLE p_user_otp (
+	id BIGSERIAL PRIMARY KEY,
+	user_id BIGINT NOT NULL DEFAULT 0,
+	secret VARCHAR(64) NOT NULL DEFAULT '', -- TOTP密钥
+	last_counter BIGINT NOT NULL DEFAULT 0, -- 最近使用的验证码周期，用于防止重放
+	enabled_on BIGINT NOT NULL DEFAULT 0, -- 开启时间，0为待确认
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_user_otp_user_id ON p_user_otp USING btree (user_id);
+
+CREATE TABLE p_user_recovery_code (
+	id BIGSERIAL PRIMARY KEY,
+	user_id BIGINT NOT NULL DEFAULT 0,
+	code VARCHAR(64) NOT NULL DEFAULT '', -- 恢复码的SHA256摘要
+	used_on BIGINT NOT NULL DEFAULT 0, -- 使用时间，0为未使用
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_user_recovery_code_user_id ON p_user_recovery_code USING btree (user_id);
@@ -0,0 +1,6 @@
+ALTER TABLE p_post_draft ADD COLUMN is_sensitive SMALLINT NOT NULL DEFAULT 0; -- 是否敏感内容
+ALTER TABLE p_post_draft ADD COLUMN content_warning VARCHAR(255) NOT NULL DEFAULT ''; -- 内容警告
+ALTER TABLE p_post_draft ADD COLUMN group_ids VARCHAR(255) NOT NULL DEFAULT ''; -- 分组可见的分组ID
+ALTER TABLE p_post_draft ADD COLUMN is_story SMALLINT NOT NULL DEFAULT 0; -- 是否限时推文
+ALTER TABLE p_post_draft ADD COLUMN expires_in BIGINT NOT NULL DEFAULT 0; -- 限时推文的有效时长(秒)
+ALTER TABLE p_post_draft ADD COLUMN reply_policy SMALLINT NOT NULL DEFAULT 0; -- 回复权限 0所有人 1关注者 2好友 3被@的用户 4仅作者
@@ -0,0 +1,2 @@
+ALTER TABLE p_user_session ADD COLUMN rotated_token VARCHAR(64) NOT NULL DEFAULT ''; -- 上一个已轮换的刷新令牌的SHA256摘要，用于检测重放
+CREATE INDEX idx_user_session_rotated_token ON p_user_session USING btree (rotated_token);
@@ -0,0 +1,3 @@
+ALTER TABLE "p_post" DROP COLUMN "edited_on";
+DROP INDEX IF EXISTS "idx_post_content_revision_post_id_revision";
+DROP TABLE IF EXISTS "p_post_content_revision";
@@ -0,0 +1,22 @@
+ALTER TABLE "p_post" ADD COLUMN "edited_on" integer NOT NULL DEFAULT 0;
+
+CREATE TABLE "p_post_content_revision" (
+	"id" integer,
+	"post_id" integer NOT NULL DEFAULT 0,
+	"user_id" integer NOT NULL DEFAULT 0,
+	"revision" integer NOT NULL DEFAULT 0,
+	"content" text NOT NULL DEFAULT '',
+	"type" integer NOT NULL DEFAULT 2,
+	"sort" integer NOT NULL DEFAULT 100,
+	"created_on" integer NOT NULL DEFAULT 0,
+	"modified_on" integer NOT NULL DEFAULT 0,
+	"deleted_on" integer NOT NULL DEFAULT 0,
+	"is_del" integer NOT NULL DEFAULT 0,
+	PRIMARY KEY ("id")
+);
+
+CREATE INDEX "idx_post_content_revision_post_id_revision"
+ON "p_post_content_revision" (
+	"post_id" ASC,
+	"revision" ASC
+);
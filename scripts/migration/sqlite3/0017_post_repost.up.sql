@@ -0,0 +1,5 @@
+ALTER TABLE "p_post" ADD COLUMN "repost_id" integer NOT NULL DEFAULT 0;
+CREATE INDEX "idx_post_repost_id"
+ON "p_post" (
+	"repost_id" ASC
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "idx_post_thread_root_id";
+ALTER TABLE "p_post" DROP COLUMN "thread_root_id";
+ALTER TABLE "p_post" DROP COLUMN "thread_parent_id";
+ALTER TABLE "p_post" DROP COLUMN "thread_count";
@@ -0,0 +1,7 @@
+ALTER TABLE "p_post" ADD COLUMN "thread_root_id" integer NOT NULL DEFAULT 0;
+ALTER TABLE "p_post" ADD COLUMN "thread_parent_id" integer NOT NULL DEFAULT 0;
+ALTER TABLE "p_post" ADD COLUMN "thread_count" integer NOT NULL DEFAULT 0;
+CREATE INDEX "idx_post_thread_root_id"
+ON "p_post" (
+	"thread_root_id" ASC
+);
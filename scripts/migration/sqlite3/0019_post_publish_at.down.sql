@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "idx_post_publish_at";
+ALTER TABLE "p_post" DROP COLUMN "publish_at";
@@ -0,0 +1,5 @@
+ALTER TABLE "p_post" ADD COLUMN "publish_at" integer NOT NULL DEFAULT 0;
+CREATE INDEX "idx_post_publish_at"
+ON "p_post" (
+	"publish_at" ASC
+);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "idx_post_draft_user_id";
+DROP INDEX IF EXISTS "idx_post_draft_modified_on";
+DROP TABLE IF EXISTS "p_post_draft";
@@ -0,0 +1,24 @@
+CREATE TABLE "p_post_draft" (
+	"id" integer,
+	"user_id" integer NOT NULL DEFAULT 0,
+	"contents" text NOT NULL DEFAULT '',
+	"tags" text(255) NOT NULL DEFAULT '',
+	"users" text(1024) NOT NULL DEFAULT '',
+	"visibility" integer NOT NULL DEFAULT 0,
+	"attachment_price" integer NOT NULL DEFAULT 0,
+	"created_on" integer NOT NULL DEFAULT 0,
+	"modified_on" integer NOT NULL DEFAULT 0,
+	"deleted_on" integer NOT NULL DEFAULT 0,
+	"is_del" integer NOT NULL DEFAULT 0,
+	PRIMARY KEY ("id")
+);
+
+CREATE INDEX "idx_post_draft_user_id"
+ON "p_post_draft" (
+	"user_id" ASC
+);
+
+CREATE INDEX "idx_post_draft_modified_on"
+ON "p_post_draft" (
+	"modified_on" ASC
+);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS "idx_post_poll_post_id";
+DROP INDEX IF EXISTS "idx_post_poll_expires_at";
+DROP INDEX IF EXISTS "idx_post_poll_option_poll_id";
+DROP INDEX IF EXISTS "idx_post_poll_vote_poll_option_user";
+DROP INDEX IF EXISTS "idx_post_poll_vote_user_id";
+DROP TABLE IF EXISTS "p_post_poll_vote";
+DROP TABLE IF EXISTS "p_post_poll_option";
+DROP TABLE IF EXISTS "p_post_poll";
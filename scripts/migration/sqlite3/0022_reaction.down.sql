@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "idx_post_reaction_post_user_reaction";
+DROP INDEX IF EXISTS "idx_comment_reaction_comment_user_reaction";
+DROP TABLE IF EXISTS "p_post_reaction";
+DROP TABLE IF EXISTS "p_comment_reaction";
+ALTER TABLE "p_post" DROP COLUMN "reaction_count";
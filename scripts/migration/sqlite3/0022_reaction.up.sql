@@ -0,0 +1,39 @@
+ALTER TABLE "p_post" ADD COLUMN "reaction_count" integer NOT NULL DEFAULT 0;
+
+CREATE TABLE "p_post_reaction" (
+	"id" integer,
+	"post_id" integer NOT NULL DEFAULT 0,
+	"user_id" integer NOT NULL DEFAULT 0,
+	"reaction" text(32) NOT NULL DEFAULT '',
+	"created_on" integer NOT NULL DEFAULT 0,
+	"modified_on" integer NOT NULL DEFAULT 0,
+	"deleted_on" integer NOT NULL DEFAULT 0,
+	"is_del" integer NOT NULL DEFAULT 0,
+	PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX "idx_post_reaction_post_user_reaction"
+ON "p_post_reaction" (
+	"post_id" ASC,
+	"user_id" ASC,
+	"reaction" ASC
+);
+
+CREATE TABLE "p_comment_reaction" (
+	"id" integer,
+	"comment_id" integer NOT NULL DEFAULT 0,
+	"user_id" integer NOT NULL DEFAULT 0,
+	"reaction" text(32) NOT NULL DEFAULT '',
+	"created_on" integer NOT NULL DEFAULT 0,
+	"modified_on" integer NOT NULL DEFAULT 0,
+	"deleted_on" integer NOT NULL DEFAULT 0,
+	"is_del" integer NOT NULL DEFAULT 0,
+	PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX "idx_comment_reaction_comment_user_reaction"
+ON "p_comment_reaction" (
+	"comment_id" ASC,
+	"user_id" ASC,
+	"reaction" ASC
+);
@@ -0,0 +1 @@
+ALTER TABLE "p_post" DROP COLUMN "view_count";
@@ -0,0 +1 @@
+ALTER TABLE "p_post" ADD COLUMN "view_count" integer NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+ALTER TABLE "p_post" DROP COLUMN "pinned_on";
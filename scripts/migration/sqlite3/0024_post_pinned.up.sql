@@ -0,0 +1 @@
+ALTER TABLE "p_post" ADD COLUMN "pinned_on" integer NOT NULL DEFAULT 0;
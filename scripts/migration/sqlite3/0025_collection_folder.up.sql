@@ -0,0 +1,39 @@
+CREATE TABLE "p_post_collection_folder" (
+	"id" integer,
+	"user_id" integer NOT NULL DEFAULT 0,
+	"name" text(64) NOT NULL DEFAULT '',
+	"is_public" integer NOT NULL DEFAULT 0,
+	"is_default" integer NOT NULL DEFAULT 0,
+	"created_on" integer NOT NULL DEFAULT 0,
+	"modified_on" integer NOT NULL DEFAULT 0,
+	"deleted_on" integer NOT NULL DEFAULT 0,
+	"is_del" integer NOT NULL DEFAULT 0,
+	PRIMARY KEY ("id")
+);
+
+CREATE INDEX "idx_post_collection_folder_user_id"
+ON "p_post_collection_folder" (
+	"user_id" ASC
+);
+
+CREATE UNIQUE INDEX "idx_post_collection_folder_default_user_id"
+ON "p_post_collection_folder" (
+	"user_id" ASC
+) WHERE "is_default" = 1 AND "is_del" = 0;
+
+ALTER TABLE "p_post_collection" ADD COLUMN "folder_id" integer NOT NULL DEFAULT 0;
+
+CREATE INDEX "idx_post_collection_folder_id"
+ON "p_post_collection" (
+	"folder_id" ASC
+);
+
+-- 已有收藏迁移到各用户的默认收藏夹
+INSERT INTO "p_post_collection_folder" ("user_id", "name", "is_public", "is_default", "created_on", "modified_on")
+SELECT DISTINCT "user_id", '默认收藏夹', 0, 1, strftime('%s', 'now'), strftime('%s', 'now') FROM "p_post_collection" WHERE "is_del" = 0;
+
+UPDATE "p_post_collection" SET "folder_id" = (
+	SELECT f."id" FROM "p_post_collection_folder" f
+	WHERE f."user_id" = "p_post_collection"."user_id" AND f."is_default" = 1
+)
+WHERE "user_id" IN (SELECT "user_id" FROM "p_post_collection_folder" WHERE "is_default" = 1);
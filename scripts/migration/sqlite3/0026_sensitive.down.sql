@@ -0,0 +1,4 @@
+ALTER TABLE "p_user" DROP COLUMN "show_sensitive";
+ALTER TABLE "p_post_content" DROP COLUMN "is_sensitive";
+ALTER TABLE "p_post" DROP COLUMN "content_warning";
+ALTER TABLE "p_post" DROP COLUMN "is_sensitive";
@@ -0,0 +1,4 @@
+ALTER TABLE "p_post" ADD COLUMN "is_sensitive" integer NOT NULL DEFAULT 0;
+ALTER TABLE "p_post" ADD COLUMN "content_warning" text(255) NOT NULL DEFAULT '';
+ALTER TABLE "p_post_content" ADD COLUMN "is_sensitive" integer NOT NULL DEFAULT 0;
+ALTER TABLE "p_user" ADD COLUMN "show_sensitive" integer NOT NULL DEFAULT 0;
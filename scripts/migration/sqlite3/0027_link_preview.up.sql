@@ -0,0 +1,20 @@
+CREATE TABLE "p_link_preview" (
+	"id" integer,
+	"url_hash" text(32) NOT NULL DEFAULT '',
+	"url" text(2000) NOT NULL DEFAULT '',
+	"title" text(256) NOT NULL DEFAULT '',
+	"description" text(512) NOT NULL DEFAULT '',
+	"image" text(2000) NOT NULL DEFAULT '',
+	"site_name" text(128) NOT NULL DEFAULT '',
+	"status" integer NOT NULL DEFAULT 0,
+	"created_on" integer NOT NULL DEFAULT 0,
+	"modified_on" integer NOT NULL DEFAULT 0,
+	"deleted_on" integer NOT NULL DEFAULT 0,
+	"is_del" integer NOT NULL DEFAULT 0,
+	PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX "idx_link_preview_url_hash"
+ON "p_link_preview" (
+	"url_hash" ASC
+);
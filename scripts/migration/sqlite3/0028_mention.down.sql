@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "idx_mention_comment_id";
+DROP INDEX IF EXISTS "idx_mention_post_id";
+DROP INDEX IF EXISTS "idx_mention_user_id";
+DROP TABLE IF EXISTS "p_mention";
@@ -0,0 +1,28 @@
+CREATE TABLE "p_mention" (
+	"id" integer,
+	"user_id" integer NOT NULL DEFAULT 0,
+	"author_id" integer NOT NULL DEFAULT 0,
+	"post_id" integer NOT NULL DEFAULT 0,
+	"comment_id" integer NOT NULL DEFAULT 0,
+	"reply_id" integer NOT NULL DEFAULT 0,
+	"created_on" integer NOT NULL DEFAULT 0,
+	"modified_on" integer NOT NULL DEFAULT 0,
+	"deleted_on" integer NOT NULL DEFAULT 0,
+	"is_del" integer NOT NULL DEFAULT 0,
+	PRIMARY KEY ("id")
+);
+
+CREATE INDEX "idx_mention_user_id"
+ON "p_mention" (
+	"user_id" ASC
+);
+
+CREATE INDEX "idx_mention_post_id"
+ON "p_mention" (
+	"post_id" ASC
+);
+
+CREATE INDEX "idx_mention_comment_id"
+ON "p_mention" (
+	"comment_id" ASC
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "idx_post_trash_created_on";
+DROP INDEX IF EXISTS "idx_post_trash_user_id";
+DROP INDEX IF EXISTS "idx_post_trash_post_id";
+DROP TABLE IF EXISTS "p_post_trash";
@@ -0,0 +1,26 @@
+CREATE TABLE "p_post_trash" (
+	"id" integer,
+	"post_id" integer NOT NULL DEFAULT 0,
+	"user_id" integer NOT NULL DEFAULT 0,
+	"operator_id" integer NOT NULL DEFAULT 0,
+	"created_on" integer NOT NULL DEFAULT 0,
+	"modified_on" integer NOT NULL DEFAULT 0,
+	"deleted_on" integer NOT NULL DEFAULT 0,
+	"is_del" integer NOT NULL DEFAULT 0,
+	PRIMARY KEY ("id")
+);
+
+CREATE INDEX "idx_post_trash_post_id"
+ON "p_post_trash" (
+	"post_id" ASC
+);
+
+CREATE INDEX "idx_post_trash_user_id"
+ON "p_post_trash" (
+	"user_id" ASC
+);
+
+CREATE INDEX "idx_post_trash_created_on"
+ON "p_post_trash" (
+	"created_on" ASC
+);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "idx_user_sponsor_creator_id";
+DROP INDEX IF EXISTS "idx_user_sponsor_user_creator";
+DROP TABLE IF EXISTS "p_user_sponsor";
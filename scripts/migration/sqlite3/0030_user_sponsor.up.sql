@@ -0,0 +1,23 @@
+CREATE TABLE "p_user_sponsor" (
+	"id" integer,
+	"user_id" integer NOT NULL DEFAULT 0,
+	"creator_id" integer NOT NULL DEFAULT 0,
+	"charged_amount" integer NOT NULL DEFAULT 0,
+	"subscribe_expired_on" integer NOT NULL DEFAULT 0,
+	"created_on" integer NOT NULL DEFAULT 0,
+	"modified_on" integer NOT NULL DEFAULT 0,
+	"deleted_on" integer NOT NULL DEFAULT 0,
+	"is_del" integer NOT NULL DEFAULT 0,
+	PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX "idx_user_sponsor_user_creator"
+ON "p_user_sponsor" (
+	"user_id" ASC,
+	"creator_id" ASC
+);
+
+CREATE INDEX "idx_user_sponsor_creator_id"
+ON "p_user_sponsor" (
+	"creator_id" ASC
+);
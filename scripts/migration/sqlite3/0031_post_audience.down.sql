@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "idx_post_audience_group_id";
+DROP INDEX IF EXISTS "idx_post_audience_post_id";
+DROP TABLE IF EXISTS "p_post_audience";
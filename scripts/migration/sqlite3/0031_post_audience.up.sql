@@ -0,0 +1,20 @@
+CREATE TABLE "p_post_audience" (
+	"id" integer,
+	"post_id" integer NOT NULL DEFAULT 0,
+	"group_id" integer NOT NULL DEFAULT 0,
+	"created_on" integer NOT NULL DEFAULT 0,
+	"modified_on" integer NOT NULL DEFAULT 0,
+	"deleted_on" integer NOT NULL DEFAULT 0,
+	"is_del" integer NOT NULL DEFAULT 0,
+	PRIMARY KEY ("id")
+);
+
+CREATE INDEX "idx_post_audience_post_id"
+ON "p_post_audience" (
+	"post_id" ASC
+);
+
+CREATE INDEX "idx_post_audience_group_id"
+ON "p_post_audience" (
+	"group_id" ASC
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "idx_post_expires_at";
+ALTER TABLE "p_post" DROP COLUMN "expires_at";
@@ -0,0 +1,5 @@
+ALTER TABLE "p_post" ADD COLUMN "expires_at" integer NOT NULL DEFAULT 0;
+CREATE INDEX "idx_post_expires_at"
+ON "p_post" (
+	"expires_at" ASC
+);
@@ -0,0 +1,4 @@
+ALTER TABLE "p_comment" DROP COLUMN "edited_on";
+ALTER TABLE "p_comment_reply" DROP COLUMN "edited_on";
+DROP INDEX IF EXISTS "idx_comment_revision_comment_reply_revision";
+DROP TABLE IF EXISTS "p_comment_revision";
@@ -0,0 +1,26 @@
+ALTER TABLE "p_comment" ADD COLUMN "edited_on" integer NOT NULL DEFAULT 0;
+ALTER TABLE "p_comment_reply" ADD COLUMN "edited_on" integer NOT NULL DEFAULT 0;
+
+CREATE TABLE "p_comment_revision" (
+	"id" integer,
+	"comment_id" integer NOT NULL DEFAULT 0,
+	"reply_id" integer NOT NULL DEFAULT 0,
+	"user_id" integer NOT NULL DEFAULT 0,
+	"editor_id" integer NOT NULL DEFAULT 0,
+	"revision" integer NOT NULL DEFAULT 0,
+	"content" text NOT NULL DEFAULT '',
+	"type" integer NOT NULL DEFAULT 2,
+	"sort" integer NOT NULL DEFAULT 100,
+	"created_on" integer NOT NULL DEFAULT 0,
+	"modified_on" integer NOT NULL DEFAULT 0,
+	"deleted_on" integer NOT NULL DEFAULT 0,
+	"is_del" integer NOT NULL DEFAULT 0,
+	PRIMARY KEY ("id")
+);
+
+CREATE INDEX "idx_comment_revision_comment_reply_revision"
+ON "p_comment_revision" (
+	"comment_id" ASC,
+	"reply_id" ASC,
+	"revision" ASC
+);
@@ -0,0 +1 @@
+ALTER TABLE "p_post" ADD COLUMN "reply_policy" integer NOT NULL DEFAULT 0;
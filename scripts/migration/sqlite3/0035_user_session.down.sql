@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "idx_user_session_user_id";
+DROP INDEX IF EXISTS "idx_user_session_refresh_token";
+DROP TABLE IF EXISTS "p_user_session";
@@ -0,0 +1,25 @@
+CREATE TABLE "p_user_session" (
+	"id" integer,
+	"user_id" integer NOT NULL DEFAULT 0,
+	"device" text(255) NOT NULL DEFAULT '',
+	"ip" text(64) NOT NULL DEFAULT '',
+	"ip_loc" text(64) NOT NULL DEFAULT '',
+	"refresh_token" text(64) NOT NULL DEFAULT '',
+	"expires_on" integer NOT NULL DEFAULT 0,
+	"last_seen_on" integer NOT NULL DEFAULT 0,
+	"created_on" integer NOT NULL DEFAULT 0,
+	"modified_on" integer NOT NULL DEFAULT 0,
+	"deleted_on" integer NOT NULL DEFAULT 0,
+	"is_del" integer NOT NULL DEFAULT 0,
+	PRIMARY KEY ("id")
+);
+
+CREATE INDEX "idx_user_session_user_id"
+ON "p_user_session" (
+	"user_id" ASC
+);
+
+CREATE INDEX "idx_user_session_refresh_token"
+ON "p_user_session" (
+	"refresh_token" ASC
+);
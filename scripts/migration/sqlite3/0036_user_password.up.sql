@@ -0,0 +1 @@
+-- nothing, sqlite3 does not enforce the length of text(32), bcrypt/argon2id hashes fit into p_user.password as is
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "idx_user_otp_user_id";
+DROP INDEX IF EXISTS "idx_user_recovery_code_user_id";
+DROP TABLE IF EXISTS "p_user_otp";
+DROP TABLE IF EXISTS "p_user_recovery_code";
@@ -0,0 +1,34 @@
+CREATE TABLE "p_user_otp" (
+	"id" integer,
+	"user_id" integer NOT NULL DEFAULT 0,
+	"secret" text(64) NOT NULL DEFAULT '',
+	"last_counter" integer NOT NULL DEFAULT 0,
+	"enabled_on" integer NOT NULL DEFAULT 0,
+	"created_on" integer NOT NULL DEFAULT 0,
+	"modified_on" integer NOT NULL DEFAULT 0,
+	"deleted_on" integer NOT NULL DEFAULT 0,
+	"is_del" integer NOT NULL DEFAULT 0,
+	PRIMARY KEY ("id")
+);
+
+CREATE INDEX "idx_user_otp_user_id"
+ON "p_user_otp" (
+	"user_id" ASC
+);
+
+CREATE TABLE "p_user_recovery_code" (
+	"id" integer,
+	"user_id" integer NOT NULL DEFAULT 0,
+	"code" text(64) NOT NULL DEFAULT '',
+	"used_on" integer NOT NULL DEFAULT 0,
+	"created_on" integer NOT NULL DEFAULT 0,
+	"modified_on" integer NOT NULL DEFAULT 0,
+	"deleted_on" integer NOT NULL DEFAULT 0,
+	"is_del" integer NOT NULL DEFAULT 0,
+	PRIMARY KEY ("id")
+);
+
+CREATE INDEX "idx_user_recovery_code_user_id"
+ON "p_user_recovery_code" (
+	"user_id" ASC
+);
@@ -0,0 +1,6 @@
+ALTER TABLE "p_post_draft" DROP COLUMN "is_sensitive";
+ALTER TABLE "p_post_draft" DROP COLUMN "content_warning";
+ALTER TABLE "p_post_draft" DROP COLUMN "group_ids";
+ALTER TABLE "p_post_draft" DROP COLUMN "is_story";
+ALTER TABLE "p_post_draft" DROP COLUMN "expires_in";
+ALTER TABLE "p_post_draft" DROP COLUMN "reply_policy";
@@ -0,0 +1,6 @@
+ALTER TABLE "p_post_draft" ADD COLUMN "is_sensitive" integer NOT NULL DEFAULT 0;
+ALTER TABLE "p_post_draft" ADD COLUMN "content_warning" text(255) NOT NULL DEFAULT '';
+ALTER TABLE "p_post_draft" ADD COLUMN "group_ids" text(255) NOT NULL DEFAULT '';
+ALTER TABLE "p_post_draft" ADD COLUMN "is_story" integer NOT NULL DEFAULT 0;
+ALTER TABLE "p_post_draft" ADD COLUMN "expires_in" integer NOT NULL DEFAULT 0;
+ALTER TABLE "p_post_draft" ADD COLUMN "reply_policy" integer NOT NULL DEFAULT 0;
@@ -0,0 +1,6 @@
+ALTER TABLE "p_user_session" ADD COLUMN "rotated_token" text(64) NOT NULL DEFAULT '';
+
+CREATE INDEX "idx_user_session_rotated_token"
+ON "p_user_session" (
+	"rotated_token" ASC
+);
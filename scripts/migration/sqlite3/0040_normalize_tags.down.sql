@@ -0,0 +1,2 @@
+-- 合并后的话题无法拆分，回退时不做改动
+SELECT 1;
@@ -55,6 +55,7 @@ CREATE TABLE `p_comment` (
 	`reply_count` int NOT NULL DEFAULT 0 COMMENT '回复数',
 	`thumbs_up_count` int NOT NULL DEFAULT 0 COMMENT '点赞数',
 	`thumbs_down_count` int NOT NULL DEFAULT 0 COMMENT '点踩数',
+	`edited_on` BIGINT NOT NULL DEFAULT 0 COMMENT '最后编辑时间',
 	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
 	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
 	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
@@ -100,6 +101,7 @@ CREATE TABLE `p_comment_reply` (
 	`ip_loc` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT 'IP城市地址',
 	`thumbs_up_count` int NOT NULL DEFAULT '0' COMMENT '点赞数',
 	`thumbs_down_count` int NOT NULL DEFAULT '0' COMMENT '点踩数',
+	`edited_on` BIGINT NOT NULL DEFAULT 0 COMMENT '最后编辑时间',
 	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
 	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
 	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
@@ -108,6 +110,28 @@ CREATE TABLE `p_comment_reply` (
 	KEY `idx_comment_reply_comment_id` (`comment_id`) USING BTREE
 ) ENGINE=InnoDB AUTO_INCREMENT=12000015 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='评论回复';
 
+-- ----------------------------
+-- Table structure for p_comment_revision
+-- ----------------------------
+DROP TABLE IF EXISTS `p_comment_revision`;
+CREATE TABLE `p_comment_revision` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '历史内容ID',
+	`comment_id` BIGINT NOT NULL DEFAULT '0' COMMENT '评论ID',
+	`reply_id` BIGINT NOT NULL DEFAULT '0' COMMENT '回复ID，0为评论本身的历史内容',
+	`user_id` BIGINT NOT NULL DEFAULT '0' COMMENT '作者ID',
+	`editor_id` BIGINT NOT NULL DEFAULT '0' COMMENT '编辑者ID',
+	`revision` BIGINT NOT NULL DEFAULT '0' COMMENT '版本号，从1开始递增',
+	`content` varchar(4000) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '内容',
+	`type` tinyint NOT NULL DEFAULT '2' COMMENT '类型，1标题，2文字段落，3图片地址，4视频地址，5语音地址，6链接地址',
+	`sort` int NOT NULL DEFAULT '100' COMMENT '排序，越小越靠前',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	PRIMARY KEY (`id`) USING BTREE,
+	KEY `idx_comment_revision_comment_reply_revision` (`comment_id`, `reply_id`, `revision`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='评论/回复编辑历史';
+
 -- ----------------------------
 -- Table structure for p_comment_metric
 -- ----------------------------
@@ -148,6 +172,48 @@ CREATE TABLE `p_tweet_comment_thumbs` (
   KEY `idx_tweet_comment_thumbs_uid_tid` (`user_id`, `tweet_id`) USING BTREE
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='推文评论点赞';
 
+-- ----------------------------
+-- Table structure for p_link_preview
+-- ----------------------------
+DROP TABLE IF EXISTS `p_link_preview`;
+CREATE TABLE `p_link_preview` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '链接预览ID',
+	`url_hash` char(32) NOT NULL DEFAULT '' COMMENT '链接MD5',
+	`url` varchar(2000) NOT NULL DEFAULT '' COMMENT '链接地址',
+	`title` varchar(256) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '标题',
+	`description` varchar(512) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '描述',
+	`image` varchar(2000) NOT NULL DEFAULT '' COMMENT '预览图',
+	`site_name` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '站点名称',
+	`status` tinyint NOT NULL DEFAULT '0' COMMENT '抓取状态 1 为成功、2 为失败',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE KEY `idx_link_preview_url_hash` (`url_hash`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='链接预览';
+
+-- ----------------------------
+-- Table structure for p_mention
+-- ----------------------------
+DROP TABLE IF EXISTS `p_mention`;
+CREATE TABLE `p_mention` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '提及ID',
+	`user_id` BIGINT NOT NULL DEFAULT '0' COMMENT '被@的用户ID',
+	`author_id` BIGINT NOT NULL DEFAULT '0' COMMENT '发起@的用户ID',
+	`post_id` BIGINT NOT NULL DEFAULT '0' COMMENT 'POST ID',
+	`comment_id` BIGINT NOT NULL DEFAULT '0' COMMENT '评论ID',
+	`reply_id` BIGINT NOT NULL DEFAULT '0' COMMENT '回复ID',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	PRIMARY KEY (`id`) USING BTREE,
+	KEY `idx_mention_user_id` (`user_id`) USING BTREE,
+	KEY `idx_mention_post_id` (`post_id`) USING BTREE,
+	KEY `idx_mention_comment_id` (`comment_id`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='@提及';
+
 -- ----------------------------
 -- Table structure for p_message
 -- ----------------------------
@@ -184,7 +250,7 @@ CREATE TABLE `p_post` (
 	`collection_count` BIGINT NOT NULL DEFAULT '0' COMMENT '收藏数',
 	`upvote_count` BIGINT NOT NULL DEFAULT '0' COMMENT '点赞数',
 	`share_count` BIGINT NOT NULL DEFAULT '0' COMMENT '分享数',
-	`visibility` tinyint NOT NULL DEFAULT '0' COMMENT '可见性: 0私密 10充电可见 20订阅可见 30保留 40保留 50好友可见 60关注可见 70保留 80保留 90公开',
+	`visibility` tinyint NOT NULL DEFAULT '0' COMMENT '可见性: 0私密 10充电可见 20订阅可见 30分组可见 40保留 50好友可见 60关注可见 70保留 80保留 90公开',
 	`is_top` tinyint NOT NULL DEFAULT '0' COMMENT '是否置顶',
 	`is_essence` tinyint NOT NULL DEFAULT '0' COMMENT '是否精华',
 	`is_lock` tinyint NOT NULL DEFAULT '0' COMMENT '是否锁定',
@@ -193,13 +259,30 @@ CREATE TABLE `p_post` (
 	`attachment_price` BIGINT NOT NULL DEFAULT '0' COMMENT '附件价格(分)',
 	`ip` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT 'IP地址',
 	`ip_loc` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT 'IP城市地址',
+	`edited_on` BIGINT NOT NULL DEFAULT '0' COMMENT '最后编辑时间',
+	`repost_id` BIGINT NOT NULL DEFAULT '0' COMMENT '转发的原动态ID，0为非转发',
+	`thread_root_id` BIGINT NOT NULL DEFAULT '0' COMMENT '所属串推的首条动态ID，0为串推首条或非串推',
+	`thread_parent_id` BIGINT NOT NULL DEFAULT '0' COMMENT '串推中的上一条动态ID',
+	`thread_count` BIGINT NOT NULL DEFAULT '0' COMMENT '串推后续动态数，仅首条有效',
+	`publish_at` BIGINT NOT NULL DEFAULT '0' COMMENT '定时发布时间，0为已发布',
+	`reaction_count` BIGINT NOT NULL DEFAULT '0' COMMENT '表情回应数',
+	`view_count` BIGINT NOT NULL DEFAULT '0' COMMENT '浏览数',
+	`pinned_on` BIGINT NOT NULL DEFAULT '0' COMMENT '个人主页置顶时间，0为未置顶',
+	`is_sensitive` tinyint NOT NULL DEFAULT '0' COMMENT '敏感标记 0 为未标记、1 为作者标记、2 为管理员强制标记',
+	`content_warning` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '内容警告',
+	`expires_at` BIGINT NOT NULL DEFAULT '0' COMMENT '限时推文的过期时间，0为不过期',
+	`reply_policy` TINYINT NOT NULL DEFAULT '0' COMMENT '回复权限 0所有人 1关注者 2好友 3被@的用户 4仅作者',
 	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
 	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
 	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
 	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
 	PRIMARY KEY (`id`) USING BTREE,
 	KEY `idx_post_user_id` (`user_id`) USING BTREE,
-	KEY `idx_post_visibility` (`visibility`) USING BTREE
+	KEY `idx_post_visibility` (`visibility`) USING BTREE,
+	KEY `idx_post_repost_id` (`repost_id`) USING BTREE,
+	KEY `idx_post_thread_root_id` (`thread_root_id`) USING BTREE,
+	KEY `idx_post_publish_at` (`publish_at`) USING BTREE,
+	KEY `idx_post_expires_at` (`expires_at`) USING BTREE
 ) ENGINE=InnoDB AUTO_INCREMENT=1080017989 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='冒泡/文章';
 
 -- ----------------------------
@@ -247,15 +330,37 @@ CREATE TABLE `p_post_collection` (
 	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '收藏ID',
 	`post_id` BIGINT NOT NULL DEFAULT '0' COMMENT 'POST ID',
 	`user_id` BIGINT NOT NULL DEFAULT '0' COMMENT '用户ID',
+	`folder_id` BIGINT NOT NULL DEFAULT '0' COMMENT '收藏夹ID',
 	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
 	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
 	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
 	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
 	PRIMARY KEY (`id`) USING BTREE,
 	KEY `idx_post_collection_post_id` (`post_id`) USING BTREE,
-	KEY `idx_post_collection_user_id` (`user_id`) USING BTREE
+	KEY `idx_post_collection_user_id` (`user_id`) USING BTREE,
+	KEY `idx_post_collection_folder_id` (`folder_id`) USING BTREE
 ) ENGINE=InnoDB AUTO_INCREMENT=6000012 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='冒泡/文章收藏';
 
+-- ----------------------------
+-- Table structure for p_post_collection_folder
+-- ----------------------------
+DROP TABLE IF EXISTS `p_post_collection_folder`;
+CREATE TABLE `p_post_collection_folder` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '收藏夹ID',
+	`user_id` BIGINT NOT NULL DEFAULT '0' COMMENT '用户ID',
+	`name` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '收藏夹名称',
+	`is_public` tinyint NOT NULL DEFAULT '0' COMMENT '是否公开 0 为私密、1 为公开',
+	`is_default` tinyint NOT NULL DEFAULT '0' COMMENT '是否默认收藏夹',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	`default_user_id` BIGINT GENERATED ALWAYS AS (IF(`is_default` = 1 AND `is_del` = 0, `user_id`, NULL)) VIRTUAL COMMENT '默认收藏夹的用户ID，保证每个用户只有一个默认收藏夹',
+	PRIMARY KEY (`id`) USING BTREE,
+	KEY `idx_post_collection_folder_user_id` (`user_id`) USING BTREE,
+	UNIQUE KEY `idx_post_collection_folder_default_user_id` (`default_user_id`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='冒泡/文章收藏夹';
+
 -- ----------------------------
 -- Table structure for p_post_content
 -- ----------------------------
@@ -267,6 +372,7 @@ CREATE TABLE `p_post_content` (
 	`content` varchar(4000) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '内容',
 	`type` tinyint NOT NULL DEFAULT '2' COMMENT '类型，1标题，2文字段落，3图片地址，4视频地址，5语音地址，6链接地址，7附件资源，8收费资源',
 	`sort` int NOT NULL DEFAULT '100' COMMENT '排序，越小越靠前',
+	`is_sensitive` tinyint NOT NULL DEFAULT '0' COMMENT '是否敏感媒体',
 	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
 	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
 	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
@@ -276,6 +382,144 @@ CREATE TABLE `p_post_content` (
 	KEY `idx_post_content_user_id` (`user_id`) USING BTREE
 ) ENGINE=InnoDB AUTO_INCREMENT=180022546 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='冒泡/文章内容';
 
+-- ----------------------------
+-- Table structure for p_post_content_revision
+-- ----------------------------
+DROP TABLE IF EXISTS `p_post_content_revision`;
+CREATE TABLE `p_post_content_revision` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '历史内容ID',
+	`post_id` BIGINT NOT NULL DEFAULT '0' COMMENT 'POST ID',
+	`user_id` BIGINT NOT NULL DEFAULT '0' COMMENT '用户ID',
+	`revision` BIGINT NOT NULL DEFAULT '0' COMMENT '版本号，从1开始递增',
+	`content` varchar(4000) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '内容',
+	`type` tinyint NOT NULL DEFAULT '2' COMMENT '类型，1标题，2文字段落，3图片地址，4视频地址，5语音地址，6链接地址，7附件资源，8收费资源',
+	`sort` int NOT NULL DEFAULT '100' COMMENT '排序，越小越靠前',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	PRIMARY KEY (`id`) USING BTREE,
+	KEY `idx_post_content_revision_post_id_revision` (`post_id`, `revision`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='冒泡/文章内容编辑历史';
+
+-- ----------------------------
+-- Table structure for p_post_draft
+-- ----------------------------
+DROP TABLE IF EXISTS `p_post_draft`;
+CREATE TABLE `p_post_draft` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '草稿ID',
+	`user_id` BIGINT NOT NULL DEFAULT '0' COMMENT '用户ID',
+	`contents` TEXT CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '内容项，JSON格式',
+	`tags` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '标签',
+	`users` varchar(1024) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '@的用户名',
+	`visibility` tinyint NOT NULL DEFAULT '0' COMMENT '可见性: 0私密 10充电可见 20订阅可见 30分组可见 40保留 50好友可见 60关注可见 70保留 80保留 90公开',
+	`attachment_price` BIGINT NOT NULL DEFAULT '0' COMMENT '附件价格(分)',
+	`is_sensitive` tinyint NOT NULL DEFAULT '0' COMMENT '是否敏感内容',
+	`content_warning` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '内容警告',
+	`group_ids` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '分组可见的分组ID',
+	`is_story` tinyint NOT NULL DEFAULT '0' COMMENT '是否限时推文',
+	`expires_in` BIGINT NOT NULL DEFAULT '0' COMMENT '限时推文的有效时长(秒)',
+	`reply_policy` tinyint NOT NULL DEFAULT '0' COMMENT '回复权限 0所有人 1关注者 2好友 3被@的用户 4仅作者',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	PRIMARY KEY (`id`) USING BTREE,
+	KEY `idx_post_draft_user_id` (`user_id`) USING BTREE,
+	KEY `idx_post_draft_modified_on` (`modified_on`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='冒泡/文章草稿';
+
+-- ----------------------------
+-- Table structure for p_post_poll
+-- ----------------------------
+DROP TABLE IF EXISTS `p_post_poll`;
+CREATE TABLE `p_post_poll` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '投票ID',
+	`post_id` BIGINT NOT NULL DEFAULT '0' COMMENT 'POST ID',
+	`user_id` BIGINT NOT NULL DEFAULT '0' COMMENT '发起投票的用户ID',
+	`multiple` tinyint NOT NULL DEFAULT '0' COMMENT '是否多选 0 为单选、1 为多选',
+	`expires_at` BIGINT NOT NULL DEFAULT '0' COMMENT '截止时间，0 为不截止',
+	`vote_count` BIGINT NOT NULL DEFAULT '0' COMMENT '参与投票的人数',
+	`closed_on` BIGINT NOT NULL DEFAULT '0' COMMENT '结束处理时间',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	PRIMARY KEY (`id`) USING BTREE,
+	KEY `idx_post_poll_post_id` (`post_id`) USING BTREE,
+	KEY `idx_post_poll_expires_at` (`closed_on`, `expires_at`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='冒泡/文章投票';
+
+-- ----------------------------
+-- Table structure for p_post_poll_option
+-- ----------------------------
+DROP TABLE IF EXISTS `p_post_poll_option`;
+CREATE TABLE `p_post_poll_option` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '选项ID',
+	`poll_id` BIGINT NOT NULL DEFAULT '0' COMMENT '投票ID',
+	`content` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '选项内容',
+	`sort` BIGINT NOT NULL DEFAULT '100' COMMENT '排序，越小越靠前',
+	`vote_count` BIGINT NOT NULL DEFAULT '0' COMMENT '得票数',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	PRIMARY KEY (`id`) USING BTREE,
+	KEY `idx_post_poll_option_poll_id` (`poll_id`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='冒泡/文章投票选项';
+
+-- ----------------------------
+-- Table structure for p_post_poll_vote
+-- ----------------------------
+DROP TABLE IF EXISTS `p_post_poll_vote`;
+CREATE TABLE `p_post_poll_vote` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '投票记录ID',
+	`poll_id` BIGINT NOT NULL DEFAULT '0' COMMENT '投票ID',
+	`option_id` BIGINT NOT NULL DEFAULT '0' COMMENT '选项ID',
+	`user_id` BIGINT NOT NULL DEFAULT '0' COMMENT '投票用户ID',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE KEY `idx_post_poll_vote_poll_option_user` (`poll_id`, `option_id`, `user_id`) USING BTREE,
+	KEY `idx_post_poll_vote_user_id` (`user_id`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='冒泡/文章投票记录';
+
+-- ----------------------------
+-- Table structure for p_post_reaction
+-- ----------------------------
+DROP TABLE IF EXISTS `p_post_reaction`;
+CREATE TABLE `p_post_reaction` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT 'ID',
+	`post_id` BIGINT NOT NULL DEFAULT '0' COMMENT 'POST ID',
+	`user_id` BIGINT NOT NULL DEFAULT '0' COMMENT '用户ID',
+	`reaction` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL DEFAULT '' COMMENT '表情回应',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE KEY `idx_post_reaction_post_user_reaction` (`post_id`, `user_id`, `reaction`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='冒泡/文章表情回应';
+
+-- ----------------------------
+-- Table structure for p_comment_reaction
+-- ----------------------------
+DROP TABLE IF EXISTS `p_comment_reaction`;
+CREATE TABLE `p_comment_reaction` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT 'ID',
+	`comment_id` BIGINT NOT NULL DEFAULT '0' COMMENT '评论ID',
+	`user_id` BIGINT NOT NULL DEFAULT '0' COMMENT '用户ID',
+	`reaction` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL DEFAULT '' COMMENT '表情回应',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE KEY `idx_comment_reaction_comment_user_reaction` (`comment_id`, `user_id`, `reaction`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='评论表情回应';
+
 -- ----------------------------
 -- Table structure for p_post_star
 -- ----------------------------
@@ -293,6 +537,25 @@ CREATE TABLE `p_post_star` (
 	KEY `idx_post_star_user_id` (`user_id`) USING BTREE
 ) ENGINE=InnoDB AUTO_INCREMENT=6000028 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='冒泡/文章点赞';
 
+-- ----------------------------
+-- Table structure for p_post_trash
+-- ----------------------------
+DROP TABLE IF EXISTS `p_post_trash`;
+CREATE TABLE `p_post_trash` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '回收站ID',
+	`post_id` BIGINT NOT NULL DEFAULT '0' COMMENT 'POST ID',
+	`user_id` BIGINT NOT NULL DEFAULT '0' COMMENT '推文作者ID',
+	`operator_id` BIGINT NOT NULL DEFAULT '0' COMMENT '删除操作者ID，作者本人或管理员',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '恢复或清除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	PRIMARY KEY (`id`) USING BTREE,
+	KEY `idx_post_trash_post_id` (`post_id`) USING BTREE,
+	KEY `idx_post_trash_user_id` (`user_id`) USING BTREE,
+	KEY `idx_post_trash_created_on` (`created_on`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='推文回收站';
+
 -- ----------------------------
 -- Table structure for p_tag
 -- ----------------------------
@@ -345,12 +608,13 @@ CREATE TABLE `p_user` (
 	`nickname` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '昵称',
 	`username` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '用户名',
 	`phone` varchar(16) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '手机号',
-	`password` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT 'MD5密码',
+	`password` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '密码摘要，bcrypt/argon2id摘要自带算法标识，MD5摘要需配合盐值',
 	`salt` varchar(16) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '盐值',
 	`status` tinyint NOT NULL DEFAULT '1' COMMENT '状态，1正常，2停用',
 	`avatar` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '用户头像',
 	`balance` BIGINT NOT NULL COMMENT '用户余额（分）',
 	`is_admin` tinyint NOT NULL DEFAULT '0' COMMENT '是否管理员',
+	`show_sensitive` tinyint NOT NULL DEFAULT '0' COMMENT '是否直接展示敏感内容',
 	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
 	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
 	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
@@ -377,6 +641,84 @@ CREATE TABLE `p_user_metric` (
 	KEY `idx_user_metric_user_id_tweets_count_trends` (`user_id`, `tweets_count`, `latest_trends_on`) USING BTREE
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
 
+-- ----------------------------
+-- Table structure for p_user_sponsor
+-- ----------------------------
+DROP TABLE IF EXISTS `p_user_sponsor`;
+CREATE TABLE `p_user_sponsor` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '付费支持ID',
+	`user_id` BIGINT NOT NULL DEFAULT '0' COMMENT '支持者ID',
+	`creator_id` BIGINT NOT NULL DEFAULT '0' COMMENT '被支持的创作者ID',
+	`charged_amount` BIGINT NOT NULL DEFAULT '0' COMMENT '累计充电金额(分)',
+	`subscribe_expired_on` BIGINT NOT NULL DEFAULT '0' COMMENT '订阅到期时间',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	PRIMARY KEY (`id`) USING BTREE,
+	UNIQUE KEY `idx_user_sponsor_user_creator` (`user_id`, `creator_id`) USING BTREE,
+	KEY `idx_user_sponsor_creator_id` (`creator_id`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='用户对创作者的充电与订阅';
+
+-- ----------------------------
+-- Table structure for p_user_session
+-- ----------------------------
+DROP TABLE IF EXISTS `p_user_session`;
+CREATE TABLE `p_user_session` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '会话ID',
+	`user_id` BIGINT NOT NULL DEFAULT '0' COMMENT '用户ID',
+	`device` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '登录设备',
+	`ip` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '最近访问IP地址',
+	`ip_loc` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '最近访问IP城市地址',
+	`refresh_token` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '刷新令牌的SHA256摘要',
+	`rotated_token` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '上一个已轮换的刷新令牌的SHA256摘要，用于检测重放',
+	`expires_on` BIGINT NOT NULL DEFAULT '0' COMMENT '刷新令牌过期时间',
+	`last_seen_on` BIGINT NOT NULL DEFAULT '0' COMMENT '最近访问时间',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	PRIMARY KEY (`id`) USING BTREE,
+	KEY `idx_user_session_user_id` (`user_id`) USING BTREE,
+	KEY `idx_user_session_refresh_token` (`refresh_token`) USING BTREE,
+	KEY `idx_user_session_rotated_token` (`rotated_token`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='用户登录会话';
+
+-- ----------------------------
+-- Table structure for p_user_otp
+-- ----------------------------
+DROP TABLE IF EXISTS `p_user_otp`;
+CREATE TABLE `p_user_otp` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT 'ID',
+	`user_id` BIGINT NOT NULL DEFAULT '0' COMMENT '用户ID',
+	`secret` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT 'TOTP密钥',
+	`last_counter` BIGINT NOT NULL DEFAULT '0' COMMENT '最近使用的验证码周期，用于防止重放',
+	`enabled_on` BIGINT NOT NULL DEFAULT '0' COMMENT '开启时间，0为待确认',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	PRIMARY KEY (`id`) USING BTREE,
+	KEY `idx_user_otp_user_id` (`user_id`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='用户二次验证';
+
+-- ----------------------------
+-- Table structure for p_user_recovery_code
+-- ----------------------------
+DROP TABLE IF EXISTS `p_user_recovery_code`;
+CREATE TABLE `p_user_recovery_code` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT 'ID',
+	`user_id` BIGINT NOT NULL DEFAULT '0' COMMENT '用户ID',
+	`code` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '恢复码的SHA256摘要',
+	`used_on` BIGINT NOT NULL DEFAULT '0' COMMENT '使用时间，0为未使用',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	PRIMARY KEY (`id`) USING BTREE,
+	KEY `idx_user_recovery_code_user_id` (`user_id`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='用户二次验证的一次性恢复码';
+
 -- ----------------------------
 -- Table structure for p_following
 -- ----------------------------
@@ -431,6 +773,23 @@ CREATE TABLE `p_contact_group` (
 	PRIMARY KEY (`id`) USING BTREE
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='联系人分组';
 
+-- ----------------------------
+-- Table structure for p_post_audience
+-- ----------------------------
+DROP TABLE IF EXISTS `p_post_audience`;
+CREATE TABLE `p_post_audience` (
+	`id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '推文可见分组ID',
+	`post_id` BIGINT NOT NULL DEFAULT '0' COMMENT '推文ID',
+	`group_id` BIGINT NOT NULL DEFAULT '0' COMMENT '联系人分组ID',
+	`created_on` BIGINT NOT NULL DEFAULT '0' COMMENT '创建时间',
+	`modified_on` BIGINT NOT NULL DEFAULT '0' COMMENT '修改时间',
+	`deleted_on` BIGINT NOT NULL DEFAULT '0' COMMENT '删除时间',
+	`is_del` tinyint NOT NULL DEFAULT '0' COMMENT '是否删除 0 为未删除、1 为已删除',
+	PRIMARY KEY (`id`) USING BTREE,
+	KEY `idx_post_audience_post_id` (`post_id`) USING BTREE,
+	KEY `idx_post_audience_group_id` (`group_id`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='分组可见推文的可见分组';
+
 -- ----------------------------
 -- Table structure for p_wallet_recharge
 -- ----------------------------
@@ -47,6 +47,7 @@ CREATE TABLE p_comment (
 	reply_count INT NOT NULL DEFAULT 0, -- 回复数
 	thumbs_up_count INT NOT NULL DEFAULT 0, -- 点赞数
 	thumbs_down_count INT NOT NULL DEFAULT 0, -- 点踩数
+	edited_on BIGINT NOT NULL DEFAULT 0, -- 最后编辑时间
 	created_on BIGINT NOT NULL DEFAULT 0,
 	modified_on BIGINT NOT NULL DEFAULT 0,
 	deleted_on BIGINT NOT NULL DEFAULT 0,
@@ -84,6 +85,7 @@ CREATE TABLE p_comment_reply (
 	ip_loc VARCHAR(64) NOT NULL DEFAULT '',
 	thumbs_up_count int NOT NULL DEFAULT 0, -- 点赞数
 	thumbs_down_count int NOT NULL DEFAULT 0, -- 点踩数
+	edited_on BIGINT NOT NULL DEFAULT 0, -- 最后编辑时间
 	created_on BIGINT NOT NULL DEFAULT 0,
 	modified_on BIGINT NOT NULL DEFAULT 0,
 	deleted_on BIGINT NOT NULL DEFAULT 0,
@@ -91,6 +93,24 @@ CREATE TABLE p_comment_reply (
 );
 CREATE INDEX idx_comment_reply_comment_id ON p_comment_reply USING btree (comment_id);
 
+DROP TABLE IF EXISTS p_comment_revision;
+CREATE TABLE p_comment_revision (
+	id BIGSERIAL PRIMARY KEY,
+	comment_id BIGINT NOT NULL DEFAULT 0,
+	reply_id BIGINT NOT NULL DEFAULT 0, -- 回复ID，0为评论本身的历史内容
+	user_id BIGINT NOT NULL DEFAULT 0,
+	editor_id BIGINT NOT NULL DEFAULT 0, -- 编辑者ID
+	revision BIGINT NOT NULL DEFAULT 0, -- 版本号，从1开始递增
+	content TEXT NOT NULL DEFAULT '',
+	"type" SMALLINT NOT NULL DEFAULT 2, -- 类型，1标题，2文字段落，3图片地址，4视频地址，5语音地址，6链接地址
+	sort SMALLINT NOT NULL DEFAULT 100,
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_comment_revision_comment_reply_revision ON p_comment_revision USING btree (comment_id, reply_id, revision);
+
 CREATE TABLE p_comment_metric (
 	id BIGSERIAL PRIMARY KEY,
 	comment_id BIGINT NOT NULL,
@@ -122,6 +142,40 @@ CREATE TABLE p_tweet_comment_thumbs (
 );
 CREATE INDEX idx_tweet_comment_thumbs_uid_tid ON p_tweet_comment_thumbs USING btree (user_id, tweet_id);
 
+DROP TABLE IF EXISTS p_link_preview;
+CREATE TABLE p_link_preview (
+	id BIGSERIAL PRIMARY KEY,
+	url_hash CHAR(32) NOT NULL DEFAULT '', -- 链接MD5
+	url VARCHAR(2000) NOT NULL DEFAULT '',
+	title VARCHAR(256) NOT NULL DEFAULT '',
+	description VARCHAR(512) NOT NULL DEFAULT '',
+	image VARCHAR(2000) NOT NULL DEFAULT '', -- 预览图
+	site_name VARCHAR(128) NOT NULL DEFAULT '',
+	status SMALLINT NOT NULL DEFAULT 0, -- 抓取状态 1 为成功、2 为失败
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE UNIQUE INDEX idx_link_preview_url_hash ON p_link_preview USING btree (url_hash);
+
+DROP TABLE IF EXISTS p_mention;
+CREATE TABLE p_mention (
+	id BIGSERIAL PRIMARY KEY,
+	user_id BIGINT NOT NULL DEFAULT 0, -- 被@的用户ID
+	author_id BIGINT NOT NULL DEFAULT 0, -- 发起@的用户ID
+	post_id BIGINT NOT NULL DEFAULT 0,
+	comment_id BIGINT NOT NULL DEFAULT 0,
+	reply_id BIGINT NOT NULL DEFAULT 0,
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_mention_user_id ON p_mention USING btree (user_id);
+CREATE INDEX idx_mention_post_id ON p_mention USING btree (post_id);
+CREATE INDEX idx_mention_comment_id ON p_mention USING btree (comment_id);
+
 DROP TABLE IF EXISTS p_message;
 CREATE TABLE p_message (
 	id BIGSERIAL PRIMARY KEY,
@@ -152,7 +206,7 @@ CREATE TABLE p_post (
 	collection_count BIGINT NOT NULL DEFAULT 0,
 	upvote_count BIGINT NOT NULL DEFAULT 0,
 	share_count BIGINT NOT NULL DEFAULT 0,
-	visibility SMALLINT NOT NULL DEFAULT 0, -- 可见性: 0私密 10充电可见 20订阅可见 30保留 40保留 50好友可见 60关注可见 70保留 80保留 90公开
+	visibility SMALLINT NOT NULL DEFAULT 0, -- 可见性: 0私密 10充电可见 20订阅可见 30分组可见 40保留 50好友可见 60关注可见 70保留 80保留 90公开
 	is_top SMALLINT NOT NULL DEFAULT 0, -- 是否置顶
 	is_essence SMALLINT NOT NULL DEFAULT 0, -- 是否精华
 	is_lock SMALLINT NOT NULL DEFAULT 0, -- 是否锁定
@@ -161,6 +215,19 @@ CREATE TABLE p_post (
 	attachment_price BIGINT NOT NULL DEFAULT 0, -- 附件价格(分)
 	ip VARCHAR(64) NOT NULL DEFAULT '', -- IP地址
 	ip_loc VARCHAR(64) NOT NULL DEFAULT '', -- IP城市地址
+	edited_on BIGINT NOT NULL DEFAULT 0, -- 最后编辑时间
+	repost_id BIGINT NOT NULL DEFAULT 0, -- 转发的原动态ID，0为非转发
+	thread_root_id BIGINT NOT NULL DEFAULT 0, -- 所属串推的首条动态ID，0为串推首条或非串推
+	thread_parent_id BIGINT NOT NULL DEFAULT 0, -- 串推中的上一条动态ID
+	thread_count BIGINT NOT NULL DEFAULT 0, -- 串推后续动态数，仅首条有效
+	publish_at BIGINT NOT NULL DEFAULT 0, -- 定时发布时间，0为已发布
+	reaction_count BIGINT NOT NULL DEFAULT 0, -- 表情回应数
+	view_count BIGINT NOT NULL DEFAULT 0, -- 浏览数
+	pinned_on BIGINT NOT NULL DEFAULT 0, -- 个人主页置顶时间，0为未置顶
+	is_sensitive SMALLINT NOT NULL DEFAULT 0, -- 敏感标记 0 为未标记、1 为作者标记、2 为管理员强制标记
+	content_warning VARCHAR(255) NOT NULL DEFAULT '', -- 内容警告
+	expires_at BIGINT NOT NULL DEFAULT 0, -- 限时推文的过期时间，0为不过期
+	reply_policy SMALLINT NOT NULL DEFAULT 0, -- 回复权限 0所有人 1关注者 2好友 3被@的用户 4仅作者
 	created_on BIGINT NOT NULL DEFAULT 0,
 	modified_on BIGINT NOT NULL DEFAULT 0,
 	deleted_on BIGINT NOT NULL DEFAULT 0,
@@ -169,6 +236,10 @@ CREATE TABLE p_post (
 );
 CREATE INDEX idx_post_user_id ON p_post USING btree (user_id);
 CREATE INDEX idx_post_visibility ON p_post USING btree (visibility);
+CREATE INDEX idx_post_repost_id ON p_post USING btree (repost_id);
+CREATE INDEX idx_post_thread_root_id ON p_post USING btree (thread_root_id);
+CREATE INDEX idx_post_publish_at ON p_post USING btree (publish_at);
+CREATE INDEX idx_post_expires_at ON p_post USING btree (expires_at);
 
 DROP TABLE IF EXISTS p_post_metric;
 CREATE TABLE p_post_metric (
@@ -204,6 +275,7 @@ CREATE TABLE p_post_collection (
 	id BIGSERIAL PRIMARY KEY,
 	post_id BIGINT NOT NULL DEFAULT 0,
 	user_id BIGINT NOT NULL DEFAULT 0,
+	folder_id BIGINT NOT NULL DEFAULT 0,
 	created_on BIGINT NOT NULL DEFAULT 0,
 	modified_on BIGINT NOT NULL DEFAULT 0,
 	deleted_on BIGINT NOT NULL DEFAULT 0,
@@ -211,6 +283,22 @@ CREATE TABLE p_post_collection (
 );
 CREATE INDEX idx_post_collection_post_id ON p_post_collection USING btree (post_id);
 CREATE INDEX idx_post_collection_user_id ON p_post_collection USING btree (user_id);
+CREATE INDEX idx_post_collection_folder_id ON p_post_collection USING btree (folder_id);
+
+DROP TABLE IF EXISTS p_post_collection_folder;
+CREATE TABLE p_post_collection_folder (
+	id BIGSERIAL PRIMARY KEY,
+	user_id BIGINT NOT NULL DEFAULT 0,
+	name VARCHAR(64) NOT NULL DEFAULT '', -- 收藏夹名称
+	is_public SMALLINT NOT NULL DEFAULT 0, -- 是否公开 0 为私密、1 为公开
+	is_default SMALLINT NOT NULL DEFAULT 0, -- 是否默认收藏夹
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_post_collection_folder_user_id ON p_post_collection_folder USING btree (user_id);
+CREATE UNIQUE INDEX idx_post_collection_folder_default_user_id ON p_post_collection_folder USING btree (user_id) WHERE is_default = 1 AND is_del = 0;
 
 DROP TABLE IF EXISTS p_post_content;
 CREATE TABLE p_post_content (
@@ -220,6 +308,7 @@ CREATE TABLE p_post_content (
 	content TEXT NOT NULL DEFAULT '',
 	"type" SMALLINT NOT NULL DEFAULT 2, -- 类型，1标题，2文字段落，3图片地址，4视频地址，5语音地址，6链接地址，7附件资源，8收费资源
 	sort SMALLINT NOT NULL DEFAULT 100,
+	is_sensitive SMALLINT NOT NULL DEFAULT 0, -- 是否敏感媒体
 	created_on BIGINT NOT NULL DEFAULT 0,
 	modified_on BIGINT NOT NULL DEFAULT 0,
 	deleted_on BIGINT NOT NULL DEFAULT 0,
@@ -228,6 +317,116 @@ CREATE TABLE p_post_content (
 CREATE INDEX idx_post_content_post_id ON p_post_content USING btree (post_id);
 CREATE INDEX idx_post_content_user_id ON p_post_content USING btree (user_id);
 
+DROP TABLE IF EXISTS p_post_content_revision;
+CREATE TABLE p_post_content_revision (
+	id BIGSERIAL PRIMARY KEY,
+	post_id BIGINT NOT NULL DEFAULT 0,
+	user_id BIGINT NOT NULL DEFAULT 0,
+	revision BIGINT NOT NULL DEFAULT 0, -- 版本号，从1开始递增
+	content TEXT NOT NULL DEFAULT '',
+	"type" SMALLINT NOT NULL DEFAULT 2, -- 类型，1标题，2文字段落，3图片地址，4视频地址，5语音地址，6链接地址，7附件资源，8收费资源
+	sort SMALLINT NOT NULL DEFAULT 100,
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_post_content_revision_post_id_revision ON p_post_content_revision USING btree (post_id, revision);
+
+DROP TABLE IF EXISTS p_post_draft;
+CREATE TABLE p_post_draft (
+	id BIGSERIAL PRIMARY KEY,
+	user_id BIGINT NOT NULL DEFAULT 0,
+	contents TEXT NOT NULL DEFAULT '', -- 内容项，JSON格式
+	tags VARCHAR(255) NOT NULL DEFAULT '',
+	users VARCHAR(1024) NOT NULL DEFAULT '', -- @的用户名
+	visibility SMALLINT NOT NULL DEFAULT 0, -- 可见性: 0私密 10充电可见 20订阅可见 30分组可见 40保留 50好友可见 60关注可见 70保留 80保留 90公开
+	attachment_price BIGINT NOT NULL DEFAULT 0, -- 附件价格(分)
+	is_sensitive SMALLINT NOT NULL DEFAULT 0, -- 是否敏感内容
+	content_warning VARCHAR(255) NOT NULL DEFAULT '', -- 内容警告
+	group_ids VARCHAR(255) NOT NULL DEFAULT '', -- 分组可见的分组ID
+	is_story SMALLINT NOT NULL DEFAULT 0, -- 是否限时推文
+	expires_in BIGINT NOT NULL DEFAULT 0, -- 限时推文的有效时长(秒)
+	reply_policy SMALLINT NOT NULL DEFAULT 0, -- 回复权限 0所有人 1关注者 2好友 3被@的用户 4仅作者
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_post_draft_user_id ON p_post_draft USING btree (user_id);
+CREATE INDEX idx_post_draft_modified_on ON p_post_draft USING btree (modified_on);
+
+DROP TABLE IF EXISTS p_post_poll;
+CREATE TABLE p_post_poll (
+	id BIGSERIAL PRIMARY KEY,
+	post_id BIGINT NOT NULL DEFAULT 0,
+	user_id BIGINT NOT NULL DEFAULT 0,
+	multiple SMALLINT NOT NULL DEFAULT 0, -- 是否多选 0 为单选、1 为多选
+	expires_at BIGINT NOT NULL DEFAULT 0, -- 截止时间，0 为不截止
+	vote_count BIGINT NOT NULL DEFAULT 0, -- 参与投票的人数
+	closed_on BIGINT NOT NULL DEFAULT 0, -- 结束处理时间
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_post_poll_post_id ON p_post_poll USING btree (post_id);
+CREATE INDEX idx_post_poll_expires_at ON p_post_poll USING btree (closed_on, expires_at);
+
+DROP TABLE IF EXISTS p_post_poll_option;
+CREATE TABLE p_post_poll_option (
+	id BIGSERIAL PRIMARY KEY,
+	poll_id BIGINT NOT NULL DEFAULT 0,
+	content VARCHAR(255) NOT NULL DEFAULT '',
+	sort BIGINT NOT NULL DEFAULT 100,
+	vote_count BIGINT NOT NULL DEFAULT 0,
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_post_poll_option_poll_id ON p_post_poll_option USING btree (poll_id);
+
+DROP TABLE IF EXISTS p_post_poll_vote;
+CREATE TABLE p_post_poll_vote (
+	id BIGSERIAL PRIMARY KEY,
+	poll_id BIGINT NOT NULL DEFAULT 0,
+	option_id BIGINT NOT NULL DEFAULT 0,
+	user_id BIGINT NOT NULL DEFAULT 0,
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE UNIQUE INDEX idx_post_poll_vote_poll_option_user ON p_post_poll_vote USING btree (poll_id, option_id, user_id);
+CREATE INDEX idx_post_poll_vote_user_id ON p_post_poll_vote USING btree (user_id);
+
+DROP TABLE IF EXISTS p_post_reaction;
+CREATE TABLE p_post_reaction (
+	id BIGSERIAL PRIMARY KEY,
+	post_id BIGINT NOT NULL DEFAULT 0,
+	user_id BIGINT NOT NULL DEFAULT 0,
+	reaction VARCHAR(32) NOT NULL DEFAULT '', -- 表情回应
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE UNIQUE INDEX idx_post_reaction_post_user_reaction ON p_post_reaction USING btree (post_id, user_id, reaction);
+
+DROP TABLE IF EXISTS p_comment_reaction;
+CREATE TABLE p_comment_reaction (
+	id BIGSERIAL PRIMARY KEY,
+	comment_id BIGINT NOT NULL DEFAULT 0,
+	user_id BIGINT NOT NULL DEFAULT 0,
+	reaction VARCHAR(32) NOT NULL DEFAULT '', -- 表情回应
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE UNIQUE INDEX idx_comment_reaction_comment_user_reaction ON p_comment_reaction USING btree (comment_id, user_id, reaction);
+
 DROP TABLE IF EXISTS p_post_star;
 CREATE TABLE p_post_star (
 	id BIGSERIAL PRIMARY KEY,
@@ -241,6 +440,21 @@ CREATE TABLE p_post_star (
 CREATE INDEX idx_post_star_post_id ON p_post_star USING btree (post_id);
 CREATE INDEX idx_post_star_user_id ON p_post_star USING btree (user_id);
 
+DROP TABLE IF EXISTS p_post_trash;
+CREATE TABLE p_post_trash (
+	id BIGSERIAL PRIMARY KEY,
+	post_id BIGINT NOT NULL DEFAULT 0,
+	user_id BIGINT NOT NULL DEFAULT 0, -- 推文作者ID
+	operator_id BIGINT NOT NULL DEFAULT 0, -- 删除操作者ID，作者本人或管理员
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_post_trash_post_id ON p_post_trash USING btree (post_id);
+CREATE INDEX idx_post_trash_user_id ON p_post_trash USING btree (user_id);
+CREATE INDEX idx_post_trash_created_on ON p_post_trash USING btree (created_on);
+
 DROP TABLE IF EXISTS p_tag;
 CREATE TABLE p_tag (
 	id BIGSERIAL PRIMARY KEY,
@@ -283,12 +497,13 @@ CREATE TABLE p_user (
 	nickname VARCHAR(32) NOT NULL DEFAULT '',
 	username VARCHAR(32) NOT NULL DEFAULT '',
 	phone VARCHAR(16) NOT NULL DEFAULT '', -- 手机号
-	password VARCHAR(32) NOT NULL DEFAULT '', -- MD5密码
+	password VARCHAR(128) NOT NULL DEFAULT '', -- 密码摘要
 	salt VARCHAR(16) NOT NULL DEFAULT '', -- 盐值
 	status SMALLINT NOT NULL DEFAULT 1, -- 状态，1正常，2停用
 	avatar VARCHAR(255) NOT NULL DEFAULT '',
 	balance BIGINT NOT NULL, -- 用户余额（分）
 	is_admin BOOLEAN NOT NULL DEFAULT false, -- 是否管理员
+	show_sensitive BOOLEAN NOT NULL DEFAULT false, -- 是否直接展示敏感内容
 	created_on BIGINT NOT NULL DEFAULT 0,
 	modified_on BIGINT NOT NULL DEFAULT 0,
 	deleted_on BIGINT NOT NULL DEFAULT 0,
@@ -310,6 +525,68 @@ CREATE TABLE p_user_metric (
 );
 CREATE INDEX idx_user_metric_user_id_tweets_count_trends ON p_user_metric USING btree (user_id, tweets_count, latest_trends_on);
 
+DROP TABLE IF EXISTS p_user_sponsor;
+CREATE TABLE p_user_sponsor (
+	id BIGSERIAL PRIMARY KEY,
+	user_id BIGINT NOT NULL DEFAULT 0, -- 支持者ID
+	creator_id BIGINT NOT NULL DEFAULT 0, -- 被支持的创作者ID
+	charged_amount BIGINT NOT NULL DEFAULT 0, -- 累计充电金额(分)
+	subscribe_expired_on BIGINT NOT NULL DEFAULT 0, -- 订阅到期时间
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE UNIQUE INDEX idx_user_sponsor_user_creator ON p_user_sponsor USING btree (user_id, creator_id);
+CREATE INDEX idx_user_sponsor_creator_id ON p_user_sponsor USING btree (creator_id);
+
+DROP TABLE IF EXISTS p_user_session;
+CREATE TABLE p_user_session (
+	id BIGSERIAL PRIMARY KEY,
+	user_id BIGINT NOT NULL DEFAULT 0,
+	device VARCHAR(255) NOT NULL DEFAULT '', -- 登录设备
+	ip VARCHAR(64) NOT NULL DEFAULT '', -- 最近访问IP地址
+	ip_loc VARCHAR(64) NOT NULL DEFAULT '',
+	refresh_token VARCHAR(64) NOT NULL DEFAULT '', -- 刷新令牌的SHA256摘要
+	rotated_token VARCHAR(64) NOT NULL DEFAULT '', -- 上一个已轮换的刷新令牌的SHA256摘要，用于检测重放
+	expires_on BIGINT NOT NULL DEFAULT 0, -- 刷新令牌过期时间
+	last_seen_on BIGINT NOT NULL DEFAULT 0, -- 最近访问时间
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_user_session_user_id ON p_user_session USING btree (user_id);
+CREATE INDEX idx_user_session_refresh_token ON p_user_session USING btree (refresh_token);
+CREATE INDEX idx_user_session_rotated_token ON p_user_session USING btree (rotated_token);
+
+DROP TABLE IF EXISTS p_user_otp;
+CREATE TABLE p_user_otp (
+	id BIGSERIAL PRIMARY KEY,
+	user_id BIGINT NOT NULL DEFAULT 0,
+	secret VARCHAR(64) NOT NULL DEFAULT '', -- TOTP密钥
+	last_counter BIGINT NOT NULL DEFAULT 0, -- 最近使用的验证码周期，用于防止重放
+	enabled_on BIGINT NOT NULL DEFAULT 0, -- 开启时间，0为待确认
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_user_otp_user_id ON p_user_otp USING btree (user_id);
+
+DROP TABLE IF EXISTS p_user_recovery_code;
+CREATE TABLE p_user_recovery_code (
+	id BIGSERIAL PRIMARY KEY,
+	user_id BIGINT NOT NULL DEFAULT 0,
+	code VARCHAR(64) NOT NULL DEFAULT '', -- 恢复码的SHA256摘要
+	used_on BIGINT NOT NULL DEFAULT 0, -- 使用时间，0为未使用
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_user_recovery_code_user_id ON p_user_recovery_code USING btree (user_id);
+
 DROP TABLE IF EXISTS p_following;
 CREATE TABLE p_following (
 	id BIGSERIAL PRIMARY KEY,
@@ -352,6 +629,19 @@ CREATE TABLE p_contact_group (
 	deleted_on BIGINT NOT NULL DEFAULT 0
 );
 
+DROP TABLE IF EXISTS p_post_audience;
+CREATE TABLE p_post_audience (
+	id BIGSERIAL PRIMARY KEY,
+	post_id BIGINT NOT NULL DEFAULT 0, -- 推文ID
+	group_id BIGINT NOT NULL DEFAULT 0, -- 联系人分组ID
+	created_on BIGINT NOT NULL DEFAULT 0,
+	modified_on BIGINT NOT NULL DEFAULT 0,
+	deleted_on BIGINT NOT NULL DEFAULT 0,
+	is_del SMALLINT NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_post_audience_post_id ON p_post_audience USING btree (post_id);
+CREATE INDEX idx_post_audience_group_id ON p_post_audience USING btree (group_id);
+
 DROP TABLE IF EXISTS p_wallet_recharge;
 CREATE TABLE p_wallet_recharge (
 	id BIGSERIAL PRIMARY KEY,
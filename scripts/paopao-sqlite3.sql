@@ -50,6 +50,7 @@ CREATE TABLE "p_comment" (
   "reply_count" int NOT NULL DEFAULT 0, -- 回复数
   "thumbs_up_count" integer NOT NULL DEFAULT 0, -- 点赞数
 	"thumbs_down_count" integer NOT NULL DEFAULT 0, -- 点踩数
+  "edited_on" integer NOT NULL DEFAULT 0, -- 最后编辑时间
   "created_on" integer NOT NULL,
   "modified_on" integer NOT NULL,
   "deleted_on" integer NOT NULL,
@@ -89,6 +90,7 @@ CREATE TABLE "p_comment_reply" (
   "ip_loc" text(64) NOT NULL,
   "thumbs_up_count" integer NOT NULL DEFAULT 0, -- 点赞数
 	"thumbs_down_count" integer NOT NULL DEFAULT 0, -- 点踩数
+  "edited_on" integer NOT NULL DEFAULT 0, -- 最后编辑时间
   "created_on" integer NOT NULL,
   "modified_on" integer NOT NULL,
   "deleted_on" integer NOT NULL,
@@ -96,6 +98,27 @@ CREATE TABLE "p_comment_reply" (
   PRIMARY KEY ("id")
 );
 
+-- ----------------------------
+-- Table structure for p_comment_revision
+-- ----------------------------
+DROP TABLE IF EXISTS "p_comment_revision";
+CREATE TABLE "p_comment_revision" (
+	"id" integer,
+	"comment_id" integer NOT NULL DEFAULT 0,
+	"reply_id" integer NOT NULL DEFAULT 0,
+	"user_id" integer NOT NULL DEFAULT 0,
+	"editor_id" integer NOT NULL DEFAULT 0,
+	"revision" integer NOT NULL DEFAULT 0,
+	"content" text NOT NULL DEFAULT '',
+	"type" integer NOT NULL DEFAULT 2,
+	"sort" integer NOT NULL DEFAULT 100,
+	"created_on" integer NOT NULL DEFAULT 0,
+	"modified_on" integer NOT NULL DEFAULT 0,
+	"deleted_on" integer NOT NULL DEFAULT 0,
+	"is_del" integer NOT NULL DEFAULT 0,
+	PRIMARY KEY ("id")
+);
+
 -- ----------------------------
 -- Table structure for p_comment_metric
 -- ----------------------------
@@ -183,6 +206,59 @@ CREATE TABLE "p_contact_group" (
   PRIMARY KEY ("id")
 );
 
+-- ----------------------------
+-- Table structure for p_post_audience
+-- ----------------------------
+DROP TABLE IF EXISTS "p_post_audience";
+CREATE TABLE "p_post_audience" (
+	"id" integer,
+	"post_id" integer NOT NULL DEFAULT 0,
+	"group_id" integer NOT NULL DEFAULT 0,
+	"created_on" integer NOT NULL DEFAULT 0,
+	"modified_on" integer NOT NULL DEFAULT 0,
+	"deleted_on" integer NOT NULL DEFAULT 0,
+	"is_del" integer NOT NULL DEFAULT 0,
+	PRIMARY KEY ("id")
+);
+
+-- ----------------------------
+-- Table structure for p_link_preview
+-- ----------------------------
+DROP TABLE IF EXISTS "p_link_preview";
+CREATE TABLE "p_link_preview" (
+  "id" integer,
+  "url_hash" text(32) NOT NULL DEFAULT '',
+  "url" text(2000) NOT NULL DEFAULT '',
+  "title" text(256) NOT NULL DEFAULT '',
+  "description" text(512) NOT NULL DEFAULT '',
+  "image" text(2000) NOT NULL DEFAULT '',
+  "site_name" text(128) NOT NULL DEFAULT '',
+  "status" integer NOT NULL DEFAULT 0,
+  "created_on" integer NOT NULL DEFAULT 0,
+  "modified_on" integer NOT NULL DEFAULT 0,
+  "deleted_on" integer NOT NULL DEFAULT 0,
+  "is_del" integer NOT NULL DEFAULT 0,
+  PRIMARY KEY ("id")
+);
+
+-- ----------------------------
+-- Table structure for p_mention
+-- ----------------------------
+DROP TABLE IF EXISTS "p_mention";
+CREATE TABLE "p_mention" (
+  "id" integer,
+  "user_id" integer NOT NULL DEFAULT 0,
+  "author_id" integer NOT NULL DEFAULT 0,
+  "post_id" integer NOT NULL DEFAULT 0,
+  "comment_id" integer NOT NULL DEFAULT 0,
+  "reply_id" integer NOT NULL DEFAULT 0,
+  "created_on" integer NOT NULL DEFAULT 0,
+  "modified_on" integer NOT NULL DEFAULT 0,
+  "deleted_on" integer NOT NULL DEFAULT 0,
+  "is_del" integer NOT NULL DEFAULT 0,
+  PRIMARY KEY ("id")
+);
+
 -- ----------------------------
 -- Table structure for p_message
 -- ----------------------------
@@ -228,7 +304,20 @@ CREATE TABLE "p_post" (
   "modified_on" integer NOT NULL,
   "deleted_on" integer NOT NULL,
   "is_del" integer NOT NULL,
-  "visibility" integer NOT NULL,  -- 可见性: 0私密 10充电可见 20订阅可见 30保留 40保留 50好友可见 60关注可见 70保留 80保留 90公开
+  "visibility" integer NOT NULL,  -- 可见性: 0私密 10充电可见 20订阅可见 30分组可见 40保留 50好友可见 60关注可见 70保留 80保留 90公开
+  "edited_on" integer NOT NULL DEFAULT 0,
+  "repost_id" integer NOT NULL DEFAULT 0,
+  "thread_root_id" integer NOT NULL DEFAULT 0,
+  "thread_parent_id" integer NOT NULL DEFAULT 0,
+  "thread_count" integer NOT NULL DEFAULT 0,
+  "publish_at" integer NOT NULL DEFAULT 0,
+  "reaction_count" integer NOT NULL DEFAULT 0,
+  "view_count" integer NOT NULL DEFAULT 0,
+  "pinned_on" integer NOT NULL DEFAULT 0,
+  "is_sensitive" integer NOT NULL DEFAULT 0,
+  "content_warning" text(255) NOT NULL DEFAULT '',
+  "expires_at" integer NOT NULL DEFAULT 0,
+  "reply_policy" integer NOT NULL DEFAULT 0,
   PRIMARY KEY ("id")
 );
 
@@ -274,6 +363,7 @@ CREATE TABLE "p_post_collection" (
   "id" integer NOT NULL,
   "post_id" integer NOT NULL,
   "user_id" integer NOT NULL,
+  "folder_id" integer NOT NULL DEFAULT 0,
   "created_on" integer NOT NULL,
   "modified_on" integer NOT NULL,
   "deleted_on" integer NOT NULL,
@@ -281,6 +371,23 @@ CREATE TABLE "p_post_collection" (
   PRIMARY KEY ("id")
 );
 
+-- ----------------------------
+-- Table structure for p_post_collection_folder
+-- ----------------------------
+DROP TABLE IF EXISTS "p_post_collection_folder";
+CREATE TABLE "p_post_collection_folder" (
+  "id" integer,
+  "user_id" integer NOT NULL DEFAULT 0,
+  "name" text(64) NOT NULL DEFAULT '',
+  "is_public" integer NOT NULL DEFAULT 0,
+  "is_default" integer NOT NULL DEFAULT 0,
+  "created_on" integer NOT NULL DEFAULT 0,
+  "modified_on" integer NOT NULL DEFAULT 0,
+  "deleted_on" integer NOT NULL DEFAULT 0,
+  "is_del" integer NOT NULL DEFAULT 0,
+  PRIMARY KEY ("id")
+);
+
 -- ----------------------------
 -- Table structure for p_post_content
 -- ----------------------------
@@ -292,6 +399,135 @@ CREATE TABLE "p_post_content" (
   "content" text NOT NULL,
   "type" integer NOT NULL,
   "sort" integer NOT NULL,
+  "is_sensitive" integer NOT NULL DEFAULT 0,
+  "created_on" integer NOT NULL,
+  "modified_on" integer NOT NULL,
+  "deleted_on" integer NOT NULL,
+  "is_del" integer NOT NULL,
+  PRIMARY KEY ("id")
+);
+
+-- ----------------------------
+-- Table structure for p_post_content_revision
+-- ----------------------------
+DROP TABLE IF EXISTS "p_post_content_revision";
+CREATE TABLE "p_post_content_revision" (
+  "id" integer NOT NULL,
+  "post_id" integer NOT NULL,
+  "user_id" integer NOT NULL,
+  "revision" integer NOT NULL,
+  "content" text NOT NULL,
+  "type" integer NOT NULL,
+  "sort" integer NOT NULL,
+  "created_on" integer NOT NULL,
+  "modified_on" integer NOT NULL,
+  "deleted_on" integer NOT NULL,
+  "is_del" integer NOT NULL,
+  PRIMARY KEY ("id")
+);
+
+-- ----------------------------
+-- Table structure for p_post_draft
+-- ----------------------------
+DROP TABLE IF EXISTS "p_post_draft";
+CREATE TABLE "p_post_draft" (
+  "id" integer NOT NULL,
+  "user_id" integer NOT NULL,
+  "contents" text NOT NULL,
+  "tags" text(255) NOT NULL,
+  "users" text(1024) NOT NULL,
+  "visibility" integer NOT NULL,
+  "attachment_price" integer NOT NULL,
+  "is_sensitive" integer NOT NULL DEFAULT 0,
+  "content_warning" text(255) NOT NULL DEFAULT '',
+  "group_ids" text(255) NOT NULL DEFAULT '',
+  "is_story" integer NOT NULL DEFAULT 0,
+  "expires_in" integer NOT NULL DEFAULT 0,
+  "reply_policy" integer NOT NULL DEFAULT 0,
+  "created_on" integer NOT NULL,
+  "modified_on" integer NOT NULL,
+  "deleted_on" integer NOT NULL,
+  "is_del" integer NOT NULL,
+  PRIMARY KEY ("id")
+);
+
+-- ----------------------------
+-- Table structure for p_post_poll
+-- ----------------------------
+DROP TABLE IF EXISTS "p_post_poll";
+CREATE TABLE "p_post_poll" (
+  "id" integer NOT NULL,
+  "post_id" integer NOT NULL,
+  "user_id" integer NOT NULL,
+  "multiple" integer NOT NULL,
+  "expires_at" integer NOT NULL,
+  "vote_count" integer NOT NULL,
+  "closed_on" integer NOT NULL,
+  "created_on" integer NOT NULL,
+  "modified_on" integer NOT NULL,
+  "deleted_on" integer NOT NULL,
+  "is_del" integer NOT NULL,
+  PRIMARY KEY ("id")
+);
+
+-- ----------------------------
+-- Table structure for p_post_poll_option
+-- ----------------------------
+DROP TABLE IF EXISTS "p_post_poll_option";
+CREATE TABLE "p_post_poll_option" (
+  "id" integer NOT NULL,
+  "poll_id" integer NOT NULL,
+  "content" text(255) NOT NULL,
+  "sort" integer NOT NULL,
+  "vote_count" integer NOT NULL,
+  "created_on" integer NOT NULL,
+  "modified_on" integer NOT NULL,
+  "deleted_on" integer NOT NULL,
+  "is_del" integer NOT NULL,
+  PRIMARY KEY ("id")
+);
+
+-- ----------------------------
+-- Table structure for p_post_poll_vote
+-- ----------------------------
+DROP TABLE IF EXISTS "p_post_poll_vote";
+CREATE TABLE "p_post_poll_vote" (
+  "id" integer NOT NULL,
+  "poll_id" integer NOT NULL,
+  "option_id" integer NOT NULL,
+  "user_id" integer NOT NULL,
+  "created_on" integer NOT NULL,
+  "modified_on" integer NOT NULL,
+  "deleted_on" integer NOT NULL,
+  "is_del" integer NOT NULL,
+  PRIMARY KEY ("id")
+);
+
+-- ----------------------------
+-- Table structure for p_post_reaction
+-- ----------------------------
+DROP TABLE IF EXISTS "p_post_reaction";
+CREATE TABLE "p_post_reaction" (
+  "id" integer NOT NULL,
+  "post_id" integer NOT NULL,
+  "user_id" integer NOT NULL,
+  "reaction" text(32) NOT NULL,
+  "created_on" integer NOT NULL,
+  "modified_on" integer NOT NULL,
+  "deleted_on" integer NOT NULL,
+  "is_del" integer NOT NULL,
+  PRIMARY KEY ("id")
+);
+
+-- ----------------------------
+-- Table structure for p_comment_reaction
+-- ----------------------------
+DROP TABLE IF EXISTS "p_comment_reaction";
+CREATE TABLE "p_comment_reaction" (
+  "id" integer NOT NULL,
+  "comment_id" integer NOT NULL,
+  "user_id" integer NOT NULL,
+  "reaction" text(32) NOT NULL,
   "created_on" integer NOT NULL,
   "modified_on" integer NOT NULL,
   "deleted_on" integer NOT NULL,
@@ -314,6 +550,22 @@ CREATE TABLE "p_post_star" (
   PRIMARY KEY ("id")
 );
 
+-- ----------------------------
+-- Table structure for p_post_trash
+-- ----------------------------
+DROP TABLE IF EXISTS "p_post_trash";
+CREATE TABLE "p_post_trash" (
+  "id" integer,
+  "post_id" integer NOT NULL DEFAULT 0,
+  "user_id" integer NOT NULL DEFAULT 0,
+  "operator_id" integer NOT NULL DEFAULT 0,
+  "created_on" integer NOT NULL DEFAULT 0,
+  "modified_on" integer NOT NULL DEFAULT 0,
+  "deleted_on" integer NOT NULL DEFAULT 0,
+  "is_del" integer NOT NULL DEFAULT 0,
+  PRIMARY KEY ("id")
+);
+
 -- ----------------------------
 -- Table structure for p_tag
 -- ----------------------------
@@ -361,12 +613,13 @@ CREATE TABLE "p_user" (
   "nickname" text(32) NOT NULL,
   "username" text(32) NOT NULL,
   "phone" text(16) NOT NULL,
-  "password" text(32) NOT NULL,
+  "password" text(128) NOT NULL,
   "salt" text(16) NOT NULL,
   "status" integer NOT NULL,
   "avatar" text(255) NOT NULL,
   "balance" integer NOT NULL,
   "is_admin" integer NOT NULL,
+  "show_sensitive" integer NOT NULL DEFAULT 0,
   "created_on" integer NOT NULL,
   "modified_on" integer NOT NULL,
   "deleted_on" integer NOT NULL,
@@ -389,6 +642,77 @@ CREATE TABLE "p_user_metric" (
 	PRIMARY KEY ("id")
 );
 
+-- ----------------------------
+-- Table structure for p_user_sponsor
+-- ----------------------------
+DROP TABLE IF EXISTS "p_user_sponsor";
+CREATE TABLE "p_user_sponsor" (
+	"id" integer,
+	"user_id" integer NOT NULL DEFAULT 0,
+	"creator_id" integer NOT NULL DEFAULT 0,
+	"charged_amount" integer NOT NULL DEFAULT 0,
+	"subscribe_expired_on" integer NOT NULL DEFAULT 0,
+	"created_on" integer NOT NULL DEFAULT 0,
+	"modified_on" integer NOT NULL DEFAULT 0,
+	"deleted_on" integer NOT NULL DEFAULT 0,
+	"is_del" integer NOT NULL DEFAULT 0,
+	PRIMARY KEY ("id")
+);
+
+-- ----------------------------
+-- Table structure for p_user_session
+-- ----------------------------
+DROP TABLE IF EXISTS "p_user_session";
+CREATE TABLE "p_user_session" (
+	"id" integer,
+	"user_id" integer NOT NULL DEFAULT 0,
+	"device" text(255) NOT NULL DEFAULT '',
+	"ip" text(64) NOT NULL DEFAULT '',
+	"ip_loc" text(64) NOT NULL DEFAULT '',
+	"refresh_token" text(64) NOT NULL DEFAULT '',
+	"rotated_token" text(64) NOT NULL DEFAULT '',
+	"expires_on" integer NOT NULL DEFAULT 0,
+	"last_seen_on" integer NOT NULL DEFAULT 0,
+	"created_on" integer NOT NULL DEFAULT 0,
+	"modified_on" integer NOT NULL DEFAULT 0,
+	"deleted_on" integer NOT NULL DEFAULT 0,
+	"is_del" integer NOT NULL DEFAULT 0,
+	PRIMARY KEY ("id")
+);
+
+-- ----------------------------
+-- Table structure for p_user_otp
+-- ----------------------------
+DROP TABLE IF EXISTS "p_user_otp";
+CREATE TABLE "p_user_otp" (
+	"id" integer,
+	"user_id" integer NOT NULL DEFAULT 0,
+	"secret" text(64) NOT NULL DEFAULT '',
+	"last_counter" integer NOT NULL DEFAULT 0,
+	"enabled_on" integer NOT NULL DEFAULT 0,
+	"created_on" integer NOT NULL DEFAULT 0,
+	"modified_on" integer NOT NULL DEFAULT 0,
+	"deleted_on" integer NOT NULL DEFAULT 0,
+	"is_del" integer NOT NULL DEFAULT 0,
+	PRIMARY KEY ("id")
+);
+
+-- ----------------------------
+-- Table structure for p_user_recovery_code
+-- ----------------------------
+DROP TABLE IF EXISTS "p_user_recovery_code";
+CREATE TABLE "p_user_recovery_code" (
+	"id" integer,
+	"user_id" integer NOT NULL DEFAULT 0,
+	"code" text(64) NOT NULL DEFAULT '',
+	"used_on" integer NOT NULL DEFAULT 0,
+	"created_on" integer NOT NULL DEFAULT 0,
+	"modified_on" integer NOT NULL DEFAULT 0,
+	"deleted_on" integer NOT NULL DEFAULT 0,
+	"is_del" integer NOT NULL DEFAULT 0,
+	PRIMARY KEY ("id")
+);
+
 -- ----------------------------
 -- Table structure for p_wallet_recharge
 -- ----------------------------
@@ -531,6 +855,16 @@ ON "p_comment_reply" (
   "comment_id" ASC
 );
 
+-- ----------------------------
+-- Indexes structure for table p_comment_revision
+-- ----------------------------
+CREATE INDEX "idx_comment_revision_comment_reply_revision"
+ON "p_comment_revision" (
+	"comment_id" ASC,
+	"reply_id" ASC,
+	"revision" ASC
+);
+
 -- ----------------------------
 -- Indexes structure for table p_comment_metric
 -- ----------------------------
@@ -573,6 +907,32 @@ ON "p_contact" (
   "status" ASC
 );
 
+-- ----------------------------
+-- Indexes structure for table p_link_preview
+-- ----------------------------
+CREATE UNIQUE INDEX "idx_link_preview_url_hash"
+ON "p_link_preview" (
+  "url_hash" ASC
+);
+
+-- ----------------------------
+-- Indexes structure for table p_mention
+-- ----------------------------
+CREATE INDEX "idx_mention_user_id"
+ON "p_mention" (
+  "user_id" ASC
+);
+
+CREATE INDEX "idx_mention_post_id"
+ON "p_mention" (
+  "post_id" ASC
+);
+
+CREATE INDEX "idx_mention_comment_id"
+ON "p_mention" (
+  "comment_id" ASC
+);
+
 -- ----------------------------
 -- Indexes structure for table p_message
 -- ----------------------------
@@ -600,6 +960,22 @@ CREATE INDEX "idx_post_visibility"
 ON "p_post" (
   "visibility" ASC
 );
+CREATE INDEX "idx_post_repost_id"
+ON "p_post" (
+  "repost_id" ASC
+);
+CREATE INDEX "idx_post_thread_root_id"
+ON "p_post" (
+  "thread_root_id" ASC
+);
+CREATE INDEX "idx_post_publish_at"
+ON "p_post" (
+  "publish_at" ASC
+);
+CREATE INDEX "idx_post_expires_at"
+ON "p_post" (
+  "expires_at" ASC
+);
 
 -- ----------------------------
 -- Indexes structure for table idx_post_metric_post_id_rank_score
@@ -633,6 +1009,22 @@ CREATE INDEX "idx_post_collection_user_id"
 ON "p_post_collection" (
   "user_id" ASC
 );
+CREATE INDEX "idx_post_collection_folder_id"
+ON "p_post_collection" (
+  "folder_id" ASC
+);
+
+-- ----------------------------
+-- Indexes structure for table p_post_collection_folder
+-- ----------------------------
+CREATE INDEX "idx_post_collection_folder_user_id"
+ON "p_post_collection_folder" (
+  "user_id" ASC
+);
+CREATE UNIQUE INDEX "idx_post_collection_folder_default_user_id"
+ON "p_post_collection_folder" (
+  "user_id" ASC
+) WHERE "is_default" = 1 AND "is_del" = 0;
 
 -- ----------------------------
 -- Indexes structure for table p_post_content
@@ -646,6 +1038,82 @@ ON "p_post_content" (
   "user_id" ASC
 );
 
+-- ----------------------------
+-- Indexes structure for table p_post_content_revision
+-- ----------------------------
+CREATE INDEX "idx_post_content_revision_post_id_revision"
+ON "p_post_content_revision" (
+  "post_id" ASC,
+  "revision" ASC
+);
+
+-- ----------------------------
+-- Indexes structure for table p_post_draft
+-- ----------------------------
+CREATE INDEX "idx_post_draft_user_id"
+ON "p_post_draft" (
+  "user_id" ASC
+);
+CREATE INDEX "idx_post_draft_modified_on"
+ON "p_post_draft" (
+  "modified_on" ASC
+);
+
+-- ----------------------------
+-- Indexes structure for table p_post_poll
+-- ----------------------------
+CREATE INDEX "idx_post_poll_post_id"
+ON "p_post_poll" (
+  "post_id" ASC
+);
+CREATE INDEX "idx_post_poll_expires_at"
+ON "p_post_poll" (
+  "closed_on" ASC,
+  "expires_at" ASC
+);
+
+-- ----------------------------
+-- Indexes structure for table p_post_poll_option
+-- ----------------------------
+CREATE INDEX "idx_post_poll_option_poll_id"
+ON "p_post_poll_option" (
+  "poll_id" ASC
+);
+
+-- ----------------------------
+-- Indexes structure for table p_post_poll_vote
+-- ----------------------------
+CREATE UNIQUE INDEX "idx_post_poll_vote_poll_option_user"
+ON "p_post_poll_vote" (
+  "poll_id" ASC,
+  "option_id" ASC,
+  "user_id" ASC
+);
+CREATE INDEX "idx_post_poll_vote_user_id"
+ON "p_post_poll_vote" (
+  "user_id" ASC
+);
+
+-- ----------------------------
+-- Indexes structure for table p_post_reaction
+-- ----------------------------
+CREATE UNIQUE INDEX "idx_post_reaction_post_user_reaction"
+ON "p_post_reaction" (
+  "post_id" ASC,
+  "user_id" ASC,
+  "reaction" ASC
+);
+
+-- ----------------------------
+-- Indexes structure for table p_comment_reaction
+-- ----------------------------
+CREATE UNIQUE INDEX "idx_comment_reaction_comment_user_reaction"
+ON "p_comment_reaction" (
+  "comment_id" ASC,
+  "user_id" ASC,
+  "reaction" ASC
+);
+
 -- ----------------------------
 -- Indexes structure for table p_post_star
 -- ----------------------------
@@ -658,6 +1126,22 @@ ON "p_post_star" (
   "user_id" ASC
 );
 
+-- ----------------------------
+-- Indexes structure for table p_post_trash
+-- ----------------------------
+CREATE INDEX "idx_post_trash_post_id"
+ON "p_post_trash" (
+  "post_id" ASC
+);
+CREATE INDEX "idx_post_trash_user_id"
+ON "p_post_trash" (
+  "user_id" ASC
+);
+CREATE INDEX "idx_post_trash_created_on"
+ON "p_post_trash" (
+  "created_on" ASC
+);
+
 -- ----------------------------
 -- Indexes structure for table p_tag
 -- ----------------------------
@@ -710,6 +1194,67 @@ ON "p_user_metric" (
 	"latest_trends_on" ASC
 );
 
+-- ----------------------------
+-- Indexes structure for table p_post_audience
+-- ----------------------------
+CREATE INDEX "idx_post_audience_post_id"
+ON "p_post_audience" (
+	"post_id" ASC
+);
+
+CREATE INDEX "idx_post_audience_group_id"
+ON "p_post_audience" (
+	"group_id" ASC
+);
+
+-- ----------------------------
+-- Indexes structure for table p_user_sponsor
+-- ----------------------------
+CREATE UNIQUE INDEX "idx_user_sponsor_user_creator"
+ON "p_user_sponsor" (
+	"user_id" ASC,
+	"creator_id" ASC
+);
+
+CREATE INDEX "idx_user_sponsor_creator_id"
+ON "p_user_sponsor" (
+	"creator_id" ASC
+);
+
+-- ----------------------------
+-- Indexes structure for table p_user_session
+-- ----------------------------
+CREATE INDEX "idx_user_session_user_id"
+ON "p_user_session" (
+	"user_id" ASC
+);
+
+CREATE INDEX "idx_user_session_refresh_token"
+ON "p_user_session" (
+	"refresh_token" ASC
+);
+
+CREATE INDEX "idx_user_session_rotated_token"
+ON "p_user_session" (
+	"rotated_token" ASC
+);
+
+-- ----------------------------
+-- Indexes structure for table p_user_otp
+-- ----------------------------
+CREATE INDEX "idx_user_otp_user_id"
+ON "p_user_otp" (
+	"user_id" ASC
+);
+
+-- ----------------------------
+-- Indexes structure for table p_user_recovery_code
+-- ----------------------------
+CREATE INDEX "idx_user_recovery_code_user_id"
+ON "p_user_recovery_code" (
+	"user_id" ASC
+);
+
 -- ----------------------------
 -- Indexes structure for table p_wallet_recharge
 -- ----------------------------